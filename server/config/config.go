@@ -1,43 +1,331 @@
 package config
 
 import (
-	"log/slog"
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
 )
 
 var Env Environment
 
-func LoadEnvironment() error {
-	err := godotenv.Load()
+// Sources locate the configuration read on top of the defaults. File is a YAML
+// file, taken from the CONFIG_FILE variable when empty, and Overrides are the
+// variables set on the command line, which take precedence over the
+// environment.
+type Sources struct {
+	File      string
+	Overrides map[string]string
+}
+
+// LoadEnvironment builds the configuration from, in increasing precedence, the
+// defaults of the environment, the configuration file, the environment
+// variables, including the ones of the optional .env file, and the overrides.
+// It fails when a value cannot be parsed or when the configuration is not safe
+// to run in its environment.
+func LoadEnvironment(sources Sources) error {
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to load the .env file: %w", err)
+	}
+
+	content, err := readFile(cmp.Or(sources.File, os.Getenv("CONFIG_FILE")))
 	if err != nil {
-		slog.Error("An error occurred while loading the environment variables", slog.String("error", err.Error()))
 		return err
 	}
 
-	Env = Environment{
-		Env:       getEnv("ENV", "development"),
-		ApiPort:   getEnv("API_PORT", "8080"),
-		SecretKey: getEnv("JWT_SECRET_KEY", ""),
-		DB: Mysql{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "3306"),
-			User:     getEnv("DB_USER", "root"),
-			Password: getEnv("DB_PASSWORD", "root"),
-			Name:     getEnv("DB_NAME", "url-shortener"),
-		},
+	var file struct {
+		Env string `yaml:"env"`
+	}
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return fmt.Errorf("invalid configuration file: %w", err)
+	}
+
+	s := &source{overrides: sources.Overrides}
+	env := Defaults(s.getEnv("ENV", cmp.Or(file.Env, EnvDevelopment)))
+	envName := env.Env
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&env); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid configuration file: %w", err)
+	}
+
+	env.Env = envName
+	env = s.apply(env)
+	if len(s.errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(s.errs...))
 	}
 
-	slog.Info("environment variables loaded successfully")
+	if err := env.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
 
+	Env = env
 	return nil
 }
 
-func getEnv(key, defaultValue string) string {
-	if v := os.Getenv(key); v != "" {
+// readFile returns the content of the configuration file, or nothing when no
+// file is configured.
+func readFile(path string) ([]byte, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the configuration file: %w", err)
+	}
+
+	return content, nil
+}
+
+// source reads the environment variables and the overrides, keeping the
+// values that cannot be parsed as errors instead of falling back to the
+// defaults, which would hide a typo in a production setting.
+type source struct {
+	overrides map[string]string
+	errs      []error
+}
+
+// apply returns env with the settings found in the environment.
+func (s *source) apply(env Environment) Environment {
+	apiURL := s.getEnv("API_URL", env.ApiURL)
+
+	return Environment{
+		Env:             env.Env,
+		ApiPort:         s.getEnv("API_PORT", env.ApiPort),
+		ShutdownTimeout: s.getDurationEnv("SHUTDOWN_TIMEOUT", env.ShutdownTimeout),
+		SecretKey:       s.getEnv("JWT_SECRET_KEY", env.SecretKey),
+		AppURL:          s.getEnv("APP_URL", env.AppURL),
+		ApiURL:          apiURL,
+		TrustedProxies:  s.getListEnv("TRUSTED_PROXIES", env.TrustedProxies),
+		DB: Postgres{
+			Host:     s.getEnv("DB_HOST", env.DB.Host),
+			Port:     s.getEnv("DB_PORT", env.DB.Port),
+			User:     s.getEnv("DB_USER", env.DB.User),
+			Password: s.getEnv("DB_PASSWORD", env.DB.Password),
+			Name:     s.getEnv("DB_NAME", env.DB.Name),
+		},
+		Jwt: Jwt{
+			Algorithm:        s.getEnv("JWT_ALGORITHM", env.Jwt.Algorithm),
+			KeysDir:          s.getEnv("JWT_KEYS_DIR", env.Jwt.KeysDir),
+			RotationInterval: s.getDurationEnv("JWT_KEY_ROTATION_INTERVAL", env.Jwt.RotationInterval),
+			KeyRetention:     s.getDurationEnv("JWT_KEY_RETENTION", env.Jwt.KeyRetention),
+		},
+		Mail: Mail{
+			Driver:   s.getEnv("MAIL_DRIVER", env.Mail.Driver),
+			Host:     s.getEnv("MAIL_HOST", env.Mail.Host),
+			Port:     s.getEnv("MAIL_PORT", env.Mail.Port),
+			Username: s.getEnv("MAIL_USERNAME", env.Mail.Username),
+			Password: s.getEnv("MAIL_PASSWORD", env.Mail.Password),
+			From:     s.getEnv("MAIL_FROM", env.Mail.From),
+			Dir:      s.getEnv("MAIL_DIR", env.Mail.Dir),
+		},
+		EmailVerification: EmailVerification{
+			TokenDuration:  s.getDurationEnv("EMAIL_VERIFICATION_TOKEN_DURATION", env.EmailVerification.TokenDuration),
+			ResendCooldown: s.getDurationEnv("EMAIL_VERIFICATION_RESEND_COOLDOWN", env.EmailVerification.ResendCooldown),
+			RequiredFor:    s.getListEnv("EMAIL_VERIFICATION_REQUIRED_FOR", env.EmailVerification.RequiredFor),
+		},
+		TwoFactor: TwoFactor{
+			Issuer:            s.getEnv("TOTP_ISSUER", env.TwoFactor.Issuer),
+			RequiredForAdmins: s.getBoolEnv("TOTP_REQUIRED_FOR_ADMINS", env.TwoFactor.RequiredForAdmins),
+		},
+		LoginProtection: LoginProtection{
+			Store:              s.getEnv("LOGIN_PROTECTION_STORE", env.LoginProtection.Store),
+			MaxAccountFailures: s.getIntEnv("LOGIN_MAX_ACCOUNT_FAILURES", env.LoginProtection.MaxAccountFailures),
+			MaxIPFailures:      s.getIntEnv("LOGIN_MAX_IP_FAILURES", env.LoginProtection.MaxIPFailures),
+			FailureWindow:      s.getDurationEnv("LOGIN_FAILURE_WINDOW", env.LoginProtection.FailureWindow),
+			BaseLockout:        s.getDurationEnv("LOGIN_BASE_LOCKOUT", env.LoginProtection.BaseLockout),
+			MaxLockout:         s.getDurationEnv("LOGIN_MAX_LOCKOUT", env.LoginProtection.MaxLockout),
+		},
+		OIDC: OIDC{
+			StateDuration: s.getDurationEnv("OIDC_STATE_DURATION", env.OIDC.StateDuration),
+			Providers:     s.getOIDCProviders(apiURL, env.OIDC.Providers),
+		},
+		Authorization: Authorization{
+			AccessCacheTTL: s.getDurationEnv("AUTHORIZATION_ACCESS_CACHE_TTL", env.Authorization.AccessCacheTTL),
+		},
+		Cookie: Cookie{
+			Secure:   s.getBoolEnv("COOKIE_SECURE", env.Cookie.Secure),
+			Domain:   s.getEnv("COOKIE_DOMAIN", env.Cookie.Domain),
+			SameSite: s.getEnv("COOKIE_SAME_SITE", env.Cookie.SameSite),
+			Lifetime: s.getDurationEnv("COOKIE_LIFETIME", env.Cookie.Lifetime),
+		},
+		Seed: Seed{
+			AdminEmail:    s.getEnv("SEED_ADMIN_EMAIL", env.Seed.AdminEmail),
+			AdminPassword: s.getEnv("SEED_ADMIN_PASSWORD", env.Seed.AdminPassword),
+			AdminUsername: s.getEnv("SEED_ADMIN_USERNAME", env.Seed.AdminUsername),
+		},
+		Health: Health{
+			CheckTimeout: s.getDurationEnv("HEALTH_CHECK_TIMEOUT", env.Health.CheckTimeout),
+		},
+		Metrics: Metrics{
+			Port: s.getEnv("METRICS_PORT", env.Metrics.Port),
+		},
+		Tracing: Tracing{
+			Exporter:    s.getEnv("TRACING_EXPORTER", env.Tracing.Exporter),
+			File:        s.getEnv("TRACING_FILE", env.Tracing.File),
+			ServiceName: s.getEnv("OTEL_SERVICE_NAME", env.Tracing.ServiceName),
+			SampleRatio: s.getFloatEnv("TRACING_SAMPLE_RATIO", env.Tracing.SampleRatio),
+		},
+		Log: Log{
+			Level:      s.getEnv("LOG_LEVEL", env.Log.Level),
+			Format:     s.getEnv("LOG_FORMAT", env.Log.Format),
+			Output:     s.getEnv("LOG_OUTPUT", env.Log.Output),
+			File:       s.getEnv("LOG_FILE", env.Log.File),
+			MaxSizeMB:  s.getIntEnv("LOG_MAX_SIZE_MB", env.Log.MaxSizeMB),
+			MaxBackups: s.getIntEnv("LOG_MAX_BACKUPS", env.Log.MaxBackups),
+			MaxAgeDays: s.getIntEnv("LOG_MAX_AGE_DAYS", env.Log.MaxAgeDays),
+			Compress:   s.getBoolEnv("LOG_COMPRESS", env.Log.Compress),
+			GormLevel:  s.getEnv("DB_LOG_LEVEL", env.Log.GormLevel),
+		},
+	}
+}
+
+// getOIDCProviders returns the providers listed in OIDC_PROVIDERS, or the ones
+// of the configuration file when it is not set. Each provider is configured by
+// variables prefixed with its upper-cased name, for example
+// OIDC_GOOGLE_CLIENT_ID for the "google" provider, which take precedence over
+// the file.
+func (s *source) getOIDCProviders(apiURL string, configured []OIDCProvider) []OIDCProvider {
+	names := []string{}
+	for _, provider := range configured {
+		names = append(names, provider.Name)
+	}
+
+	providers := []OIDCProvider{}
+	for _, name := range s.getListEnv("OIDC_PROVIDERS", names) {
+		provider := OIDCProvider{Name: name}
+		if i := slices.IndexFunc(configured, func(p OIDCProvider) bool { return p.Name == name }); i >= 0 {
+			provider = configured[i]
+		}
+
+		if len(provider.Scopes) == 0 {
+			provider.Scopes = []string{"openid", "email", "profile"}
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		providers = append(providers, OIDCProvider{
+			Name:         name,
+			IssuerURL:    s.getEnv(prefix+"ISSUER_URL", provider.IssuerURL),
+			ClientID:     s.getEnv(prefix+"CLIENT_ID", provider.ClientID),
+			ClientSecret: s.getEnv(prefix+"CLIENT_SECRET", provider.ClientSecret),
+			RedirectURL:  s.getEnv(prefix+"REDIRECT_URL", cmp.Or(provider.RedirectURL, apiURL+"/auth/oidc/"+name+"/callback")),
+			Scopes:       s.getListEnv(prefix+"SCOPES", provider.Scopes),
+		})
+	}
+
+	return providers
+}
+
+// lookup returns the value of the variable, the overrides taking precedence
+// over the environment. Empty variables are ignored.
+func (s *source) lookup(key string) string {
+	if v, ok := s.overrides[key]; ok {
+		return v
+	}
+
+	return os.Getenv(key)
+}
+
+func (s *source) invalid(key, kind, value string) {
+	s.errs = append(s.errs, fmt.Errorf("%s must be a %s, got %q", key, kind, value))
+}
+
+func (s *source) getEnv(key, defaultValue string) string {
+	if v := s.lookup(key); v != "" {
 		return v
 	}
 
 	return defaultValue
 }
+
+func (s *source) getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	v := s.lookup(key)
+	if v == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(v)
+	if err != nil {
+		s.invalid(key, "duration", v)
+		return defaultValue
+	}
+
+	return duration
+}
+
+func (s *source) getIntEnv(key string, defaultValue int) int {
+	v := s.lookup(key)
+	if v == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.Atoi(v)
+	if err != nil {
+		s.invalid(key, "integer", v)
+		return defaultValue
+	}
+
+	return value
+}
+
+func (s *source) getFloatEnv(key string, defaultValue float64) float64 {
+	v := s.lookup(key)
+	if v == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		s.invalid(key, "number", v)
+		return defaultValue
+	}
+
+	return value
+}
+
+func (s *source) getBoolEnv(key string, defaultValue bool) bool {
+	v := s.lookup(key)
+	if v == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseBool(v)
+	if err != nil {
+		s.invalid(key, "boolean", v)
+		return defaultValue
+	}
+
+	return value
+}
+
+// getListEnv reads a comma-separated list. Unlike the other variables, a list
+// set to an empty value is kept, so that a default list can be emptied.
+func (s *source) getListEnv(key string, defaultValue []string) []string {
+	v, ok := s.overrides[key]
+	if !ok {
+		if v, ok = os.LookupEnv(key); !ok {
+			return defaultValue
+		}
+	}
+
+	values := []string{}
+	for _, value := range strings.Split(v, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,105 @@
+package config
+
+import "time"
+
+const (
+	EnvDevelopment = "development"
+	EnvTest        = "test"
+	EnvStaging     = "staging"
+	EnvProduction  = "production"
+)
+
+// AccessTokenDuration is how long an access token is valid, and so how long
+// the key that signed it must be kept for verification after a rotation.
+const AccessTokenDuration = 15 * time.Minute
+
+// Defaults returns the configuration used for the settings that are neither in
+// the configuration file nor in the environment. They favour readable logs in
+// development and secure cookies and structured, quieter logs elsewhere. Mails
+// are written to files in development so their links can be followed. The JWT
+// secret has no default, so it must always be set.
+func Defaults(env string) Environment {
+	logLevel, logFormat, gormLevel := "info", "json", "error"
+	mailDriver := "memory"
+	if env == EnvDevelopment {
+		logLevel, logFormat, gormLevel = "debug", "text", "warn"
+		mailDriver = "file"
+	}
+
+	return Environment{
+		Env:             env,
+		ApiPort:         "8080",
+		ShutdownTimeout: 15 * time.Second,
+		AppURL:          "http://localhost:3000",
+		ApiURL:          "http://localhost:8080",
+		TrustedProxies:  []string{},
+		DB: Postgres{
+			Host: "localhost",
+			Port: "5432",
+			User: "postgres",
+			Name: "my_game_list",
+		},
+		Jwt: Jwt{
+			Algorithm:        "HS256",
+			RotationInterval: 30 * 24 * time.Hour,
+			KeyRetention:     24 * time.Hour,
+		},
+		Mail: Mail{
+			Driver: mailDriver,
+			Host:   "localhost",
+			Port:   "1025",
+			From:   "no-reply@mygamelist.local",
+			Dir:    "mails",
+		},
+		EmailVerification: EmailVerification{
+			TokenDuration:  24 * time.Hour,
+			ResendCooldown: 5 * time.Minute,
+			RequiredFor:    []string{"create_list"},
+		},
+		TwoFactor: TwoFactor{
+			Issuer: "My Game List",
+		},
+		LoginProtection: LoginProtection{
+			Store:              "postgres",
+			MaxAccountFailures: 5,
+			MaxIPFailures:      20,
+			FailureWindow:      15 * time.Minute,
+			BaseLockout:        30 * time.Second,
+			MaxLockout:         time.Hour,
+		},
+		OIDC: OIDC{
+			StateDuration: 10 * time.Minute,
+			Providers:     []OIDCProvider{},
+		},
+		Authorization: Authorization{
+			AccessCacheTTL: 5 * time.Minute,
+		},
+		Cookie: Cookie{
+			Secure:   env == EnvProduction,
+			SameSite: "strict",
+		},
+		Seed: Seed{
+			AdminUsername: "admin",
+		},
+		Health: Health{
+			CheckTimeout: 2 * time.Second,
+		},
+		Tracing: Tracing{
+			Exporter:    "none",
+			File:        "traces.jsonl",
+			ServiceName: "my-game-list",
+			SampleRatio: 1,
+		},
+		Log: Log{
+			Level:      logLevel,
+			Format:     logFormat,
+			Output:     "stdout",
+			File:       "logs/api.log",
+			MaxSizeMB:  100,
+			MaxBackups: 5,
+			MaxAgeDays: 30,
+			Compress:   true,
+			GormLevel:  gormLevel,
+		},
+	}
+}
@@ -1,16 +1,147 @@
 package config
 
+import "time"
+
+// Environment is the configuration of the application. The yaml keys are the
+// ones read from the configuration file, and the environment variables that
+// override them are listed in config.go.
 type Environment struct {
-	Env       string
-	ApiPort   string
-	SecretKey string
-	DB        Mysql
-}
-
-type Mysql struct {
-	Host     string
-	Port     string
-	User     string
-	Password string
-	Name     string
+	Env               string            `yaml:"env"`
+	ApiPort           string            `yaml:"api_port"`
+	ShutdownTimeout   time.Duration     `yaml:"shutdown_timeout"`
+	SecretKey         string            `yaml:"jwt_secret_key"`
+	AppURL            string            `yaml:"app_url"`
+	ApiURL            string            `yaml:"api_url"`
+	TrustedProxies    []string          `yaml:"trusted_proxies"`
+	DB                Postgres          `yaml:"database"`
+	Jwt               Jwt               `yaml:"jwt"`
+	Mail              Mail              `yaml:"mail"`
+	EmailVerification EmailVerification `yaml:"email_verification"`
+	TwoFactor         TwoFactor         `yaml:"two_factor"`
+	LoginProtection   LoginProtection   `yaml:"login_protection"`
+	OIDC              OIDC              `yaml:"oidc"`
+	Authorization     Authorization     `yaml:"authorization"`
+	Cookie            Cookie            `yaml:"cookie"`
+	Seed              Seed              `yaml:"seed"`
+	Health            Health            `yaml:"health"`
+	Metrics           Metrics           `yaml:"metrics"`
+	Tracing           Tracing           `yaml:"tracing"`
+	Log               Log               `yaml:"log"`
+}
+
+// Postgres holds the connection settings of the PostgreSQL database.
+type Postgres struct {
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	Name     string `yaml:"name"`
+}
+
+type Jwt struct {
+	Algorithm        string        `yaml:"algorithm"`
+	KeysDir          string        `yaml:"keys_dir"`
+	RotationInterval time.Duration `yaml:"rotation_interval"`
+	KeyRetention     time.Duration `yaml:"key_retention"`
+}
+
+type Mail struct {
+	Driver   string `yaml:"driver"`
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	From     string `yaml:"from"`
+	Dir      string `yaml:"dir"`
+}
+
+type EmailVerification struct {
+	TokenDuration  time.Duration `yaml:"token_duration"`
+	ResendCooldown time.Duration `yaml:"resend_cooldown"`
+	RequiredFor    []string      `yaml:"required_for"`
+}
+
+type TwoFactor struct {
+	Issuer            string `yaml:"issuer"`
+	RequiredForAdmins bool   `yaml:"required_for_admins"`
+}
+
+type LoginProtection struct {
+	Store              string        `yaml:"store"`
+	MaxAccountFailures int           `yaml:"max_account_failures"`
+	MaxIPFailures      int           `yaml:"max_ip_failures"`
+	FailureWindow      time.Duration `yaml:"failure_window"`
+	BaseLockout        time.Duration `yaml:"base_lockout"`
+	MaxLockout         time.Duration `yaml:"max_lockout"`
+}
+
+type OIDC struct {
+	StateDuration time.Duration  `yaml:"state_duration"`
+	Providers     []OIDCProvider `yaml:"providers"`
+}
+
+type OIDCProvider struct {
+	Name         string   `yaml:"name"`
+	IssuerURL    string   `yaml:"issuer_url"`
+	ClientID     string   `yaml:"client_id"`
+	ClientSecret string   `yaml:"client_secret"`
+	RedirectURL  string   `yaml:"redirect_url"`
+	Scopes       []string `yaml:"scopes"`
+}
+
+type Authorization struct {
+	AccessCacheTTL time.Duration `yaml:"access_cache_ttl"`
+}
+
+// Cookie holds the attributes of the cookies set by the API. A zero Lifetime
+// keeps the session cookies until the token they carry expires.
+type Cookie struct {
+	Secure   bool          `yaml:"secure"`
+	Domain   string        `yaml:"domain"`
+	SameSite string        `yaml:"same_site"`
+	Lifetime time.Duration `yaml:"lifetime"`
+}
+
+// Seed holds the administrator account created by the seed command. No account
+// is created when AdminEmail is empty.
+type Seed struct {
+	AdminEmail    string `yaml:"admin_email"`
+	AdminPassword string `yaml:"admin_password"`
+	AdminUsername string `yaml:"admin_username"`
+}
+
+type Health struct {
+	CheckTimeout time.Duration `yaml:"check_timeout"`
+}
+
+// Metrics holds where the Prometheus metrics are exposed. They are served on
+// the API port when Port is empty, otherwise on a separate admin port that can
+// be kept out of reach of the public.
+type Metrics struct {
+	Port string `yaml:"port"`
+}
+
+// Tracing selects where the spans are exported: "none", "stdout", "file" or
+// "otlp". The OTLP exporter is configured by the standard OTEL_EXPORTER_OTLP_*
+// variables, such as OTEL_EXPORTER_OTLP_ENDPOINT.
+type Tracing struct {
+	Exporter    string  `yaml:"exporter"`
+	File        string  `yaml:"file"`
+	ServiceName string  `yaml:"service_name"`
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+// Log configures the application logs. Output is "stdout", "stderr" or "file",
+// in which case the file is rotated once it reaches MaxSizeMB. GormLevel is
+// one of "silent", "error", "warn" or "info", the latter logging every query.
+type Log struct {
+	Level      string `yaml:"level"`
+	Format     string `yaml:"format"`
+	Output     string `yaml:"output"`
+	File       string `yaml:"file"`
+	MaxSizeMB  int    `yaml:"max_size_mb"`
+	MaxBackups int    `yaml:"max_backups"`
+	MaxAgeDays int    `yaml:"max_age_days"`
+	Compress   bool   `yaml:"compress"`
+	GormLevel  string `yaml:"gorm_level"`
 }
@@ -0,0 +1,30 @@
+package config
+
+import "slices"
+
+const masked = "********"
+
+// Masked returns a copy of the configuration whose passwords and secrets are
+// replaced, so that it can be printed or logged. The secrets that are not set
+// are left empty, which tells them apart from the ones that are.
+func (e Environment) Masked() Environment {
+	e.SecretKey = mask(e.SecretKey)
+	e.DB.Password = mask(e.DB.Password)
+	e.Mail.Password = mask(e.Mail.Password)
+	e.Seed.AdminPassword = mask(e.Seed.AdminPassword)
+
+	e.OIDC.Providers = slices.Clone(e.OIDC.Providers)
+	for i := range e.OIDC.Providers {
+		e.OIDC.Providers[i].ClientSecret = mask(e.OIDC.Providers[i].ClientSecret)
+	}
+
+	return e
+}
+
+func mask(secret string) string {
+	if secret == "" {
+		return ""
+	}
+
+	return masked
+}
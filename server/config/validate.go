@@ -0,0 +1,149 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// minSecretKeyLength is the length required of the JWT secret in production,
+// 32 bytes being the size of the HMAC-SHA256 key.
+const minSecretKeyLength = 32
+
+// weakSecretKeys are placeholders found in examples and tutorials, which are
+// refused in production whatever their length.
+var weakSecretKeys = []string{
+	"secret",
+	"changeme",
+	"change-me",
+	"password",
+	"jwt-secret",
+	"jwt_secret",
+	"my-secret-key",
+	"your-secret-key",
+	"supersecret",
+}
+
+// verifiedActions are the actions that can be restricted to verified emails,
+// one per entities.VerifiedAction.
+var verifiedActions = []string{"create_list"}
+
+// Validate reports every setting that is missing or invalid. The settings that
+// only matter to a public deployment, such as a strong JWT secret, secure
+// cookies and a real mail driver, are only required in production.
+func (e Environment) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(slices.Contains([]string{EnvDevelopment, EnvTest, EnvStaging, EnvProduction}, e.Env),
+		"ENV must be one of %s, %s, %s or %s, got %q", EnvDevelopment, EnvTest, EnvStaging, EnvProduction, e.Env)
+	check(isPort(e.ApiPort), "API_PORT must be a port number, got %q", e.ApiPort)
+	check(e.Metrics.Port == "" || isPort(e.Metrics.Port), "METRICS_PORT must be a port number, got %q", e.Metrics.Port)
+	check(e.Metrics.Port != e.ApiPort, "METRICS_PORT must differ from API_PORT, or be empty to serve the metrics on the API port")
+	check(e.ShutdownTimeout > 0, "SHUTDOWN_TIMEOUT must be positive")
+	for _, proxy := range e.TrustedProxies {
+		_, _, err := net.ParseCIDR(proxy)
+		check(err == nil, "TRUSTED_PROXIES must list IP ranges in CIDR notation, got %q", proxy)
+	}
+
+	check(e.DB.Host != "", "DB_HOST is required")
+	check(isPort(e.DB.Port), "DB_PORT must be a port number, got %q", e.DB.Port)
+	check(e.DB.User != "", "DB_USER is required")
+	check(e.DB.Name != "", "DB_NAME is required")
+
+	check(isURL(e.ApiURL), "API_URL must be an absolute URL, got %q", e.ApiURL)
+
+	if e.Jwt.KeysDir == "" {
+		check(e.SecretKey != "", "JWT_SECRET_KEY is required when JWT_KEYS_DIR is not set")
+	} else {
+		check(e.Jwt.RotationInterval > 0, "JWT_KEY_ROTATION_INTERVAL must be positive")
+		check(e.Jwt.KeyRetention >= AccessTokenDuration,
+			"JWT_KEY_RETENTION must be at least the access token lifetime of %s, so the tokens signed by a rotated key stay valid", AccessTokenDuration)
+	}
+
+	for _, action := range e.EmailVerification.RequiredFor {
+		check(slices.Contains(verifiedActions, action),
+			"EMAIL_VERIFICATION_REQUIRED_FOR must only list %s, got %q", strings.Join(verifiedActions, ", "), action)
+	}
+
+	check(slices.Contains([]string{"strict", "lax", "none"}, strings.ToLower(e.Cookie.SameSite)),
+		"COOKIE_SAME_SITE must be strict, lax or none, got %q", e.Cookie.SameSite)
+	check(!strings.EqualFold(e.Cookie.SameSite, "none") || e.Cookie.Secure, "COOKIE_SECURE is required when COOKIE_SAME_SITE is none")
+	check(e.Tracing.SampleRatio >= 0 && e.Tracing.SampleRatio <= 1, "TRACING_SAMPLE_RATIO must be between 0 and 1")
+
+	for _, provider := range e.OIDC.Providers {
+		check(provider.IssuerURL != "" && provider.ClientID != "", "the issuer URL and client ID of the OIDC provider %q are required", provider.Name)
+	}
+
+	if e.Env == EnvProduction {
+		if e.Jwt.KeysDir == "" && e.SecretKey != "" {
+			check(!isWeakSecretKey(e.SecretKey), "JWT_SECRET_KEY must be a random value of at least %d characters in production", minSecretKeyLength)
+		}
+
+		check(e.DB.Password != "", "DB_PASSWORD is required in production")
+		check(isPublicHTTPSURL(e.ApiURL), "API_URL must be the public HTTPS URL of the API in production, got %q", e.ApiURL)
+		check(e.Cookie.Secure, "COOKIE_SECURE cannot be disabled in production")
+		check(e.Mail.Driver == "smtp", "MAIL_DRIVER must be smtp in production, got %q", e.Mail.Driver)
+	} else if e.SecretKey != "" && isWeakSecretKey(e.SecretKey) {
+		slog.Warn("The JWT secret is weak and would be refused in production")
+	}
+
+	return errors.Join(errs...)
+}
+
+func isURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+// isPublicHTTPSURL reports whether the URL can be reached by the browsers and
+// identity providers, which rules out the local default.
+func isPublicHTTPSURL(value string) bool {
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme != "https" {
+		return false
+	}
+
+	host := u.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		return !ip.IsLoopback() && !ip.IsUnspecified()
+	}
+
+	return host != "" && host != "localhost"
+}
+
+func isPort(value string) bool {
+	port, err := strconv.Atoi(value)
+	return err == nil && port > 0 && port <= 65535
+}
+
+// isWeakSecretKey reports whether the secret is short, a known placeholder or
+// made of a handful of repeated characters.
+func isWeakSecretKey(secret string) bool {
+	if len(secret) < minSecretKeyLength {
+		return true
+	}
+
+	lower := strings.ToLower(secret)
+	for _, weak := range weakSecretKeys {
+		if strings.Contains(lower, weak) {
+			return true
+		}
+	}
+
+	distinct := map[rune]bool{}
+	for _, r := range secret {
+		distinct[r] = true
+	}
+
+	return len(distinct) < 8
+}
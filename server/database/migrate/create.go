@@ -0,0 +1,49 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	migrationNameSeparators = regexp.MustCompile(`[^a-z0-9]+`)
+
+	ErrInvalidName = errors.New("migration name must contain letters or digits")
+)
+
+// Create writes empty up and down files for a new migration in dir, numbered
+// after the last migration found there, and returns their paths.
+func Create(dir, name string) (string, string, error) {
+	name = strings.Trim(migrationNameSeparators.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return "", "", ErrInvalidName
+	}
+
+	migrations, err := Load(os.DirFS(dir))
+	if err != nil {
+		return "", "", err
+	}
+
+	var version int64 = 1
+	if len(migrations) > 0 {
+		version = migrations[len(migrations)-1].Version + 1
+	}
+
+	base := fmt.Sprintf("%04d_%s", version, name)
+	upPath := filepath.Join(dir, base+".up.sql")
+	downPath := filepath.Join(dir, base+".down.sql")
+
+	if err := os.WriteFile(upPath, []byte("-- Write the migration here.\n"), 0o644); err != nil {
+		return "", "", err
+	}
+
+	if err := os.WriteFile(downPath, []byte("-- Write the statements that revert the migration here.\n"), 0o644); err != nil {
+		return "", "", err
+	}
+
+	return upPath, downPath, nil
+}
@@ -0,0 +1,308 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// lockKey identifies the advisory lock held while migrations run, so that two
+// instances starting at the same time do not apply the same migration.
+const lockKey int64 = 0x6d676c5f6d6967
+
+const createTableSQL = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version bigint PRIMARY KEY,
+    name varchar(255) NOT NULL,
+    checksum char(64) NOT NULL,
+    applied_at timestamptz NOT NULL DEFAULT now()
+)`
+
+var (
+	ErrChecksumMismatch = errors.New("applied migration was modified")
+	ErrUnknownMigration = errors.New("applied migration is missing from the migration files")
+	ErrInvalidSteps     = errors.New("the number of migrations to revert must be positive")
+)
+
+// Status describes a migration file, an applied migration, or both.
+type Status struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+	Modified  bool
+	Missing   bool
+}
+
+type appliedMigration struct {
+	Version   int64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []*Migration
+	logger     *slog.Logger
+}
+
+func NewMigrator(db *gorm.DB, migrations []*Migration, logger *slog.Logger) *Migrator {
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+		logger:     logger.With(slog.String("database", "migrator")),
+	}
+}
+
+// Up applies every pending migration in order, each one in its own
+// transaction, and returns the migrations it applied.
+func (m *Migrator) Up(ctx context.Context) ([]*Migration, error) {
+	log := m.logger.With(slog.String("func", "Up"))
+
+	var applied []*Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		appliedMigrations, err := m.verify(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := appliedMigrations[migration.Version]; ok {
+				continue
+			}
+
+			if err := m.apply(ctx, conn, migration); err != nil {
+				log.Error("Failed to apply migration",
+					slog.Int64("version", migration.Version),
+					slog.String("error", err.Error()),
+				)
+				return fmt.Errorf("apply %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down reverts the last steps applied migrations, newest first, and returns
+// the migrations it reverted.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	log := m.logger.With(slog.String("func", "Down"))
+
+	if steps <= 0 {
+		return nil, ErrInvalidSteps
+	}
+
+	var reverted []*Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		appliedMigrations, err := m.verify(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := appliedMigrations[migration.Version]; !ok {
+				continue
+			}
+
+			if err := m.revert(ctx, conn, migration); err != nil {
+				log.Error("Failed to revert migration",
+					slog.Int64("version", migration.Version),
+					slog.String("error", err.Error()),
+				)
+				return fmt.Errorf("revert %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			reverted = append(reverted, migration)
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Status lists the migration files together with the applied migrations,
+// flagging the ones modified after being applied and the ones applied from a
+// file that no longer exists.
+func (m *Migrator) Status(ctx context.Context) ([]*Status, error) {
+	var statuses []*Status
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		appliedMigrations, err := m.findApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			status := &Status{Version: migration.Version, Name: migration.Name}
+			if applied, ok := appliedMigrations[migration.Version]; ok {
+				status.AppliedAt = &applied.AppliedAt
+				status.Modified = applied.Checksum != migration.Checksum
+				delete(appliedMigrations, migration.Version)
+			}
+
+			statuses = append(statuses, status)
+		}
+
+		for _, applied := range appliedMigrations {
+			statuses = append(statuses, &Status{
+				Version:   applied.Version,
+				Name:      applied.Name,
+				AppliedAt: &applied.AppliedAt,
+				Missing:   true,
+			})
+		}
+
+		sort.Slice(statuses, func(i, j int) bool {
+			return statuses[i].Version < statuses[j].Version
+		})
+
+		return nil
+	})
+
+	return statuses, err
+}
+
+// withLock runs fn on a dedicated connection holding the advisory lock, which
+// Postgres ties to the session that acquired it.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	log := m.logger.With(slog.String("func", "withLock"))
+
+	sqlDB, err := m.db.DB()
+	if err != nil {
+		return err
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		log.Error("Failed to open database connection", slog.String("error", err.Error()))
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		log.Error("Failed to acquire migration lock", slog.String("error", err.Error()))
+		return err
+	}
+
+	defer func() {
+		// The context may be cancelled by now, the lock must still be released.
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey); err != nil {
+			log.Error("Failed to release migration lock", slog.String("error", err.Error()))
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, createTableSQL); err != nil {
+		log.Error("Failed to create schema_migrations table", slog.String("error", err.Error()))
+		return err
+	}
+
+	return fn(conn)
+}
+
+// verify refuses to go on when an applied migration was edited or removed,
+// since the schema would no longer match the migration files.
+func (m *Migrator) verify(ctx context.Context, conn *sql.Conn) (map[int64]*appliedMigration, error) {
+	log := m.logger.With(slog.String("func", "verify"))
+
+	appliedMigrations, err := m.findApplied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[int64]*Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+	}
+
+	for version, applied := range appliedMigrations {
+		migration, ok := known[version]
+		if !ok {
+			log.Warn("Applied migration is missing from the migration files", slog.Int64("version", version))
+			return nil, fmt.Errorf("%w: %04d_%s", ErrUnknownMigration, version, applied.Name)
+		}
+
+		if migration.Checksum != applied.Checksum {
+			log.Warn("Applied migration was modified", slog.Int64("version", version))
+			return nil, fmt.Errorf("%w: %04d_%s", ErrChecksumMismatch, version, migration.Name)
+		}
+	}
+
+	return appliedMigrations, nil
+}
+
+func (m *Migrator) findApplied(ctx context.Context, conn *sql.Conn) (map[int64]*appliedMigration, error) {
+	log := m.logger.With(slog.String("func", "findApplied"))
+
+	rows, err := conn.QueryContext(ctx, "SELECT version, name, checksum, applied_at FROM schema_migrations")
+	if err != nil {
+		log.Error("Failed to find applied migrations", slog.String("error", err.Error()))
+		return nil, err
+	}
+	defer rows.Close()
+
+	appliedMigrations := make(map[int64]*appliedMigration)
+	for rows.Next() {
+		var applied appliedMigration
+		if err := rows.Scan(&applied.Version, &applied.Name, &applied.Checksum, &applied.AppliedAt); err != nil {
+			log.Error("Failed to read applied migration", slog.String("error", err.Error()))
+			return nil, err
+		}
+
+		appliedMigrations[applied.Version] = &applied
+	}
+
+	return appliedMigrations, rows.Err()
+}
+
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration *Migration) error {
+	return inTransaction(ctx, conn, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+			migration.Version, migration.Name, migration.Checksum,
+		)
+		return err
+	})
+}
+
+func (m *Migrator) revert(ctx context.Context, conn *sql.Conn, migration *Migration) error {
+	return inTransaction(ctx, conn, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+		return err
+	})
+}
+
+func inTransaction(ctx context.Context, conn *sql.Conn, fn func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return errors.Join(err, rollbackErr)
+		}
+
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,17 @@
+DROP TABLE IF EXISTS audit_logs;
+DROP TABLE IF EXISTS permission_grants;
+DROP TABLE IF EXISTS list_items;
+DROP TABLE IF EXISTS game_lists;
+DROP TABLE IF EXISTS games;
+DROP TABLE IF EXISTS o_id_c_login_states;
+DROP TABLE IF EXISTS user_identities;
+DROP TABLE IF EXISTS login_throttles;
+DROP TABLE IF EXISTS recovery_codes;
+DROP TABLE IF EXISTS two_factors;
+DROP TABLE IF EXISTS user_tokens;
+DROP TABLE IF EXISTS personal_access_tokens;
+DROP TABLE IF EXISTS sessions;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS role_accesses;
+DROP TABLE IF EXISTS roles;
+DROP TABLE IF EXISTS accesses;
@@ -0,0 +1,200 @@
+-- Baseline of the schema previously created by gorm AutoMigrate. Every
+-- statement is guarded so databases created by AutoMigrate adopt it as is.
+
+CREATE TABLE IF NOT EXISTS accesses (
+    id bigserial PRIMARY KEY,
+    access_type char(100) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS roles (
+    id bigserial PRIMARY KEY,
+    name text NOT NULL,
+    CONSTRAINT uni_roles_name UNIQUE (name)
+);
+
+CREATE TABLE IF NOT EXISTS role_accesses (
+    role_id smallint NOT NULL,
+    access_id smallint NOT NULL,
+    PRIMARY KEY (role_id, access_id),
+    CONSTRAINT fk_role_accesses_role FOREIGN KEY (role_id) REFERENCES roles (id),
+    CONSTRAINT fk_role_accesses_access FOREIGN KEY (access_id) REFERENCES accesses (id)
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    id uuid PRIMARY KEY,
+    email varchar(255) NOT NULL,
+    password varchar(100) NOT NULL,
+    username varchar(100) NOT NULL,
+    avatar_url text,
+    email_verified boolean NOT NULL DEFAULT false,
+    email_verified_at timestamp,
+    created_at timestamptz,
+    updated_at timestamptz,
+    role_id bigint,
+    CONSTRAINT fk_roles_users FOREIGN KEY (role_id) REFERENCES roles (id)
+);
+
+CREATE TABLE IF NOT EXISTS sessions (
+    id uuid PRIMARY KEY,
+    token text,
+    ip_adress text,
+    user_agent text,
+    expires_at timestamp,
+    revoked_at timestamp,
+    created_at timestamptz,
+    user_id uuid NOT NULL,
+    CONSTRAINT fk_sessions_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS personal_access_tokens (
+    id uuid PRIMARY KEY,
+    name varchar(100) NOT NULL,
+    token char(64) NOT NULL,
+    scopes text NOT NULL,
+    expires_at timestamp,
+    last_used_at timestamp,
+    revoked_at timestamp,
+    created_at timestamptz,
+    user_id uuid NOT NULL,
+    CONSTRAINT fk_personal_access_tokens_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_personal_access_tokens_token ON personal_access_tokens (token);
+
+CREATE TABLE IF NOT EXISTS user_tokens (
+    id uuid PRIMARY KEY,
+    purpose varchar(50) NOT NULL,
+    token char(64) NOT NULL,
+    expires_at timestamp,
+    used_at timestamp,
+    created_at timestamptz,
+    user_id uuid NOT NULL,
+    CONSTRAINT fk_user_tokens_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_tokens_purpose ON user_tokens (purpose);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_tokens_token ON user_tokens (token);
+
+CREATE TABLE IF NOT EXISTS two_factors (
+    user_id uuid PRIMARY KEY,
+    secret varchar(64) NOT NULL,
+    confirmed_at timestamp,
+    last_used_step bigint NOT NULL DEFAULT 0,
+    created_at timestamptz,
+    updated_at timestamptz,
+    CONSTRAINT fk_two_factors_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS recovery_codes (
+    id uuid PRIMARY KEY,
+    code char(64) NOT NULL,
+    used_at timestamp,
+    created_at timestamptz,
+    user_id uuid NOT NULL,
+    CONSTRAINT fk_recovery_codes_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_recovery_codes_code ON recovery_codes (code);
+CREATE INDEX IF NOT EXISTS idx_recovery_codes_user_id ON recovery_codes (user_id);
+
+CREATE TABLE IF NOT EXISTS login_throttles (
+    key varchar(320) PRIMARY KEY,
+    failures bigint NOT NULL DEFAULT 0,
+    last_failure_at timestamp,
+    locked_until timestamp
+);
+
+CREATE TABLE IF NOT EXISTS user_identities (
+    id uuid PRIMARY KEY,
+    provider varchar(50) NOT NULL,
+    subject varchar(255) NOT NULL,
+    email varchar(255),
+    created_at timestamptz,
+    user_id uuid NOT NULL,
+    CONSTRAINT fk_user_identities_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_identities_provider_subject ON user_identities (provider, subject);
+CREATE INDEX IF NOT EXISTS idx_user_identities_user_id ON user_identities (user_id);
+
+CREATE TABLE IF NOT EXISTS o_id_c_login_states (
+    state char(64) PRIMARY KEY,
+    provider varchar(50) NOT NULL,
+    nonce varchar(64) NOT NULL,
+    code_verifier varchar(128) NOT NULL,
+    expires_at timestamp,
+    created_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_o_id_c_login_states_expires_at ON o_id_c_login_states (expires_at);
+
+CREATE TABLE IF NOT EXISTS games (
+    id uuid PRIMARY KEY,
+    name varchar(255) NOT NULL,
+    genre varchar(100) NOT NULL,
+    developer varchar(255) NOT NULL,
+    description text NOT NULL,
+    rating real NOT NULL DEFAULT 0,
+    image_url text,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS game_lists (
+    id uuid PRIMARY KEY,
+    name varchar(100) NOT NULL,
+    is_public boolean DEFAULT true,
+    is_default boolean NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz,
+    user_id uuid NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS list_items (
+    game_list_id uuid NOT NULL,
+    game_id uuid NOT NULL,
+    status varchar(100),
+    rating numeric,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (game_list_id, game_id),
+    CONSTRAINT fk_list_items_game_list FOREIGN KEY (game_list_id) REFERENCES game_lists (id),
+    CONSTRAINT fk_list_items_game FOREIGN KEY (game_id) REFERENCES games (id)
+);
+
+CREATE TABLE IF NOT EXISTS permission_grants (
+    id uuid PRIMARY KEY,
+    user_id uuid,
+    role_id smallint,
+    action varchar(20) NOT NULL,
+    resource_type varchar(50) NOT NULL,
+    resource_id uuid,
+    attribute varchar(50),
+    value varchar(255),
+    created_at timestamptz,
+    CONSTRAINT fk_permission_grants_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT fk_permission_grants_role FOREIGN KEY (role_id) REFERENCES roles (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_permission_grants_user_id ON permission_grants (user_id);
+CREATE INDEX IF NOT EXISTS idx_permission_grants_role_id ON permission_grants (role_id);
+CREATE INDEX IF NOT EXISTS idx_permission_grants_resource_type ON permission_grants (resource_type);
+CREATE INDEX IF NOT EXISTS idx_permission_grants_resource_id ON permission_grants (resource_id);
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id uuid PRIMARY KEY,
+    actor_id uuid,
+    action varchar(50) NOT NULL,
+    target_type varchar(50) NOT NULL,
+    target_id varchar(64),
+    changes jsonb,
+    ip_address varchar(45),
+    user_agent text,
+    request_id varchar(64),
+    created_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor_id ON audit_logs (actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_action ON audit_logs (action);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_target ON audit_logs (target_type, target_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs (created_at);
@@ -0,0 +1,2 @@
+-- The columns are part of the initial schema, whose down migration drops them
+-- with their tables.
@@ -0,0 +1,26 @@
+-- Brings databases created by gorm AutoMigrate before the initial schema was
+-- written up to date. The initial schema only creates the missing tables, so
+-- the baseline tables kept the columns they had when AutoMigrate last ran.
+-- The columns added since are created here, and already exist on databases
+-- created by the initial schema.
+--
+-- The accounts created before email verification existed are considered
+-- verified, otherwise every one of them would lose the actions listed in
+-- EMAIL_VERIFICATION_REQUIRED_FOR. They are only backfilled when the column
+-- is added, so the unverified accounts of an up to date database are kept.
+
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = 'users' AND column_name = 'email_verified'
+    ) THEN
+        ALTER TABLE users ADD COLUMN email_verified boolean NOT NULL DEFAULT false;
+        ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at timestamp;
+        UPDATE users SET email_verified = true, email_verified_at = created_at;
+    END IF;
+END $$;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at timestamp;
+
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS revoked_at timestamp;
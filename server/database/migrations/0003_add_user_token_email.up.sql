@@ -0,0 +1,5 @@
+-- Binds each user token to the email it was sent to. Tokens created before
+-- keep an empty email, so pending email verifications must be requested
+-- again.
+
+ALTER TABLE user_tokens ADD COLUMN IF NOT EXISTS email varchar(255) NOT NULL DEFAULT '';
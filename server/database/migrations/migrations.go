@@ -0,0 +1,12 @@
+// Package migrations holds the versioned SQL migrations of the database. New
+// migrations are created with `go run . migrate create <name>` and must
+// never be edited once applied, since the migrator checks their checksums.
+package migrations
+
+import "embed"
+
+// Dir is where the migration files live, relative to the module root.
+const Dir = "database/migrations"
+
+//go:embed *.sql
+var FS embed.FS
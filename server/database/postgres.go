@@ -2,15 +2,12 @@ package database
 
 import (
 	"fmt"
-	"log"
 	"log/slog"
-	"os"
-	"time"
 
 	"github.com/Bromolima/my-game-list/config"
+	"github.com/Bromolima/my-game-list/logger"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
 func SetupPostgresConnection() (*gorm.DB, error) {
@@ -22,16 +19,10 @@ func SetupPostgresConnection() (*gorm.DB, error) {
 		config.Env.DB.Port,
 	)
 
-	l := logger.New(
-		log.New(os.Stdout, "\r\n", log.LstdFlags),
-		logger.Config{
-			SlowThreshold:             time.Second,
-			LogLevel:                  logger.Silent,
-			IgnoreRecordNotFoundError: true,
-			ParameterizedQueries:      false,
-			Colorful:                  true,
-		},
-	)
+	l, err := logger.NewGormLogger(config.Env.Log)
+	if err != nil {
+		return nil, err
+	}
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
 		Logger: l,
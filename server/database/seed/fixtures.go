@@ -0,0 +1,274 @@
+package seed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/factory"
+	"github.com/Bromolima/my-game-list/internal/security"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrUnsupportedFixtureFormat = errors.New("fixture files must be .json, .yaml or .yml")
+	ErrUnknownFixtureRole       = errors.New("fixture user role must be admin or user")
+	ErrUnknownFixtureOwner      = errors.New("fixture list owner is not a known user")
+	ErrUnknownFixtureGame       = errors.New("fixture list item references an unknown game")
+)
+
+// Fixtures is a set of development and demo data. Users are identified by
+// their email, games by their name and lists by their owner and name, so
+// loading the same file twice does not duplicate anything.
+type Fixtures struct {
+	Users []FixtureUser `json:"users" yaml:"users"`
+	Games []FixtureGame `json:"games" yaml:"games"`
+	Lists []FixtureList `json:"lists" yaml:"lists"`
+}
+
+type FixtureUser struct {
+	Email     string `json:"email" yaml:"email"`
+	Username  string `json:"username" yaml:"username"`
+	Password  string `json:"password" yaml:"password"`
+	Role      string `json:"role" yaml:"role"`
+	AvatarURL string `json:"avatar_url" yaml:"avatar_url"`
+}
+
+type FixtureGame struct {
+	Name        string  `json:"name" yaml:"name"`
+	Genre       string  `json:"genre" yaml:"genre"`
+	Developer   string  `json:"developer" yaml:"developer"`
+	Description string  `json:"description" yaml:"description"`
+	ImageURL    string  `json:"image_url" yaml:"image_url"`
+	Rating      float32 `json:"rating" yaml:"rating"`
+}
+
+type FixtureList struct {
+	Owner  string            `json:"owner" yaml:"owner"`
+	Name   string            `json:"name" yaml:"name"`
+	Public bool              `json:"public" yaml:"public"`
+	Items  []FixtureListItem `json:"items" yaml:"items"`
+}
+
+type FixtureListItem struct {
+	Game   string  `json:"game" yaml:"game"`
+	Status string  `json:"status" yaml:"status"`
+	Rating float32 `json:"rating" yaml:"rating"`
+}
+
+// LoadFixtures reads a fixture file, decoded as JSON or YAML depending on its
+// extension.
+func LoadFixtures(path string) (*Fixtures, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var fixtures Fixtures
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(content, &fixtures)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &fixtures)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFixtureFormat, path)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("decode %s: %w", path, err)
+	}
+
+	return &fixtures, nil
+}
+
+// SeedFixtures creates the users, games, lists and list items missing from the
+// database, in a single transaction. Records that already exist are left as
+// they are. The roles must have been seeded first.
+func (s *Seeder) SeedFixtures(ctx context.Context, fixtures *Fixtures) error {
+	log := s.logger.With(slog.String("func", "SeedFixtures"))
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		users, err := s.seedFixtureUsers(tx, fixtures.Users)
+		if err != nil {
+			log.Error("Failed to seed fixture users", slog.String("error", err.Error()))
+			return err
+		}
+
+		games, err := s.seedFixtureGames(tx, fixtures.Games)
+		if err != nil {
+			log.Error("Failed to seed fixture games", slog.String("error", err.Error()))
+			return err
+		}
+
+		if err := s.seedFixtureLists(tx, fixtures.Lists, users, games); err != nil {
+			log.Error("Failed to seed fixture lists", slog.String("error", err.Error()))
+			return err
+		}
+
+		log.Info("Fixtures loaded",
+			slog.Int("users", len(fixtures.Users)),
+			slog.Int("games", len(fixtures.Games)),
+			slog.Int("lists", len(fixtures.Lists)),
+		)
+		return nil
+	})
+}
+
+func (s *Seeder) seedFixtureUsers(tx *gorm.DB, fixtureUsers []FixtureUser) (map[string]uuid.UUID, error) {
+	users := make(map[string]uuid.UUID, len(fixtureUsers))
+	for _, fixtureUser := range fixtureUsers {
+		var user entities.User
+		err := tx.Where("email = ?", fixtureUser.Email).First(&user).Error
+		if err == nil {
+			users[fixtureUser.Email] = user.ID
+			continue
+		}
+
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+
+		roleID, err := fixtureRoleID(fixtureUser.Role)
+		if err != nil {
+			return nil, fmt.Errorf("user %s: %w", fixtureUser.Email, err)
+		}
+
+		hashedPassword, err := security.HashPassword(fixtureUser.Password)
+		if err != nil {
+			return nil, err
+		}
+
+		newUser := factory.NewUser(fixtureUser.Email, hashedPassword, fixtureUser.Username, fixtureUser.AvatarURL, roleID)
+		if err := createVerifiedUser(tx, newUser); err != nil {
+			return nil, err
+		}
+
+		users[fixtureUser.Email] = newUser.ID
+	}
+
+	return users, nil
+}
+
+func (s *Seeder) seedFixtureGames(tx *gorm.DB, fixtureGames []FixtureGame) (map[string]uuid.UUID, error) {
+	games := make(map[string]uuid.UUID, len(fixtureGames))
+	for _, fixtureGame := range fixtureGames {
+		var game entities.Game
+		err := tx.Where("name = ?", fixtureGame.Name).First(&game).Error
+		if err == nil {
+			games[fixtureGame.Name] = game.ID
+			continue
+		}
+
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+
+		newGame := factory.NewGame(fixtureGame.Name, fixtureGame.Genre, fixtureGame.Developer, fixtureGame.Description, fixtureGame.ImageURL)
+		newGame.Rating = fixtureGame.Rating
+		if err := tx.Omit(clause.Associations).Create(newGame).Error; err != nil {
+			return nil, err
+		}
+
+		games[fixtureGame.Name] = newGame.ID
+	}
+
+	return games, nil
+}
+
+func (s *Seeder) seedFixtureLists(tx *gorm.DB, fixtureLists []FixtureList, users, games map[string]uuid.UUID) error {
+	for _, fixtureList := range fixtureLists {
+		userID, err := s.findFixtureUserID(tx, users, fixtureList.Owner)
+		if err != nil {
+			return fmt.Errorf("list %s: %w", fixtureList.Name, err)
+		}
+
+		var gameList entities.GameList
+		err = tx.Where("user_id = ? AND name = ?", userID, fixtureList.Name).First(&gameList).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			gameList = *factory.NewGameList(userID, fixtureList.Name, fixtureList.Public, false)
+			// Select every column, otherwise gorm skips a false IsPublic and
+			// the column default makes the list public.
+			err = tx.Select("*").Omit(clause.Associations).Create(&gameList).Error
+		}
+
+		if err != nil {
+			return err
+		}
+
+		for _, item := range fixtureList.Items {
+			gameID, err := s.findFixtureGameID(tx, games, item.Game)
+			if err != nil {
+				return fmt.Errorf("list %s: %w", fixtureList.Name, err)
+			}
+
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entities.ListItem{
+				GameListID: gameList.ID,
+				GameID:     gameID,
+				Status:     item.Status,
+				Rating:     item.Rating,
+			}).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// findFixtureUserID resolves a list owner among the fixture users first and
+// then among the accounts already in the database, such as the seeded admin.
+func (s *Seeder) findFixtureUserID(tx *gorm.DB, users map[string]uuid.UUID, email string) (uuid.UUID, error) {
+	if id, ok := users[email]; ok {
+		return id, nil
+	}
+
+	var user entities.User
+	if err := tx.Where("email = ?", email).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return uuid.Nil, fmt.Errorf("%w: %s", ErrUnknownFixtureOwner, email)
+		}
+
+		return uuid.Nil, err
+	}
+
+	users[email] = user.ID
+	return user.ID, nil
+}
+
+func (s *Seeder) findFixtureGameID(tx *gorm.DB, games map[string]uuid.UUID, name string) (uuid.UUID, error) {
+	if id, ok := games[name]; ok {
+		return id, nil
+	}
+
+	var game entities.Game
+	if err := tx.Where("name = ?", name).First(&game).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return uuid.Nil, fmt.Errorf("%w: %s", ErrUnknownFixtureGame, name)
+		}
+
+		return uuid.Nil, err
+	}
+
+	games[name] = game.ID
+	return game.ID, nil
+}
+
+func fixtureRoleID(role string) (uint, error) {
+	switch strings.ToLower(role) {
+	case "", "user":
+		return entities.RoleUserID, nil
+	case "admin":
+		return entities.RoleAdminID, nil
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrUnknownFixtureRole, role)
+	}
+}
@@ -0,0 +1,162 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/Bromolima/my-game-list/config"
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/factory"
+	"github.com/Bromolima/my-game-list/internal/security"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrMissingAdminPassword = errors.New("SEED_ADMIN_PASSWORD is required when SEED_ADMIN_EMAIL is set")
+
+// roleAccesses lists the accesses every built-in role must have. Accesses
+// granted later through the API are kept.
+var roleAccesses = map[uint][]entities.AccessType{
+	entities.RoleUserID:  {entities.ReadAccess},
+	entities.RoleAdminID: entities.AccessTypes,
+}
+
+// Seeder writes the reference data the API relies on. Every step checks what
+// already exists, so running it again leaves the database unchanged.
+type Seeder struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewSeeder(db *gorm.DB, logger *slog.Logger) *Seeder {
+	return &Seeder{
+		db:     db,
+		logger: logger.With(slog.String("database", "seeder")),
+	}
+}
+
+// Seed upserts the accesses, the built-in roles and the administrator account
+// configured in the environment, in a single transaction.
+func (s *Seeder) Seed(ctx context.Context) error {
+	log := s.logger.With(slog.String("func", "Seed"))
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		accesses, err := s.seedAccesses(tx)
+		if err != nil {
+			log.Error("Failed to seed accesses", slog.String("error", err.Error()))
+			return err
+		}
+
+		if err := s.seedRoles(tx, accesses); err != nil {
+			log.Error("Failed to seed roles", slog.String("error", err.Error()))
+			return err
+		}
+
+		if err := s.seedAdmin(tx); err != nil {
+			log.Error("Failed to seed admin account", slog.String("error", err.Error()))
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (s *Seeder) seedAccesses(tx *gorm.DB) (map[entities.AccessType]entities.Access, error) {
+	accesses := make(map[entities.AccessType]entities.Access, len(entities.AccessTypes))
+	for _, accessType := range entities.AccessTypes {
+		var access entities.Access
+		if err := tx.Where(entities.Access{AccessType: accessType}).FirstOrCreate(&access).Error; err != nil {
+			return nil, err
+		}
+
+		accesses[accessType] = access
+	}
+
+	return accesses, nil
+}
+
+func (s *Seeder) seedRoles(tx *gorm.DB, accesses map[entities.AccessType]entities.Access) error {
+	roles := []entities.Role{
+		{ID: entities.RoleUserID, Name: entities.RoleUserName},
+		{ID: entities.RoleAdminID, Name: entities.RoleAdminName},
+	}
+
+	for _, role := range roles {
+		if err := tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"name"}),
+		}).Create(&role).Error; err != nil {
+			return err
+		}
+
+		for _, accessType := range roleAccesses[role.ID] {
+			if err := tx.Table("role_accesses").
+				Clauses(clause.OnConflict{DoNothing: true}).
+				Create(map[string]any{"role_id": role.ID, "access_id": accesses[accessType].ID}).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// seedAdmin creates the configured administrator account. An existing account
+// keeps its password and is only promoted to the admin role.
+func (s *Seeder) seedAdmin(tx *gorm.DB) error {
+	log := s.logger.With(slog.String("func", "seedAdmin"))
+
+	email := strings.TrimSpace(config.Env.Seed.AdminEmail)
+	if email == "" {
+		log.Info("SEED_ADMIN_EMAIL is not set, skipping the admin account")
+		return nil
+	}
+
+	var user entities.User
+	err := tx.Where("email = ?", email).First(&user).Error
+	if err == nil {
+		if user.RoleID == entities.RoleAdminID {
+			return nil
+		}
+
+		log.Info("Promoting existing account to admin", slog.String("user_id", user.ID.String()))
+		return tx.Model(&user).Update("role_id", entities.RoleAdminID).Error
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if config.Env.Seed.AdminPassword == "" {
+		return ErrMissingAdminPassword
+	}
+
+	hashedPassword, err := security.HashPassword(config.Env.Seed.AdminPassword)
+	if err != nil {
+		return err
+	}
+
+	admin := factory.NewUser(email, hashedPassword, config.Env.Seed.AdminUsername, "", entities.RoleAdminID)
+	if err := createVerifiedUser(tx, admin); err != nil {
+		return err
+	}
+
+	log.Info("Admin account created", slog.String("user_id", admin.ID.String()))
+	return nil
+}
+
+// createVerifiedUser stores a user whose email needs no verification, together
+// with the default list every account starts with.
+func createVerifiedUser(tx *gorm.DB, user *entities.User) error {
+	now := tx.NowFunc()
+	user.EmailVerified = true
+	user.EmailVerifiedAt = &now
+
+	if err := tx.Create(user).Error; err != nil {
+		return err
+	}
+
+	return tx.Create(factory.NewDefaultGameList(user.ID)).Error
+}
@@ -0,0 +1,206 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Bromolima/my-game-list/config"
+	"github.com/Bromolima/my-game-list/database"
+	"github.com/Bromolima/my-game-list/internal/injector"
+	"github.com/Bromolima/my-game-list/internal/lifecycle"
+	"github.com/Bromolima/my-game-list/internal/requestctx"
+	"github.com/Bromolima/my-game-list/internal/validation"
+	"github.com/Bromolima/my-game-list/logger"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/dig"
+)
+
+// ErrUsage is returned when a command is called with invalid arguments, after
+// its usage was printed.
+var ErrUsage = errors.New("invalid usage")
+
+type command struct {
+	name        string
+	args        string
+	description string
+	run         func(ctx context.Context, args []string) error
+}
+
+func commands() []*command {
+	return []*command{
+		{"serve", "", "start the API server (default)", runServe},
+		{"migrate", "up | down [N] | status | create NAME", "manage the database migrations", runMigrate},
+		{"seed", "[-fixtures FILE]", "upsert the roles, accesses and admin account, and load fixtures", runSeed},
+		{"create-admin", "-email EMAIL [-username NAME] [-password PASSWORD]", "create an administrator or promote an existing account", runCreateAdmin},
+		{"reset-password", "-email EMAIL [-password PASSWORD]", "set a new password and revoke the user sessions", runResetPassword},
+		{"revoke-sessions", "-email EMAIL", "sign a user out of every session", runRevokeSessions},
+		{"reindex", "", "recalculate the game ratings from the list items", runReindex},
+		{"export-user", "-email EMAIL [-output FILE]", "export the data kept about a user as JSON", runExportUser},
+		{"config", "", "print the effective configuration with the secrets masked", runConfig},
+	}
+}
+
+// overrides collects the -set flags, which take precedence over the
+// environment variables of the same name.
+type overrides map[string]string
+
+func (o overrides) String() string {
+	return ""
+}
+
+func (o overrides) Set(value string) error {
+	key, v, ok := strings.Cut(value, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("expected KEY=VALUE, got %q", value)
+	}
+
+	o[key] = v
+	return nil
+}
+
+// Run loads the configuration, sets up the logs and executes the command named
+// by the first argument after the global flags, or starts the server when
+// there is none.
+func Run(ctx context.Context, args []string) error {
+	sources := config.Sources{Overrides: overrides{}}
+
+	flags := flag.NewFlagSet("my-game-list", flag.ContinueOnError)
+	flags.StringVar(&sources.File, "config", "", "read the settings from this YAML `file` (default $CONFIG_FILE)")
+	flags.Var(overrides(sources.Overrides), "set", "override a setting, named like its environment variable, as `KEY=VALUE`")
+	flags.Usage = func() {
+		printUsage(flags.Output())
+		fmt.Fprintln(flags.Output())
+		fmt.Fprintln(flags.Output(), "flags:")
+		flags.PrintDefaults()
+	}
+
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	name, args := "serve", flags.Args()
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
+	}
+
+	for _, cmd := range commands() {
+		if cmd.name == name {
+			if err := config.LoadEnvironment(sources); err != nil {
+				return err
+			}
+
+			if err := logger.Setup(config.Env.Log); err != nil {
+				return err
+			}
+
+			ctx = requestctx.WithMetadata(ctx, &requestctx.Metadata{UserAgent: "cli/" + cmd.name})
+			ctx = logger.WithRequest(ctx, slog.Default(), slog.String("command", cmd.name))
+			return cmd.run(ctx, args)
+		}
+	}
+
+	printUsage(os.Stderr)
+	return ErrUsage
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "usage: my-game-list [-config FILE] [-set KEY=VALUE]... <command> [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, cmd := range commands() {
+		fmt.Fprintf(tw, "  %s\t%s\n", cmd.name, cmd.description)
+	}
+	tw.Flush()
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.Usage = func() {
+		for _, cmd := range commands() {
+			if cmd.name == name {
+				fmt.Fprintf(flags.Output(), "usage: my-game-list %s %s\n", cmd.name, cmd.args)
+			}
+		}
+
+		flags.PrintDefaults()
+	}
+
+	return flags
+}
+
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ErrUsage
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// newContainer connects to the database and builds the same dependency graph
+// as the API server.
+func newContainer() (*dig.Container, error) {
+	db, err := database.SetupPostgresConnection()
+	if err != nil {
+		return nil, err
+	}
+
+	c := dig.New()
+	injector.SetupDependecies(c, db)
+
+	return c, nil
+}
+
+// invoke builds the container, calls fn with its dependencies and shuts the
+// components down once fn returns, closing the database connections. The
+// tracer provider is built before the lifecycle starts, so that its spans are
+// flushed on stop.
+func invoke(ctx context.Context, fn any) error {
+	c, err := newContainer()
+	if err != nil {
+		return err
+	}
+
+	return c.Invoke(func(lc *lifecycle.Lifecycle, _ trace.TracerProvider) error {
+		if err := lc.Start(ctx); err != nil {
+			return err
+		}
+
+		stopCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), config.Env.ShutdownTimeout)
+		defer cancel()
+
+		return errors.Join(c.Invoke(fn), lc.Stop(stopCtx))
+	})
+}
+
+// validate checks the command arguments with the rules used by the API and
+// reports every invalid field.
+func validate(v any) error {
+	customValidator := validation.NewCustomValidator()
+	validation.SetupTranslations(customValidator)
+
+	if err := customValidator.Validate(v); err != nil {
+		restErr := validation.ValidateUserError(err)
+
+		causes := make([]string, 0, len(restErr.Causes))
+		for _, cause := range restErr.Causes {
+			causes = append(causes, cause.Message)
+		}
+
+		return fmt.Errorf("%s: %s", restErr.Message, strings.Join(causes, "; "))
+	}
+
+	return nil
+}
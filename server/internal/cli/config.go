@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"context"
+	"os"
+
+	"github.com/Bromolima/my-game-list/config"
+	"gopkg.in/yaml.v3"
+)
+
+// runConfig prints the effective configuration with its secrets masked, in the
+// format of the configuration file.
+func runConfig(ctx context.Context, args []string) error {
+	if err := parseFlags(newFlagSet("config"), args); err != nil {
+		return err
+	}
+
+	encoder := yaml.NewEncoder(os.Stdout)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(config.Env.Masked()); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+
+	"github.com/Bromolima/my-game-list/database/migrate"
+	"github.com/Bromolima/my-game-list/database/migrations"
+	"gorm.io/gorm"
+)
+
+func runMigrate(ctx context.Context, args []string) error {
+	flags := newFlagSet("migrate")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	args = flags.Args()
+	if len(args) == 0 {
+		flags.Usage()
+		return ErrUsage
+	}
+
+	command, args := args[0], args[1:]
+	if command == "create" {
+		if len(args) != 1 {
+			flags.Usage()
+			return ErrUsage
+		}
+
+		upPath, downPath, err := migrate.Create(migrations.Dir, args[0])
+		if err != nil {
+			return err
+		}
+
+		slog.Info("migration files created", slog.String("up", upPath), slog.String("down", downPath))
+		return nil
+	}
+
+	files, err := migrate.Load(migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	return invoke(ctx, func(db *gorm.DB, logger *slog.Logger) error {
+		migrator := migrate.NewMigrator(db, files, logger)
+
+		switch command {
+		case "up":
+			applied, err := migrator.Up(ctx)
+			if err != nil {
+				return err
+			}
+
+			for _, migration := range applied {
+				slog.Info("migration applied", slog.Int64("version", migration.Version), slog.String("name", migration.Name))
+			}
+
+			slog.Info("database migration completed successfully", slog.Int("applied", len(applied)))
+		case "down":
+			steps := 1
+			if len(args) > 0 {
+				if steps, err = strconv.Atoi(args[0]); err != nil {
+					return err
+				}
+			}
+
+			reverted, err := migrator.Down(ctx, steps)
+			if err != nil {
+				return err
+			}
+
+			for _, migration := range reverted {
+				slog.Info("migration reverted", slog.Int64("version", migration.Version), slog.String("name", migration.Name))
+			}
+		case "status":
+			statuses, err := migrator.Status(ctx)
+			if err != nil {
+				return err
+			}
+
+			printStatus(statuses)
+		default:
+			flags.Usage()
+			return ErrUsage
+		}
+
+		return nil
+	})
+}
+
+func printStatus(statuses []*migrate.Status) {
+	for _, status := range statuses {
+		state := "pending"
+		switch {
+		case status.Missing:
+			state = "applied, file missing"
+		case status.Modified:
+			state = "applied, file modified"
+		case status.AppliedAt != nil:
+			state = "applied at " + status.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+
+		fmt.Fprintf(os.Stdout, "%04d_%-40s %s\n", status.Version, status.Name, state)
+	}
+}
@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Bromolima/my-game-list/internal/service"
+)
+
+// runReindex rebuilds the data derived from other tables, which is the game
+// ratings averaged from the list items.
+func runReindex(ctx context.Context, args []string) error {
+	if err := parseFlags(newFlagSet("reindex"), args); err != nil {
+		return err
+	}
+
+	return invoke(ctx, func(adminService service.AdminService) error {
+		updated, restErr := adminService.RecalculateGameRatings(ctx)
+		if restErr != nil {
+			return restErr
+		}
+
+		slog.Info("game ratings recalculated", slog.Int64("updated", updated))
+		return nil
+	})
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Bromolima/my-game-list/database/seed"
+	"gorm.io/gorm"
+)
+
+func runSeed(ctx context.Context, args []string) error {
+	flags := newFlagSet("seed")
+	fixturesPath := flags.String("fixtures", "", "JSON or YAML file with games, users and lists to load after the reference data")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	return invoke(ctx, func(db *gorm.DB, logger *slog.Logger) error {
+		seeder := seed.NewSeeder(db, logger)
+		if err := seeder.Seed(ctx); err != nil {
+			return err
+		}
+
+		if *fixturesPath != "" {
+			fixtures, err := seed.LoadFixtures(*fixturesPath)
+			if err != nil {
+				return err
+			}
+
+			if err := seeder.SeedFixtures(ctx, fixtures); err != nil {
+				return err
+			}
+		}
+
+		slog.Info("database seeded successfully")
+		return nil
+	})
+}
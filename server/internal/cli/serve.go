@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Bromolima/my-game-list/config"
+	"github.com/Bromolima/my-game-list/internal/http/middlewares"
+	"github.com/Bromolima/my-game-list/internal/http/routes"
+	"github.com/Bromolima/my-game-list/internal/lifecycle"
+	"github.com/Bromolima/my-game-list/internal/metrics"
+	"github.com/Bromolima/my-game-list/internal/token"
+	validation "github.com/Bromolima/my-game-list/internal/validation"
+	"github.com/labstack/echo/v4"
+)
+
+// runServe starts the API server and the background workers, and shuts them
+// down on SIGINT or SIGTERM. In-flight requests are given up to the shutdown
+// timeout to complete.
+func runServe(ctx context.Context, args []string) error {
+	if err := parseFlags(newFlagSet("serve"), args); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	c, err := newContainer()
+	if err != nil {
+		return err
+	}
+
+	e := echo.New()
+	e.HideBanner = true
+	v := validation.NewCustomValidator()
+
+	validation.SetupTranslations(v)
+	e.Validator = v
+	e.IPExtractor = ipExtractor(config.Env.TrustedProxies)
+	e.Use(middlewares.RequestID, middlewares.RequestMetadata)
+
+	if err := routes.SetupRoutes(e, c); err != nil {
+		return err
+	}
+
+	return c.Invoke(func(lc *lifecycle.Lifecycle, m *metrics.Metrics, _ *token.KeyRotator) error {
+		// Buffered for both servers, so a failing one never blocks.
+		serverErr := make(chan error, 2)
+		if config.Env.Metrics.Port != "" {
+			appendServerHook(lc, "metrics server", &http.Server{
+				Addr:              fmt.Sprintf(":%s", config.Env.Metrics.Port),
+				Handler:           m.Handler(),
+				ReadHeaderTimeout: 5 * time.Second,
+			}, serverErr)
+		}
+
+		e.Server.Addr = fmt.Sprintf(":%s", config.Env.ApiPort)
+		e.Server.Handler = e
+		appendServerHook(lc, "http server", e.Server, serverErr)
+
+		if err := lc.Start(ctx); err != nil {
+			return err
+		}
+
+		slog.Info("server started", slog.String("port", config.Env.ApiPort))
+
+		var runErr error
+		select {
+		case <-ctx.Done():
+			slog.Info("shutting down", slog.Duration("timeout", config.Env.ShutdownTimeout))
+		case runErr = <-serverErr:
+			slog.Error("server stopped unexpectedly", slog.String("error", runErr.Error()))
+		}
+
+		stopCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), config.Env.ShutdownTimeout)
+		defer cancel()
+
+		if err := lc.Stop(stopCtx); err != nil {
+			return errors.Join(runErr, err)
+		}
+
+		slog.Info("server stopped")
+		return runErr
+	})
+}
+
+// appendServerHook starts the server in the background when the lifecycle
+// starts, reporting it on serverErr if it stops on its own, and shuts it down
+// gracefully when the lifecycle stops.
+func appendServerHook(lc *lifecycle.Lifecycle, name string, server *http.Server, serverErr chan<- error) {
+	lc.Append(lifecycle.Hook{
+		Name: name,
+		OnStart: func(context.Context) error {
+			go func() {
+				if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+					serverErr <- fmt.Errorf("%s: %w", name, err)
+				}
+			}()
+
+			return nil
+		},
+		OnStop: server.Shutdown,
+	})
+}
+
+// ipExtractor returns how the client IP is read, which the login throttle,
+// the sessions and the audit log rely on. Without trusted proxies the IP is
+// the one of the connection, otherwise the X-Forwarded-For header is only
+// trusted when set by one of them, so that clients cannot forge it.
+func ipExtractor(trustedProxies []string) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+
+	for _, proxy := range trustedProxies {
+		// The ranges were validated when the configuration was loaded.
+		_, ipRange, _ := net.ParseCIDR(proxy)
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...)
+}
@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Bromolima/my-game-list/internal/factory"
+	"github.com/Bromolima/my-game-list/internal/service"
+)
+
+type userArgs struct {
+	Email string `validate:"required,email"`
+}
+
+type passwordArgs struct {
+	Email    string `validate:"required,email"`
+	Password string `validate:"required,min=6,containsany=@#!&$*"`
+}
+
+type credentialArgs struct {
+	Email    string `validate:"required,email"`
+	Username string `validate:"required,max=20"`
+	Password string `validate:"required,min=6,containsany=@#!&$*"`
+}
+
+func runCreateAdmin(ctx context.Context, args []string) error {
+	flags := newFlagSet("create-admin")
+	email := flags.String("email", "", "email of the administrator")
+	username := flags.String("username", "admin", "username of the administrator")
+	password := flags.String("password", "", "password of the administrator, read from the standard input when omitted")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if *password == "" {
+		*password = readPassword()
+	}
+
+	if err := validate(&credentialArgs{Email: *email, Username: *username, Password: *password}); err != nil {
+		return err
+	}
+
+	return invoke(ctx, func(adminService service.AdminService) error {
+		user, created, restErr := adminService.CreateAdmin(ctx, *email, *username, *password)
+		if restErr != nil {
+			return restErr
+		}
+
+		if !created {
+			slog.Info("existing account is an administrator, its password was not changed", slog.String("user_id", user.ID.String()))
+			return nil
+		}
+
+		slog.Info("administrator created", slog.String("user_id", user.ID.String()))
+		return nil
+	})
+}
+
+func runResetPassword(ctx context.Context, args []string) error {
+	flags := newFlagSet("reset-password")
+	email := flags.String("email", "", "email of the user")
+	password := flags.String("password", "", "new password, read from the standard input when omitted")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if *password == "" {
+		*password = readPassword()
+	}
+
+	if err := validate(&passwordArgs{Email: *email, Password: *password}); err != nil {
+		return err
+	}
+
+	return invoke(ctx, func(adminService service.AdminService) error {
+		if restErr := adminService.ResetPassword(ctx, *email, *password); restErr != nil {
+			return restErr
+		}
+
+		slog.Info("password reset and sessions revoked")
+		return nil
+	})
+}
+
+func runRevokeSessions(ctx context.Context, args []string) error {
+	flags := newFlagSet("revoke-sessions")
+	email := flags.String("email", "", "email of the user")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if err := validate(&userArgs{Email: *email}); err != nil {
+		return err
+	}
+
+	return invoke(ctx, func(adminService service.AdminService) error {
+		if restErr := adminService.RevokeSessions(ctx, *email); restErr != nil {
+			return restErr
+		}
+
+		slog.Info("sessions revoked")
+		return nil
+	})
+}
+
+// runExportUser writes the export to a file rather than the standard output,
+// which the logs are written to.
+func runExportUser(ctx context.Context, args []string) error {
+	flags := newFlagSet("export-user")
+	email := flags.String("email", "", "email of the user")
+	output := flags.String("output", "", "file the export is written to (default user-<id>.json)")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	if err := validate(&userArgs{Email: *email}); err != nil {
+		return err
+	}
+
+	return invoke(ctx, func(adminService service.AdminService) error {
+		userExport, restErr := adminService.ExportUser(ctx, *email)
+		if restErr != nil {
+			return restErr
+		}
+
+		path := *output
+		if path == "" {
+			path = fmt.Sprintf("user-%s.json", userExport.User.ID)
+		}
+
+		content, err := json.MarshalIndent(factory.NewResponseFromUserExport(userExport, time.Now()), "", "  ")
+		if err != nil {
+			return err
+		}
+
+		// The export holds personal data, only the operator may read it.
+		if err := os.WriteFile(path, append(content, '\n'), 0o600); err != nil {
+			return err
+		}
+
+		slog.Info("user exported", slog.String("user_id", userExport.User.ID.String()), slog.String("output", path))
+		return nil
+	})
+}
+
+// readPassword reads the first line of the standard input, so that passwords
+// can be piped in instead of ending up in the shell history.
+func readPassword() string {
+	fmt.Fprint(os.Stderr, "Password: ")
+	line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	fmt.Fprintln(os.Stderr)
+
+	return strings.TrimRight(line, "\r\n")
+}
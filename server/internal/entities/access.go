@@ -1,5 +1,10 @@
 package entities
 
+import (
+	"slices"
+	"strings"
+)
+
 type AccessType string
 
 const (
@@ -7,9 +12,33 @@ const (
 	UpdateAccess AccessType = "update"
 	CreateAcess  AccessType = "create"
 	DeleteAcess  AccessType = "delete"
+
+	ManageUsersAccess  AccessType = "manage_users"
+	ManageRolesAccess  AccessType = "manage_roles"
+	ViewAuditLogAccess AccessType = "view_audit_log"
 )
 
+var AccessTypes = []AccessType{
+	ReadAccess,
+	UpdateAccess,
+	CreateAcess,
+	DeleteAcess,
+	ManageUsersAccess,
+	ManageRolesAccess,
+	ViewAuditLogAccess,
+}
+
+func (a AccessType) IsValid() bool {
+	return slices.Contains(AccessTypes, a)
+}
+
 type Access struct {
 	ID         uint       `gorm:"primaryKey;type:smallint"`
 	AccessType AccessType `gorm:"column:access_type;type:char(100);not null"`
 }
+
+// Type returns the access type without the padding Postgres adds to the
+// char column when reading it back.
+func (a Access) Type() AccessType {
+	return AccessType(strings.TrimSpace(string(a.AccessType)))
+}
@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditAction string
+
+const (
+	AuditGameCreated AuditAction = "game.created"
+	AuditGameUpdated AuditAction = "game.updated"
+	AuditGameDeleted AuditAction = "game.deleted"
+
+	AuditRoleCreated       AuditAction = "role.created"
+	AuditRoleAccessGranted AuditAction = "role.access_granted"
+	AuditRoleAccessRevoked AuditAction = "role.access_revoked"
+	AuditUserRoleChanged   AuditAction = "user.role_changed"
+
+	AuditLoginSucceeded  AuditAction = "auth.login_succeeded"
+	AuditLoginFailed     AuditAction = "auth.login_failed"
+	AuditPasswordChanged AuditAction = "user.password_changed"
+	AuditPasswordReset   AuditAction = "user.password_reset"
+	AuditSessionsRevoked AuditAction = "user.sessions_revoked"
+	AuditUserDeleted     AuditAction = "user.deleted"
+)
+
+type AuditTargetType string
+
+const (
+	AuditTargetGame AuditTargetType = "game"
+	AuditTargetRole AuditTargetType = "role"
+	AuditTargetUser AuditTargetType = "user"
+)
+
+// AuditLog records a privileged or security-relevant action. The actor is
+// kept without a foreign key so the trail survives the deletion of the user.
+type AuditLog struct {
+	ID         uuid.UUID       `gorm:"type:uuid;primaryKey"`
+	ActorID    *uuid.UUID      `gorm:"type:uuid;index"`
+	Action     AuditAction     `gorm:"type:varchar(50);not null;index"`
+	TargetType AuditTargetType `gorm:"type:varchar(50);not null;index:idx_audit_logs_target"`
+	TargetID   string          `gorm:"type:varchar(64);index:idx_audit_logs_target"`
+	Changes    json.RawMessage `gorm:"type:jsonb"`
+	IPAddress  string          `gorm:"type:varchar(45)"`
+	UserAgent  string          `gorm:"type:text"`
+	RequestID  string          `gorm:"type:varchar(64)"`
+	CreatedAt  time.Time       `gorm:"autoCreateTime;index"`
+}
+
+// AuditChange holds the value of a field before and after an action.
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+type AuditLogFilter struct {
+	ActorID    *uuid.UUID
+	Action     AuditAction
+	TargetType AuditTargetType
+	TargetID   string
+	From       *time.Time
+	To         *time.Time
+}
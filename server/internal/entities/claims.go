@@ -0,0 +1,17 @@
+package entities
+
+import "github.com/google/uuid"
+
+const (
+	TokenTypeSession             = "session"
+	TokenTypePersonalAccessToken = "personal_access_token"
+)
+
+type UserClaims struct {
+	ID        uuid.UUID
+	RoleID    uint
+	TokenType string
+	SessionID uuid.UUID
+	TokenID   uuid.UUID
+	Scopes    []AccessType
+}
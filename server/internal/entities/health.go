@@ -0,0 +1,23 @@
+package entities
+
+import "time"
+
+const (
+	HealthStatusOK    = "ok"
+	HealthStatusError = "error"
+)
+
+type HealthReport struct {
+	Status string
+	Checks []*HealthCheck
+}
+
+type HealthCheck struct {
+	Name    string
+	Status  string
+	Latency time.Duration
+}
+
+func (r *HealthReport) Healthy() bool {
+	return r.Status == HealthStatusOK
+}
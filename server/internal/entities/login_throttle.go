@@ -0,0 +1,17 @@
+package entities
+
+import "time"
+
+const (
+	LoginThrottleAccountPrefix = "account:"
+	LoginThrottleIPPrefix      = "ip:"
+)
+
+// LoginThrottle tracks the recent failed logins of an account or an IP
+// address, identified by Key.
+type LoginThrottle struct {
+	Key           string     `gorm:"type:varchar(320);primaryKey"`
+	Failures      int        `gorm:"not null;default:0"`
+	LastFailureAt time.Time  `gorm:"type:timestamp"`
+	LockedUntil   *time.Time `gorm:"type:timestamp"`
+}
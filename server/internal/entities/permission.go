@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PermissionAction string
+
+const (
+	PermissionView   PermissionAction = "view"
+	PermissionCreate PermissionAction = "create"
+	PermissionEdit   PermissionAction = "edit"
+	PermissionDelete PermissionAction = "delete"
+	PermissionShare  PermissionAction = "share"
+)
+
+var PermissionActions = []PermissionAction{
+	PermissionView,
+	PermissionCreate,
+	PermissionEdit,
+	PermissionDelete,
+	PermissionShare,
+}
+
+func (a PermissionAction) IsValid() bool {
+	return slices.Contains(PermissionActions, a)
+}
+
+type ResourceType string
+
+const (
+	ResourceGame     ResourceType = "game"
+	ResourceGameList ResourceType = "game_list"
+)
+
+var ResourceTypes = []ResourceType{
+	ResourceGame,
+	ResourceGameList,
+}
+
+func (t ResourceType) IsValid() bool {
+	return slices.Contains(ResourceTypes, t)
+}
+
+const (
+	ResourceAttributeGenre = "genre"
+)
+
+// PermissionGrant allows a user, or every user of a role, to perform an action
+// on resources of a type. A grant is narrowed to a single resource by
+// ResourceID, or to the resources whose Attribute matches Value, e.g. curators
+// of the games of a genre.
+type PermissionGrant struct {
+	ID           uuid.UUID        `gorm:"type:uuid;primaryKey"`
+	UserID       *uuid.UUID       `gorm:"type:uuid;index"`
+	RoleID       *uint            `gorm:"type:smallint;index"`
+	Action       PermissionAction `gorm:"type:varchar(20);not null"`
+	ResourceType ResourceType     `gorm:"type:varchar(50);not null;index"`
+	ResourceID   *uuid.UUID       `gorm:"type:uuid;index"`
+	Attribute    string           `gorm:"type:varchar(50)"`
+	Value        string           `gorm:"type:varchar(255)"`
+	CreatedAt    time.Time        `gorm:"autoCreateTime"`
+	User         *User            `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:Cascade"`
+	Role         *Role            `gorm:"foreignKey:RoleID;references:ID;constraint:OnDelete:Cascade"`
+}
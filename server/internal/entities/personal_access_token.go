@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PersonalAccessTokenPrefix = "mgl_pat_"
+)
+
+type PersonalAccessToken struct {
+	ID         uuid.UUID    `gorm:"primaryKey;type:uuid"`
+	Name       string       `gorm:"type:varchar(100);not null"`
+	Token      string       `gorm:"type:char(64);not null;uniqueIndex"`
+	Scopes     []AccessType `gorm:"type:text;serializer:json;not null"`
+	ExpiresAt  *time.Time   `gorm:"type:timestamp"`
+	LastUsedAt *time.Time   `gorm:"type:timestamp"`
+	RevokedAt  *time.Time   `gorm:"type:timestamp"`
+	CreatedAt  time.Time    `gorm:"autoCreateTime"`
+	UserID     uuid.UUID    `gorm:"type:uuid;not null"`
+	User       User         `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:Cascade"`
+}
@@ -0,0 +1,38 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TwoFactor struct {
+	UserID       uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Secret       string     `gorm:"type:varchar(64);not null"`
+	ConfirmedAt  *time.Time `gorm:"type:timestamp"`
+	LastUsedStep int64      `gorm:"not null;default:0"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `gorm:"autoUpdateTime"`
+	User         User       `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:Cascade"`
+}
+
+type RecoveryCode struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Code      string     `gorm:"type:char(64);not null;index"`
+	UsedAt    *time.Time `gorm:"type:timestamp"`
+	CreatedAt time.Time  `gorm:"autoCreateTime"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index"`
+	User      User       `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:Cascade"`
+}
+
+type TwoFactorChallenge struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// LoginResult holds the session tokens of a completed login, or the challenge
+// that must be answered with a second factor before the session is created.
+type LoginResult struct {
+	AuthTokens *AuthTokens
+	Challenge  *TwoFactorChallenge
+}
@@ -7,12 +7,14 @@ import (
 )
 
 type User struct {
-	ID        uuid.UUID `gorm:"primaryKey;type:uuid"`
-	Email     string    `gorm:"type:varchar(255);not null"`
-	Password  string    `gorm:"type:varchar(100);not null"`
-	Username  string    `gorm:"type:varchar(100);not null"`
-	AvatarURL string    `gorm:"type:text"`
-	CreatedAt time.Time `gorm:"autoCreateTime"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime"`
-	RoleID    uint
+	ID              uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	Email           string     `gorm:"type:varchar(255);not null"`
+	Password        string     `gorm:"type:varchar(100);not null"`
+	Username        string     `gorm:"type:varchar(100);not null"`
+	AvatarURL       string     `gorm:"type:text"`
+	EmailVerified   bool       `gorm:"not null;default:false"`
+	EmailVerifiedAt *time.Time `gorm:"type:timestamp"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt       time.Time  `gorm:"autoUpdateTime"`
+	RoleID          uint
 }
@@ -0,0 +1,16 @@
+package entities
+
+// UserExport gathers the data kept about a user, as handed over when the user
+// asks for a copy of it.
+type UserExport struct {
+	User                 *User
+	GameLists            []*GameListExport
+	Sessions             []*Session
+	PersonalAccessTokens []*PersonalAccessToken
+	AuditLogs            []*AuditLog
+}
+
+type GameListExport struct {
+	GameList *GameList
+	Games    []*Game
+}
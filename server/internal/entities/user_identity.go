@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserIdentity links a user to the subject of an external identity provider.
+type UserIdentity struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Provider  string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_user_identities_provider_subject"`
+	Subject   string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_user_identities_provider_subject"`
+	Email     string    `gorm:"type:varchar(255)"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	User      User      `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:Cascade"`
+}
+
+// OIDCLoginState keeps the nonce and PKCE verifier of a login started with an
+// identity provider until the provider redirects back with the code.
+type OIDCLoginState struct {
+	State        string    `gorm:"type:char(64);primaryKey"`
+	Provider     string    `gorm:"type:varchar(50);not null"`
+	Nonce        string    `gorm:"type:varchar(64);not null"`
+	CodeVerifier string    `gorm:"type:varchar(128);not null"`
+	ExpiresAt    time.Time `gorm:"type:timestamp;index"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+}
+
+type OIDCAuthorization struct {
+	URL       string
+	State     string
+	ExpiresAt time.Time
+}
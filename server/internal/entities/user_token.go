@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	UserTokenPurposePasswordReset     = "password_reset"
+	UserTokenPurposeEmailVerification = "email_verification"
+)
+
+type UserToken struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Purpose   string     `gorm:"type:varchar(50);not null;index"`
+	Token     string     `gorm:"type:char(64);not null;uniqueIndex"`
+	Email     string     `gorm:"type:varchar(255);not null;default:''"`
+	ExpiresAt time.Time  `gorm:"type:timestamp"`
+	UsedAt    *time.Time `gorm:"type:timestamp"`
+	CreatedAt time.Time  `gorm:"autoCreateTime"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null"`
+	User      User       `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:Cascade"`
+}
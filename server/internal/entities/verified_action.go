@@ -0,0 +1,9 @@
+package entities
+
+// VerifiedAction names an action that can be restricted to users with a
+// verified email address through the EMAIL_VERIFICATION_REQUIRED_FOR setting.
+type VerifiedAction string
+
+const (
+	VerifiedActionCreateList VerifiedAction = "create_list"
+)
@@ -0,0 +1,73 @@
+package factory
+
+import (
+	"encoding/json"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/http/dto"
+	"github.com/Bromolima/my-game-list/internal/requestctx"
+	"github.com/google/uuid"
+)
+
+func NewAuditLog(
+	metadata *requestctx.Metadata,
+	action entities.AuditAction,
+	targetType entities.AuditTargetType,
+	targetID string,
+	changes json.RawMessage,
+) *entities.AuditLog {
+	auditLog := &entities.AuditLog{
+		ID:         uuid.New(),
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Changes:    changes,
+		IPAddress:  metadata.IPAddress,
+		UserAgent:  metadata.UserAgent,
+		RequestID:  metadata.RequestID,
+	}
+
+	if metadata.ActorID != uuid.Nil {
+		actorID := metadata.ActorID
+		auditLog.ActorID = &actorID
+	}
+
+	return auditLog
+}
+
+// NewAuditSnapshotFromGame lists the audited fields of a game.
+func NewAuditSnapshotFromGame(game *entities.Game) map[string]any {
+	return map[string]any{
+		"name":        game.Name,
+		"genre":       game.Genre,
+		"developer":   game.Developer,
+		"description": game.Description,
+		"image_url":   game.ImageURL,
+	}
+}
+
+// NewAuditSnapshotFromUser lists the audited fields of a user, leaving the
+// password hash out of the trail.
+func NewAuditSnapshotFromUser(user *entities.User) map[string]any {
+	return map[string]any{
+		"username":   user.Username,
+		"email":      user.Email,
+		"avatar_url": user.AvatarURL,
+		"role_id":    user.RoleID,
+	}
+}
+
+func NewResponseFromAuditLog(auditLog *entities.AuditLog) *dto.AuditLogResponse {
+	return &dto.AuditLogResponse{
+		ID:         auditLog.ID,
+		ActorID:    auditLog.ActorID,
+		Action:     string(auditLog.Action),
+		TargetType: string(auditLog.TargetType),
+		TargetID:   auditLog.TargetID,
+		Changes:    auditLog.Changes,
+		IPAddress:  auditLog.IPAddress,
+		UserAgent:  auditLog.UserAgent,
+		RequestID:  auditLog.RequestID,
+		CreatedAt:  auditLog.CreatedAt,
+	}
+}
@@ -14,21 +14,12 @@ func NewGameList(userID uuid.UUID, name string, isPublic, isDefault bool) *entit
 	return &entities.GameList{
 		ID:        uuid.New(),
 		Name:      name,
-		IsPublic:  isDefault,
+		IsPublic:  isPublic,
 		IsDefault: isDefault,
 		UserID:    userID,
 	}
 }
 
-func NewGameListUpdate(gameListID, userID uuid.UUID, name string, isPublic bool) *entities.GameList {
-	return &entities.GameList{
-		ID:       gameListID,
-		UserID:   userID,
-		Name:     name,
-		IsPublic: isPublic,
-	}
-}
-
 func NewDefaultGameList(userID uuid.UUID) *entities.GameList {
 	return &entities.GameList{
 		ID:        uuid.New(),
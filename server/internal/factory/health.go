@@ -0,0 +1,24 @@
+package factory
+
+import (
+	"time"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/http/dto"
+)
+
+func NewResponseFromHealthReport(report *entities.HealthReport) *dto.HealthResponse {
+	checks := make([]*dto.HealthCheckResponse, 0, len(report.Checks))
+	for _, check := range report.Checks {
+		checks = append(checks, &dto.HealthCheckResponse{
+			Name:      check.Name,
+			Status:    check.Status,
+			LatencyMs: float64(check.Latency) / float64(time.Millisecond),
+		})
+	}
+
+	return &dto.HealthResponse{
+		Status: report.Status,
+		Checks: checks,
+	}
+}
@@ -0,0 +1,45 @@
+package factory
+
+import (
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/http/dto"
+	"github.com/google/uuid"
+)
+
+func NewPermissionGrant(
+	userID *uuid.UUID,
+	roleID *uint,
+	action entities.PermissionAction,
+	resourceType entities.ResourceType,
+	resourceID *uuid.UUID,
+	attribute, value string,
+) *entities.PermissionGrant {
+	return &entities.PermissionGrant{
+		ID:           uuid.New(),
+		UserID:       userID,
+		RoleID:       roleID,
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Attribute:    attribute,
+		Value:        value,
+	}
+}
+
+func NewCollaboratorGrant(collaboratorID, gameListID uuid.UUID) *entities.PermissionGrant {
+	return NewPermissionGrant(&collaboratorID, nil, entities.PermissionEdit, entities.ResourceGameList, &gameListID, "", "")
+}
+
+func NewResponseFromPermissionGrant(grant *entities.PermissionGrant) *dto.PermissionGrantResponse {
+	return &dto.PermissionGrantResponse{
+		ID:           grant.ID,
+		UserID:       grant.UserID,
+		RoleID:       grant.RoleID,
+		Action:       string(grant.Action),
+		ResourceType: string(grant.ResourceType),
+		ResourceID:   grant.ResourceID,
+		Attribute:    grant.Attribute,
+		Value:        grant.Value,
+		CreatedAt:    grant.CreatedAt,
+	}
+}
@@ -0,0 +1,43 @@
+package factory
+
+import (
+	"time"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/http/dto"
+	"github.com/google/uuid"
+)
+
+func NewPersonalAccessToken(userID uuid.UUID, name, hashedToken string, scopes []entities.AccessType, expiresAt *time.Time) *entities.PersonalAccessToken {
+	return &entities.PersonalAccessToken{
+		ID:        uuid.New(),
+		Name:      name,
+		Token:     hashedToken,
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+		UserID:    userID,
+	}
+}
+
+func NewResponseFromPersonalAccessToken(personalAccessToken *entities.PersonalAccessToken) *dto.PersonalAccessTokenResponse {
+	scopes := make([]string, 0, len(personalAccessToken.Scopes))
+	for _, scope := range personalAccessToken.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	return &dto.PersonalAccessTokenResponse{
+		ID:         personalAccessToken.ID,
+		Name:       personalAccessToken.Name,
+		Scopes:     scopes,
+		ExpiresAt:  personalAccessToken.ExpiresAt,
+		LastUsedAt: personalAccessToken.LastUsedAt,
+		CreatedAt:  personalAccessToken.CreatedAt,
+	}
+}
+
+func NewCreatedResponseFromPersonalAccessToken(personalAccessToken *entities.PersonalAccessToken, plainToken string) *dto.PersonalAccessTokenCreatedResponse {
+	return &dto.PersonalAccessTokenCreatedResponse{
+		PersonalAccessTokenResponse: *NewResponseFromPersonalAccessToken(personalAccessToken),
+		Token:                       plainToken,
+	}
+}
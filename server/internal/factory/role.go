@@ -0,0 +1,33 @@
+package factory
+
+import (
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/http/dto"
+)
+
+func NewRole(name string) *entities.Role {
+	return &entities.Role{
+		Name: name,
+	}
+}
+
+func NewResponseFromRole(role *entities.Role) *dto.RoleResponse {
+	access := make([]string, 0, len(role.Access))
+	for _, roleAccess := range role.Access {
+		access = append(access, string(roleAccess.Type()))
+	}
+
+	return &dto.RoleResponse{
+		ID:     role.ID,
+		Name:   role.Name,
+		Access: access,
+	}
+}
+
+func NewRoleMemberResponseFromUser(user *entities.User) *dto.RoleMemberResponse {
+	return &dto.RoleMemberResponse{
+		ID:       user.ID,
+		Username: user.Username,
+		Email:    user.Email,
+	}
+}
@@ -0,0 +1,50 @@
+package factory
+
+import (
+	"time"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/http/dto"
+	"github.com/google/uuid"
+)
+
+func NewSession(id, userID uuid.UUID, hashedToken, ipAddress, userAgent string, expiresAt time.Time) *entities.Session {
+	return &entities.Session{
+		ID:        id,
+		Token:     hashedToken,
+		IPAdress:  ipAddress,
+		UserAgent: userAgent,
+		ExpiresAt: expiresAt,
+		UserID:    userID,
+	}
+}
+
+func NewAuthTokens(accessToken string, accessTokenExpiresAt time.Time, refreshToken string, refreshTokenExpiresAt time.Time) *entities.AuthTokens {
+	return &entities.AuthTokens{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessTokenExpiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshTokenExpiresAt,
+	}
+}
+
+func NewResponseFromAuthTokens(tokens *entities.AuthTokens) *dto.AuthTokensResponse {
+	return &dto.AuthTokensResponse{
+		AccessToken:           tokens.AccessToken,
+		TokenType:             "Bearer",
+		ExpiresAt:             tokens.AccessTokenExpiresAt,
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt,
+	}
+}
+
+func NewResponseFromSession(session *entities.Session, currentSessionID uuid.UUID) *dto.SessionResponse {
+	return &dto.SessionResponse{
+		ID:        session.ID,
+		IPAddress: session.IPAdress,
+		UserAgent: session.UserAgent,
+		CreatedAt: session.CreatedAt,
+		ExpiresAt: session.ExpiresAt,
+		Current:   session.ID == currentSessionID,
+	}
+}
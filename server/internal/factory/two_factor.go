@@ -0,0 +1,54 @@
+package factory
+
+import (
+	"time"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/http/dto"
+	"github.com/google/uuid"
+)
+
+func NewTwoFactor(userID uuid.UUID, secret string) *entities.TwoFactor {
+	return &entities.TwoFactor{
+		UserID: userID,
+		Secret: secret,
+	}
+}
+
+func NewRecoveryCode(userID uuid.UUID, hashedCode string) *entities.RecoveryCode {
+	return &entities.RecoveryCode{
+		ID:     uuid.New(),
+		Code:   hashedCode,
+		UserID: userID,
+	}
+}
+
+func NewLoginResult(tokens *entities.AuthTokens) *entities.LoginResult {
+	return &entities.LoginResult{
+		AuthTokens: tokens,
+	}
+}
+
+func NewChallengeLoginResult(challengeToken string, expiresAt time.Time) *entities.LoginResult {
+	return &entities.LoginResult{
+		Challenge: &entities.TwoFactorChallenge{
+			Token:     challengeToken,
+			ExpiresAt: expiresAt,
+		},
+	}
+}
+
+func NewTwoFactorEnrollmentResponse(secret, uri string) *dto.TwoFactorEnrollmentResponse {
+	return &dto.TwoFactorEnrollmentResponse{
+		Secret:     secret,
+		OtpauthURI: uri,
+	}
+}
+
+func NewResponseFromTwoFactorChallenge(challenge *entities.TwoFactorChallenge) *dto.TwoFactorChallengeResponse {
+	return &dto.TwoFactorChallengeResponse{
+		TwoFactorRequired: true,
+		ChallengeToken:    challenge.Token,
+		ExpiresAt:         challenge.ExpiresAt,
+	}
+}
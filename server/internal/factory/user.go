@@ -17,20 +17,22 @@ func NewUser(email, hashedPassword, username, avatarURL string, roleID uint) *en
 	}
 }
 
-func NewUserUpdate(id uuid.UUID, email, hashedPassword, username, avatarURL string) *entities.User {
-	return &entities.User{
+func NewUserClaims(id uuid.UUID, roleID uint, sessionID uuid.UUID) *entities.UserClaims {
+	return &entities.UserClaims{
 		ID:        id,
-		Email:     email,
-		Password:  hashedPassword,
-		Username:  username,
-		AvatarURL: avatarURL,
+		RoleID:    roleID,
+		TokenType: entities.TokenTypeSession,
+		SessionID: sessionID,
 	}
 }
 
-func NewUserClaims(id uuid.UUID, roleID uint) *entities.User {
-	return &entities.User{
-		ID:     id,
-		RoleID: roleID,
+func NewPersonalAccessTokenClaims(id uuid.UUID, roleID uint, tokenID uuid.UUID, scopes []entities.AccessType) *entities.UserClaims {
+	return &entities.UserClaims{
+		ID:        id,
+		RoleID:    roleID,
+		TokenType: entities.TokenTypePersonalAccessToken,
+		TokenID:   tokenID,
+		Scopes:    scopes,
 	}
 }
 
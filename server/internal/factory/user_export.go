@@ -0,0 +1,64 @@
+package factory
+
+import (
+	"time"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/http/dto"
+	"github.com/google/uuid"
+)
+
+// NewResponseFromUserExport converts an export to the document handed to the
+// user. Password, token and secret hashes are left out.
+func NewResponseFromUserExport(userExport *entities.UserExport, exportedAt time.Time) *dto.UserExportResponse {
+	user := userExport.User
+	response := &dto.UserExportResponse{
+		User: dto.UserExportProfile{
+			ID:              user.ID,
+			Email:           user.Email,
+			Username:        user.Username,
+			AvatarURL:       user.AvatarURL,
+			EmailVerified:   user.EmailVerified,
+			EmailVerifiedAt: user.EmailVerifiedAt,
+			RoleID:          user.RoleID,
+			CreatedAt:       user.CreatedAt,
+			UpdatedAt:       user.UpdatedAt,
+		},
+		GameLists:            make([]*dto.GameListExportResponse, 0, len(userExport.GameLists)),
+		Sessions:             make([]*dto.SessionResponse, 0, len(userExport.Sessions)),
+		PersonalAccessTokens: make([]*dto.PersonalAccessTokenResponse, 0, len(userExport.PersonalAccessTokens)),
+		AuditLogs:            make([]*dto.AuditLogResponse, 0, len(userExport.AuditLogs)),
+		ExportedAt:           exportedAt,
+	}
+
+	for _, gameListExport := range userExport.GameLists {
+		gameList := &dto.GameListExportResponse{
+			ID:        gameListExport.GameList.ID,
+			Name:      gameListExport.GameList.Name,
+			IsPublic:  gameListExport.GameList.IsPublic,
+			IsDefault: gameListExport.GameList.IsDefault,
+			CreatedAt: gameListExport.GameList.CreatedAt,
+			Games:     make([]*dto.GameResponse, 0, len(gameListExport.Games)),
+		}
+
+		for _, game := range gameListExport.Games {
+			gameList.Games = append(gameList.Games, NewResponseFromGame(game))
+		}
+
+		response.GameLists = append(response.GameLists, gameList)
+	}
+
+	for _, session := range userExport.Sessions {
+		response.Sessions = append(response.Sessions, NewResponseFromSession(session, uuid.Nil))
+	}
+
+	for _, personalAccessToken := range userExport.PersonalAccessTokens {
+		response.PersonalAccessTokens = append(response.PersonalAccessTokens, NewResponseFromPersonalAccessToken(personalAccessToken))
+	}
+
+	for _, auditLog := range userExport.AuditLogs {
+		response.AuditLogs = append(response.AuditLogs, NewResponseFromAuditLog(auditLog))
+	}
+
+	return response
+}
@@ -0,0 +1,36 @@
+package factory
+
+import (
+	"time"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/google/uuid"
+)
+
+func NewUserIdentity(userID uuid.UUID, provider, subject, email string) *entities.UserIdentity {
+	return &entities.UserIdentity{
+		ID:       uuid.New(),
+		Provider: provider,
+		Subject:  subject,
+		Email:    email,
+		UserID:   userID,
+	}
+}
+
+func NewOIDCLoginState(hashedState, provider, nonce, codeVerifier string, expiresAt time.Time) *entities.OIDCLoginState {
+	return &entities.OIDCLoginState{
+		State:        hashedState,
+		Provider:     provider,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    expiresAt,
+	}
+}
+
+func NewOIDCAuthorization(url, state string, expiresAt time.Time) *entities.OIDCAuthorization {
+	return &entities.OIDCAuthorization{
+		URL:       url,
+		State:     state,
+		ExpiresAt: expiresAt,
+	}
+}
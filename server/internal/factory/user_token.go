@@ -0,0 +1,19 @@
+package factory
+
+import (
+	"time"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/google/uuid"
+)
+
+func NewUserToken(userID uuid.UUID, email, purpose, hashedToken string, expiresAt time.Time) *entities.UserToken {
+	return &entities.UserToken{
+		ID:        uuid.New(),
+		Purpose:   purpose,
+		Token:     hashedToken,
+		Email:     email,
+		ExpiresAt: expiresAt,
+		UserID:    userID,
+	}
+}
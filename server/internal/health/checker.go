@@ -0,0 +1,96 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Bromolima/my-game-list/database/migrate"
+	"github.com/Bromolima/my-game-list/database/migrations"
+	"github.com/Bromolima/my-game-list/internal/mailer"
+	"gorm.io/gorm"
+)
+
+// ReadinessGroup is the dig value group of the checkers run by the readiness
+// probe. A component adds its own check by providing a Checker in the group.
+const ReadinessGroup = "readiness_checkers"
+
+var ErrMigrationVersionMismatch = errors.New("database schema version does not match the migrations")
+
+// Checker reports whether a dependency of the server is usable. Check must
+// return once the context is done.
+type Checker interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+type checker struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+func NewChecker(name string, check func(ctx context.Context) error) Checker {
+	return &checker{name: name, check: check}
+}
+
+func (c *checker) Name() string {
+	return c.name
+}
+
+func (c *checker) Check(ctx context.Context) error {
+	return c.check(ctx)
+}
+
+// NewDatabaseChecker pings the database through the connection pool.
+func NewDatabaseChecker(db *gorm.DB) Checker {
+	return NewChecker("database", func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+
+		return sqlDB.PingContext(ctx)
+	})
+}
+
+// NewMigrationChecker compares the last applied migration with the last
+// migration embedded in the binary, so that a server is not sent traffic
+// before the schema it expects is in place.
+func NewMigrationChecker(db *gorm.DB) (Checker, error) {
+	files, err := migrate.Load(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+
+	var expected int64
+	if len(files) > 0 {
+		expected = files[len(files)-1].Version
+	}
+
+	return NewChecker("migrations", func(ctx context.Context) error {
+		var applied int64
+		if err := db.WithContext(ctx).
+			Raw("SELECT COALESCE(MAX(version), 0) FROM schema_migrations").
+			Scan(&applied).Error; err != nil {
+			return err
+		}
+
+		if applied != expected {
+			return fmt.Errorf("%w: applied %d, expected %d", ErrMigrationVersionMismatch, applied, expected)
+		}
+
+		return nil
+	}), nil
+}
+
+// NewMailerChecker pings the mail server. Mailers without an external
+// dependency are always reported healthy.
+func NewMailerChecker(m mailer.Mailer) Checker {
+	return NewChecker("mailer", func(ctx context.Context) error {
+		if pinger, ok := m.(mailer.Pinger); ok {
+			return pinger.Ping(ctx)
+		}
+
+		return nil
+	})
+}
@@ -2,13 +2,22 @@ package cookie
 
 import (
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/Bromolima/my-game-list/config"
 	"github.com/labstack/echo/v4"
 )
 
 var (
-	CookieName = "my_game_list_id"
+	CookieName        = "my_game_list_id"
+	RefreshCookieName = "my_game_list_refresh"
+	RefreshCookiePath = "/auth"
+
+	OIDCStateCookieName = "my_game_list_oidc_state"
+	OIDCStateCookiePath = "/auth/oidc"
+
+	CSRFCookieName = "my_game_list_csrf"
 )
 
 func GetCookie(ectx echo.Context) (*http.Cookie, error) {
@@ -20,30 +29,126 @@ func GetCookie(ectx echo.Context) (*http.Cookie, error) {
 	return cookie, nil
 }
 
-func SetCookie(ectx echo.Context, value string) {
-	cookie := &http.Cookie{
-		Name:     CookieName,
-		Value:    value,
-		Path:     "/",
-		HttpOnly: true,
-		Secure:   false,
-		SameSite: http.SameSiteStrictMode,
-		Expires:  time.Now().Add(24 * time.Hour),
-	}
+func SetCookie(ectx echo.Context, value string, expiresAt time.Time) {
+	cookie := newCookie(CookieName, value, "/", sameSite())
+	cookie.HttpOnly = true
+	cookie.Expires = sessionExpiration(expiresAt)
 
 	ectx.SetCookie(cookie)
 }
 
 func DeleteCookie(ectx echo.Context) {
-	cookie := &http.Cookie{
-		Name:     CookieName,
-		Value:    "",
-		Path:     "/",
-		HttpOnly: true,
-		Secure:   false,
-		SameSite: http.SameSiteStrictMode,
-		MaxAge:   -1,
+	cookie := newCookie(CookieName, "", "/", sameSite())
+	cookie.HttpOnly = true
+	cookie.MaxAge = -1
+
+	ectx.SetCookie(cookie)
+}
+
+func GetRefreshCookie(ectx echo.Context) (*http.Cookie, error) {
+	cookie, err := ectx.Cookie(RefreshCookieName)
+	if err != nil {
+		return nil, err
+	}
+
+	return cookie, nil
+}
+
+func SetRefreshCookie(ectx echo.Context, value string, expiresAt time.Time) {
+	cookie := newCookie(RefreshCookieName, value, RefreshCookiePath, sameSite())
+	cookie.HttpOnly = true
+	cookie.Expires = sessionExpiration(expiresAt)
+
+	ectx.SetCookie(cookie)
+}
+
+func DeleteRefreshCookie(ectx echo.Context) {
+	cookie := newCookie(RefreshCookieName, "", RefreshCookiePath, sameSite())
+	cookie.HttpOnly = true
+	cookie.MaxAge = -1
+
+	ectx.SetCookie(cookie)
+}
+
+// The state cookie is sent back on the redirect from the identity provider,
+// which is a cross-site navigation, so it cannot use the strict SameSite mode.
+func GetOIDCStateCookie(ectx echo.Context) (*http.Cookie, error) {
+	cookie, err := ectx.Cookie(OIDCStateCookieName)
+	if err != nil {
+		return nil, err
+	}
+
+	return cookie, nil
+}
+
+func SetOIDCStateCookie(ectx echo.Context, value string, expiresAt time.Time) {
+	cookie := newCookie(OIDCStateCookieName, value, OIDCStateCookiePath, http.SameSiteLaxMode)
+	cookie.HttpOnly = true
+	cookie.Expires = expiresAt
+
+	ectx.SetCookie(cookie)
+}
+
+func DeleteOIDCStateCookie(ectx echo.Context) {
+	cookie := newCookie(OIDCStateCookieName, "", OIDCStateCookiePath, http.SameSiteLaxMode)
+	cookie.HttpOnly = true
+	cookie.MaxAge = -1
+
+	ectx.SetCookie(cookie)
+}
+
+// The CSRF cookie must be readable by the frontend, which echoes its value in
+// the X-CSRF-Token header of unsafe requests.
+func GetCSRFCookie(ectx echo.Context) (*http.Cookie, error) {
+	cookie, err := ectx.Cookie(CSRFCookieName)
+	if err != nil {
+		return nil, err
+	}
+
+	return cookie, nil
+}
+
+func SetCSRFCookie(ectx echo.Context, value string) {
+	cookie := newCookie(CSRFCookieName, value, "/", sameSite())
+	if config.Env.Cookie.Lifetime > 0 {
+		cookie.Expires = time.Now().Add(config.Env.Cookie.Lifetime)
 	}
 
 	ectx.SetCookie(cookie)
 }
+
+func newCookie(name, value, path string, sameSite http.SameSite) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   config.Env.Cookie.Domain,
+		Secure:   config.Env.Cookie.Secure,
+		SameSite: sameSite,
+	}
+}
+
+func sameSite() http.SameSite {
+	switch strings.ToLower(config.Env.Cookie.SameSite) {
+	case "lax":
+		return http.SameSiteLaxMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteStrictMode
+	}
+}
+
+// sessionExpiration shortens the expiration of a session cookie to the
+// configured lifetime, if any.
+func sessionExpiration(expiresAt time.Time) time.Time {
+	if config.Env.Cookie.Lifetime <= 0 {
+		return expiresAt
+	}
+
+	if limit := time.Now().Add(config.Env.Cookie.Lifetime); limit.Before(expiresAt) {
+		return limit
+	}
+
+	return expiresAt
+}
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditLogSearchRequest struct {
+	Page       int        `query:"page"`
+	Limit      int        `query:"limit"`
+	ActorID    *uuid.UUID `query:"actor_id"`
+	Action     string     `query:"action"`
+	TargetType string     `query:"target_type"`
+	TargetID   string     `query:"target_id"`
+	From       *time.Time `query:"from"`
+	To         *time.Time `query:"to"`
+}
+
+type AuditLogResponse struct {
+	ID         uuid.UUID       `json:"id"`
+	ActorID    *uuid.UUID      `json:"actor_id"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"target_type"`
+	TargetID   string          `json:"target_id"`
+	Changes    json.RawMessage `json:"changes,omitempty"`
+	IPAddress  string          `json:"ip_address"`
+	UserAgent  string          `json:"user_agent"`
+	RequestID  string          `json:"request_id"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
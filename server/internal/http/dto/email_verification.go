@@ -0,0 +1,5 @@
+package dto
+
+type ConfirmEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
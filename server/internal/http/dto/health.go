@@ -0,0 +1,12 @@
+package dto
+
+type HealthResponse struct {
+	Status string                 `json:"status"`
+	Checks []*HealthCheckResponse `json:"checks"`
+}
+
+type HealthCheckResponse struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+}
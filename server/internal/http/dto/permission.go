@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PermissionGrantCreateRequest struct {
+	UserID       *uuid.UUID `json:"user_id"`
+	RoleID       *uint      `json:"role_id"`
+	Action       string     `json:"action" validate:"required"`
+	ResourceType string     `json:"resource_type" validate:"required"`
+	ResourceID   *uuid.UUID `json:"resource_id"`
+	Attribute    string     `json:"attribute" validate:"max=50"`
+	Value        string     `json:"value" validate:"max=255"`
+}
+
+type PermissionGrantResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	UserID       *uuid.UUID `json:"user_id,omitempty"`
+	RoleID       *uint      `json:"role_id,omitempty"`
+	Action       string     `json:"action"`
+	ResourceType string     `json:"resource_type"`
+	ResourceID   *uuid.UUID `json:"resource_id,omitempty"`
+	Attribute    string     `json:"attribute,omitempty"`
+	Value        string     `json:"value,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type GameListCollaboratorRequest struct {
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+}
@@ -0,0 +1,29 @@
+package dto
+
+import "github.com/google/uuid"
+
+type RoleCreateRequest struct {
+	Name   string   `json:"name" validate:"required,min=3,max=50"`
+	Access []string `json:"access" validate:"dive,required"`
+}
+
+type RoleAssignRequest struct {
+	RoleID uint `json:"role_id" validate:"required"`
+}
+
+type RoleUsersRequest struct {
+	Page  int `query:"page"`
+	Limit int `query:"limit"`
+}
+
+type RoleResponse struct {
+	ID     uint     `json:"id"`
+	Name   string   `json:"name"`
+	Access []string `json:"access"`
+}
+
+type RoleMemberResponse struct {
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username"`
+	Email    string    `json:"email"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UserExportResponse struct {
+	User                 UserExportProfile              `json:"user"`
+	GameLists            []*GameListExportResponse      `json:"game_lists"`
+	Sessions             []*SessionResponse             `json:"sessions"`
+	PersonalAccessTokens []*PersonalAccessTokenResponse `json:"personal_access_tokens"`
+	AuditLogs            []*AuditLogResponse            `json:"audit_logs"`
+	ExportedAt           time.Time                      `json:"exported_at"`
+}
+
+type UserExportProfile struct {
+	ID              uuid.UUID  `json:"id"`
+	Email           string     `json:"email"`
+	Username        string     `json:"username"`
+	AvatarURL       string     `json:"avatar_url"`
+	EmailVerified   bool       `json:"email_verified"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	RoleID          uint       `json:"role_id"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+type GameListExportResponse struct {
+	ID        uuid.UUID       `json:"id"`
+	Name      string          `json:"name"`
+	IsPublic  bool            `json:"is_public"`
+	IsDefault bool            `json:"is_default"`
+	CreatedAt time.Time       `json:"created_at"`
+	Games     []*GameResponse `json:"games"`
+}
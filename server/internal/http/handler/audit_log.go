@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/factory"
+	"github.com/Bromolima/my-game-list/internal/http/dto"
+	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/service"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/labstack/echo/v4"
+)
+
+type AuditLogHandler struct {
+	auditService service.AuditService
+	logger       *slog.Logger
+}
+
+func NewAuditLogHandler(auditService service.AuditService, logger *slog.Logger) *AuditLogHandler {
+	return &AuditLogHandler{
+		auditService: auditService,
+		logger:       logger.With(slog.String("handler", "auditLog")),
+	}
+}
+
+func (h *AuditLogHandler) SearchAuditLogs(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "SearchAuditLogs"))
+
+	var searchRequest dto.AuditLogSearchRequest
+	if err := ectx.Bind(&searchRequest); err != nil {
+		log.Warn("Failed to bind request payload", slog.String("error", err.Error()))
+		restErr := resterr.NewBadRequestError("An error occurred while binding the request payload")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	filter := &entities.AuditLogFilter{
+		ActorID:    searchRequest.ActorID,
+		Action:     entities.AuditAction(searchRequest.Action),
+		TargetType: entities.AuditTargetType(searchRequest.TargetType),
+		TargetID:   searchRequest.TargetID,
+		From:       searchRequest.From,
+		To:         searchRequest.To,
+	}
+
+	page, restErr := h.auditService.SearchAuditLogs(
+		ectx.Request().Context(),
+		factory.NewPage[entities.AuditLog](searchRequest.Page, searchRequest.Limit),
+		filter,
+	)
+	if restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	log.Info("Audit logs searched successfully")
+	return ectx.JSON(http.StatusOK, factory.NewReponseFromPage(page, factory.NewResponseFromAuditLog))
+}
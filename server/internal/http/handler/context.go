@@ -1,20 +1,43 @@
 package handler
 
 import (
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/requestctx"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
 const (
+	UserClaimsKey = "user_claims"
+
 	userIDKey     = "user_id"
 	gameIDKey     = "game_id"
 	gameListIDKey = "game_list_id"
 )
 
+func SetUserClaims(c echo.Context, claims *entities.UserClaims) {
+	c.Set(UserClaimsKey, claims)
+	c.Set(userIDKey, claims.ID)
+	metadata := requestctx.MetadataFrom(c.Request().Context())
+	metadata.ActorID = claims.ID
+	metadata.ActorRoleID = claims.RoleID
+}
+
+func GetUserClaims(c echo.Context) *entities.UserClaims {
+	return c.Get(UserClaimsKey).(*entities.UserClaims)
+}
+
 func GetUserID(c echo.Context) uuid.UUID {
 	return c.Get(string(userIDKey)).(uuid.UUID)
 }
 
+// GetOptionalUserID returns the ID of the authenticated user, or uuid.Nil when
+// the route allows anonymous requests and the request carries no token.
+func GetOptionalUserID(c echo.Context) uuid.UUID {
+	userID, _ := c.Get(userIDKey).(uuid.UUID)
+	return userID
+}
+
 func GetGameID(c echo.Context) uuid.UUID {
 	return c.Get(string(gameIDKey)).(uuid.UUID)
 }
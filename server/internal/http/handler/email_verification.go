@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/Bromolima/my-game-list/internal/http/dto"
+	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/service"
+	validation "github.com/Bromolima/my-game-list/internal/validation"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/labstack/echo/v4"
+)
+
+type EmailVerificationHandler struct {
+	emailVerificationService service.EmailVerificationService
+	logger                   *slog.Logger
+}
+
+func NewEmailVerificationHandler(emailVerificationService service.EmailVerificationService, logger *slog.Logger) *EmailVerificationHandler {
+	return &EmailVerificationHandler{
+		emailVerificationService: emailVerificationService,
+		logger:                   logger.With(slog.String("handler", "emailVerification")),
+	}
+}
+
+func (h *EmailVerificationHandler) ConfirmEmail(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "ConfirmEmail"))
+
+	var confirmRequest dto.ConfirmEmailRequest
+	if err := ectx.Bind(&confirmRequest); err != nil {
+		log.Warn("Failed to bind request payload")
+		restErr := resterr.NewBadRequestError("An error occurred while binding the request payload")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	if err := ectx.Validate(confirmRequest); err != nil {
+		log.Warn("Request payload validation failed")
+		restErr := validation.ValidateUserError(err)
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	if restErr := h.emailVerificationService.ConfirmEmail(ectx.Request().Context(), confirmRequest.Token); restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	log.Info("Email verified successfully")
+	return ectx.NoContent(http.StatusNoContent)
+}
+
+func (h *EmailVerificationHandler) ResendVerification(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "ResendVerification"))
+
+	userClaims := GetUserClaims(ectx)
+
+	if restErr := h.emailVerificationService.ResendVerification(ectx.Request().Context(), userClaims.ID); restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	log.Info("Verification email resent successfully")
+	return ectx.NoContent(http.StatusAccepted)
+}
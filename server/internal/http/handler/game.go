@@ -10,6 +10,7 @@ import (
 	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
 	"github.com/Bromolima/my-game-list/internal/service"
 	"github.com/Bromolima/my-game-list/internal/validation"
+	"github.com/Bromolima/my-game-list/logger"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
@@ -27,7 +28,7 @@ func NewGameHandler(gameService service.GameService, logger *slog.Logger) *GameH
 }
 
 func (h *GameHandler) CreateGame(ectx echo.Context) error {
-	log := h.logger.With(slog.String("func", "CreateGame"))
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "CreateGame"))
 
 	var createRequest dto.GameCreateRequest
 	if err := ectx.Bind(&createRequest); err != nil {
@@ -42,8 +43,11 @@ func (h *GameHandler) CreateGame(ectx echo.Context) error {
 		return ectx.JSON(restErr.Code, restErr)
 	}
 
+	userClaims := GetUserClaims(ectx)
+
 	if restErr := h.gameService.CreateGame(
 		ectx.Request().Context(),
+		userClaims.ID,
 		createRequest.Name,
 		createRequest.Genre,
 		createRequest.Developer,
@@ -58,7 +62,7 @@ func (h *GameHandler) CreateGame(ectx echo.Context) error {
 }
 
 func (h *GameHandler) SearchGames(ectx echo.Context) error {
-	log := h.logger.With(slog.String("func", "SearchGames"))
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "SearchGames"))
 
 	var searchRequest dto.GamesSearchRequest
 	if err := ectx.Bind(&searchRequest); err != nil {
@@ -83,7 +87,7 @@ func (h *GameHandler) SearchGames(ectx echo.Context) error {
 }
 
 func (h *GameHandler) UpdateGame(ectx echo.Context) error {
-	log := h.logger.With(slog.String("func", "UpdateGame"))
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "UpdateGame"))
 
 	id := ectx.Param("id")
 	var updateRequest dto.GameUpdateRequest
@@ -106,8 +110,11 @@ func (h *GameHandler) UpdateGame(ectx echo.Context) error {
 		return ectx.JSON(restErr.Code, restErr)
 	}
 
+	userClaims := GetUserClaims(ectx)
+
 	if restErr := h.gameService.UpdateGame(
 		ectx.Request().Context(),
+		userClaims.ID,
 		gameID,
 		*updateRequest.Name,
 		*updateRequest.Genre,
@@ -123,10 +130,12 @@ func (h *GameHandler) UpdateGame(ectx echo.Context) error {
 }
 
 func (h *GameHandler) DeleteGame(ectx echo.Context) error {
-	log := h.logger.With(slog.String("func", "DeleteGame"))
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "DeleteGame"))
 
 	id := ectx.Param("id")
-	if restErr := h.gameService.DeleteGame(ectx.Request().Context(), id); restErr != nil {
+	userClaims := GetUserClaims(ectx)
+
+	if restErr := h.gameService.DeleteGame(ectx.Request().Context(), userClaims.ID, id); restErr != nil {
 		return ectx.JSON(restErr.Code, restErr)
 	}
 
@@ -8,15 +8,15 @@ import (
 	"github.com/Bromolima/my-game-list/internal/http/dto"
 	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
 	"github.com/Bromolima/my-game-list/internal/service"
-	"github.com/Bromolima/my-game-list/internal/token"
 	"github.com/Bromolima/my-game-list/internal/validation"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
 type GameListHandler struct {
 	gameListService service.GameListService
 	logger          *slog.Logger
-	jwtService      token.JwtService
 }
 
 func NewGameListHandler(gameListService service.GameListService, logger *slog.Logger) *GameListHandler {
@@ -27,7 +27,7 @@ func NewGameListHandler(gameListService service.GameListService, logger *slog.Lo
 }
 
 func (h *GameListHandler) CreateGameList(ectx echo.Context) error {
-	log := h.logger.With(slog.String("func", "CreateGameList"))
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "CreateGameList"))
 
 	var createRequest dto.GameListCreateRequest
 	if err := ectx.Bind(&createRequest); err != nil {
@@ -42,12 +42,7 @@ func (h *GameListHandler) CreateGameList(ectx echo.Context) error {
 		return ectx.JSON(restErr.Code, restErr)
 	}
 
-	userClaims, err := h.jwtService.ExtractToken(ectx)
-	if err != nil {
-		log.Warn("Failed to extract token from context")
-		restErr := resterr.NewUnauthorizedError("Failed to extract token")
-		return ectx.JSON(restErr.Code, restErr)
-	}
+	userClaims := GetUserClaims(ectx)
 
 	if restErr := h.gameListService.CreateGameList(
 		ectx.Request().Context(),
@@ -64,10 +59,16 @@ func (h *GameListHandler) CreateGameList(ectx echo.Context) error {
 }
 
 func (h *GameListHandler) FindGamesFromList(ectx echo.Context) error {
-	log := h.logger.With(slog.String("func", "FindGamesFromList"))
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "FindGamesFromList"))
+
+	gameListID, restErr := parseGameListID(ectx)
+	if restErr != nil {
+		log.Warn("Failed to parse game list ID from path parameter")
+		return ectx.JSON(restErr.Code, restErr)
+	}
 
-	gameListID := GetGameListID(ectx)
-	gameList, restErr := h.gameListService.FindGamesFromList(ectx.Request().Context(), gameListID)
+	// Public lists can be read anonymously, in which case the user ID is nil.
+	gameList, restErr := h.gameListService.FindGamesFromList(ectx.Request().Context(), GetOptionalUserID(ectx), gameListID)
 	if restErr != nil {
 		return ectx.JSON(restErr.Code, restErr)
 	}
@@ -82,7 +83,13 @@ func (h *GameListHandler) FindGamesFromList(ectx echo.Context) error {
 }
 
 func (h *GameListHandler) UpdateGameList(ectx echo.Context) error {
-	log := h.logger.With(slog.String("func", "UpdateGameList"))
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "UpdateGameList"))
+
+	gameListID, restErr := parseGameListID(ectx)
+	if restErr != nil {
+		log.Warn("Failed to parse game list ID from path parameter")
+		return ectx.JSON(restErr.Code, restErr)
+	}
 
 	var updateRequest dto.GameListUpdateRequest
 	if err := ectx.Bind(&updateRequest); err != nil {
@@ -97,17 +104,12 @@ func (h *GameListHandler) UpdateGameList(ectx echo.Context) error {
 		return ectx.JSON(restErr.Code, restErr)
 	}
 
-	userClaims, err := h.jwtService.ExtractToken(ectx)
-	if err != nil {
-		log.Warn("Failed to extract token from context")
-		restErr := resterr.NewUnauthorizedError("Failed to extract token")
-		return ectx.JSON(restErr.Code, restErr)
-	}
+	userClaims := GetUserClaims(ectx)
 
 	if restErr := h.gameListService.UpdateGameList(
 		ectx.Request().Context(),
 		userClaims.ID,
-		GetGameListID(ectx),
+		gameListID,
 		*updateRequest.Name,
 		*updateRequest.IsPublic,
 	); restErr != nil {
@@ -119,16 +121,16 @@ func (h *GameListHandler) UpdateGameList(ectx echo.Context) error {
 }
 
 func (h *GameListHandler) DeleteGameList(ectx echo.Context) error {
-	log := h.logger.With(slog.String("func", "DeleteGameList"))
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "DeleteGameList"))
 
-	gameListID := GetGameListID(ectx)
-	userClaims, err := h.jwtService.ExtractToken(ectx)
-	if err != nil {
-		log.Warn("Failed to extract token from context")
-		restErr := resterr.NewUnauthorizedError("Failed to extract token")
+	gameListID, restErr := parseGameListID(ectx)
+	if restErr != nil {
+		log.Warn("Failed to parse game list ID from path parameter")
 		return ectx.JSON(restErr.Code, restErr)
 	}
 
+	userClaims := GetUserClaims(ectx)
+
 	if restErr := h.gameListService.DeleteGameList(ectx.Request().Context(), gameListID, userClaims.ID); restErr != nil {
 		return ectx.JSON(restErr.Code, restErr)
 	}
@@ -136,3 +138,75 @@ func (h *GameListHandler) DeleteGameList(ectx echo.Context) error {
 	log.Info("Game list deleted successfully")
 	return ectx.NoContent(http.StatusOK)
 }
+
+func (h *GameListHandler) AddCollaborator(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "AddCollaborator"))
+
+	gameListID, restErr := parseGameListID(ectx)
+	if restErr != nil {
+		log.Warn("Failed to parse game list ID from path parameter")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	var collaboratorRequest dto.GameListCollaboratorRequest
+	if err := ectx.Bind(&collaboratorRequest); err != nil {
+		log.Warn("Failed to bind request payload", slog.String("error", err.Error()))
+		restErr := resterr.NewBadRequestError("An error occurred while binding the request payload")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	if err := ectx.Validate(collaboratorRequest); err != nil {
+		log.Warn("Request payload validation failed", slog.String("error", err.Error()))
+		restErr := validation.ValidateUserError(err)
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	userClaims := GetUserClaims(ectx)
+
+	if restErr := h.gameListService.AddCollaborator(
+		ectx.Request().Context(),
+		userClaims.ID,
+		gameListID,
+		collaboratorRequest.UserID,
+	); restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	log.Info("Collaborator added successfully")
+	return ectx.NoContent(http.StatusNoContent)
+}
+
+func (h *GameListHandler) RemoveCollaborator(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "RemoveCollaborator"))
+
+	gameListID, restErr := parseGameListID(ectx)
+	if restErr != nil {
+		log.Warn("Failed to parse game list ID from path parameter")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	collaboratorID, err := uuid.Parse(ectx.Param("userId"))
+	if err != nil {
+		log.Warn("Failed to parse user ID from path parameter", slog.String("error", err.Error()))
+		restErr := resterr.NewBadRequestError("An error occurred while parsing the id")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	userClaims := GetUserClaims(ectx)
+
+	if restErr := h.gameListService.RemoveCollaborator(ectx.Request().Context(), userClaims.ID, gameListID, collaboratorID); restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	log.Info("Collaborator removed successfully")
+	return ectx.NoContent(http.StatusNoContent)
+}
+
+func parseGameListID(ectx echo.Context) (uuid.UUID, *resterr.RestErr) {
+	gameListID, err := uuid.Parse(ectx.Param("id"))
+	if err != nil {
+		return uuid.Nil, resterr.NewBadRequestError("An error occurred while parsing the id")
+	}
+
+	return gameListID, nil
+}
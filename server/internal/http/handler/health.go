@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/factory"
+	"github.com/Bromolima/my-game-list/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+// HealthHandler does not log the probes, which orchestrators send every few
+// seconds. Failed checks are logged by the service.
+type HealthHandler struct {
+	healthService service.HealthService
+}
+
+func NewHealthHandler(healthService service.HealthService) *HealthHandler {
+	return &HealthHandler{
+		healthService: healthService,
+	}
+}
+
+func (h *HealthHandler) Liveness(ectx echo.Context) error {
+	return healthResponse(ectx, h.healthService.Liveness(ectx.Request().Context()))
+}
+
+func (h *HealthHandler) Readiness(ectx echo.Context) error {
+	return healthResponse(ectx, h.healthService.Readiness(ectx.Request().Context()))
+}
+
+// healthResponse answers 503 when a check failed, which is what orchestrators
+// look at, and is never cached.
+func healthResponse(ectx echo.Context, report *entities.HealthReport) error {
+	ectx.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+
+	code := http.StatusOK
+	if !report.Healthy() {
+		code = http.StatusServiceUnavailable
+	}
+
+	return ectx.JSON(code, factory.NewResponseFromHealthReport(report))
+}
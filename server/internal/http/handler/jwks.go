@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/Bromolima/my-game-list/internal/token"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	jwksCacheControl = "public, max-age=300"
+)
+
+type JwksHandler struct {
+	keyStore token.KeyStore
+	logger   *slog.Logger
+}
+
+func NewJwksHandler(keyStore token.KeyStore, logger *slog.Logger) *JwksHandler {
+	return &JwksHandler{
+		keyStore: keyStore,
+		logger:   logger.With(slog.String("handler", "jwks")),
+	}
+}
+
+func (h *JwksHandler) GetJwks(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "GetJwks"))
+
+	ectx.Response().Header().Set(echo.HeaderCacheControl, jwksCacheControl)
+
+	log.Info("JWKS retrieved successfully")
+	return ectx.JSON(http.StatusOK, token.NewJwks(h.keyStore.PublicKeys()))
+}
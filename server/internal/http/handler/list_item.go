@@ -7,34 +7,27 @@ import (
 	"github.com/Bromolima/my-game-list/internal/http/dto"
 	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
 	"github.com/Bromolima/my-game-list/internal/service"
-	"github.com/Bromolima/my-game-list/internal/token"
 	"github.com/Bromolima/my-game-list/internal/validation"
+	"github.com/Bromolima/my-game-list/logger"
 	"github.com/labstack/echo/v4"
 )
 
 type ListItemHandler struct {
 	listItemService service.ListItemService
-	jwtService      token.JwtService
 	logger          *slog.Logger
 }
 
-func NewListItemHandler(listItemService service.ListItemService, jwtService token.JwtService, logger *slog.Logger) *ListItemHandler {
+func NewListItemHandler(listItemService service.ListItemService, logger *slog.Logger) *ListItemHandler {
 	return &ListItemHandler{
 		listItemService: listItemService,
-		jwtService:      jwtService,
 		logger:          logger.With(slog.String("handler", "listItem")),
 	}
 }
 
 func (h *ListItemHandler) AddGameToList(ectx echo.Context) error {
-	log := h.logger.With(slog.String("func", "AddGameToList"))
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "AddGameToList"))
 
-	userClaims, err := h.jwtService.ExtractToken(ectx)
-	if err != nil {
-		log.Warn("Failed to extract user claims from token")
-		restErr := resterr.NewForbiddenError("Failed to get claims")
-		return ectx.JSON(restErr.Code, restErr)
-	}
+	userClaims := GetUserClaims(ectx)
 
 	var addRequest dto.ListItemAddRequest
 	if err := ectx.Bind(&addRequest); err != nil {
@@ -65,14 +58,9 @@ func (h *ListItemHandler) AddGameToList(ectx echo.Context) error {
 }
 
 func (h *ListItemHandler) UpdateGameFromList(ectx echo.Context) error {
-	log := h.logger.With(slog.String("func", "UpdateGameFromList"))
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "UpdateGameFromList"))
 
-	userClaims, err := h.jwtService.ExtractToken(ectx)
-	if err != nil {
-		log.Warn("Failed to extract user claims from token")
-		restErr := resterr.NewForbiddenError("Failed to get claims")
-		return ectx.JSON(restErr.Code, restErr)
-	}
+	userClaims := GetUserClaims(ectx)
 
 	var updateRequest dto.ListItemUpdateRequest
 	if err := ectx.Bind(&updateRequest); err != nil {
@@ -103,14 +91,9 @@ func (h *ListItemHandler) UpdateGameFromList(ectx echo.Context) error {
 }
 
 func (h *ListItemHandler) DeleteGameFromList(ectx echo.Context) error {
-	log := h.logger.With(slog.String("func", "DeleteGameFromList"))
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "DeleteGameFromList"))
 
-	userClaims, err := h.jwtService.ExtractToken(ectx)
-	if err != nil {
-		log.Warn("Failed to extract user claims from token")
-		restErr := resterr.NewForbiddenError("Failed to get claims")
-		return ectx.JSON(restErr.Code, restErr)
-	}
+	userClaims := GetUserClaims(ectx)
 
 	var deleteRequest dto.ListItemDeleteRequest
 	if err := ectx.Bind(&deleteRequest); err != nil {
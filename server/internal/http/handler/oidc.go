@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/Bromolima/my-game-list/internal/factory"
+	"github.com/Bromolima/my-game-list/internal/http/cookie"
+	"github.com/Bromolima/my-game-list/internal/http/dto"
+	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/service"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/labstack/echo/v4"
+)
+
+type OIDCHandler struct {
+	oidcService service.OIDCService
+	logger      *slog.Logger
+}
+
+func NewOIDCHandler(oidcService service.OIDCService, logger *slog.Logger) *OIDCHandler {
+	return &OIDCHandler{
+		oidcService: oidcService,
+		logger:      logger.With(slog.String("handler", "oidc")),
+	}
+}
+
+func (h *OIDCHandler) Login(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "Login"))
+
+	authorization, restErr := h.oidcService.BeginLogin(ectx.Request().Context(), ectx.Param("provider"))
+	if restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	cookie.SetOIDCStateCookie(ectx, authorization.State, authorization.ExpiresAt)
+	log.Info("Identity provider login started successfully")
+	return ectx.Redirect(http.StatusFound, authorization.URL)
+}
+
+func (h *OIDCHandler) Callback(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "Callback"))
+
+	var callbackRequest dto.OIDCCallbackRequest
+	if err := ectx.Bind(&callbackRequest); err != nil {
+		log.Warn("Failed to bind request payload")
+		restErr := resterr.NewBadRequestError("An error occurred while binding the request payload")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	cookie.DeleteOIDCStateCookie(ectx)
+
+	if callbackRequest.Error != "" {
+		log.Warn("The identity provider denied the login", slog.String("error", callbackRequest.Error))
+		restErr := resterr.NewUnauthorizedError("The identity provider denied the login")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	var boundState string
+	if stateCookie, err := cookie.GetOIDCStateCookie(ectx); err == nil {
+		boundState = stateCookie.Value
+	}
+
+	loginResult, restErr := h.oidcService.CompleteLogin(
+		ectx.Request().Context(),
+		ectx.Param("provider"),
+		callbackRequest.Code,
+		callbackRequest.State,
+		boundState,
+		ectx.RealIP(),
+		ectx.Request().UserAgent(),
+	)
+	if restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	if loginResult.Challenge != nil {
+		log.Info("Two-factor challenge issued successfully")
+		return ectx.JSON(http.StatusOK, factory.NewResponseFromTwoFactorChallenge(loginResult.Challenge))
+	}
+
+	tokens := loginResult.AuthTokens
+	cookie.SetCookie(ectx, tokens.AccessToken, tokens.AccessTokenExpiresAt)
+	cookie.SetRefreshCookie(ectx, tokens.RefreshToken, tokens.RefreshTokenExpiresAt)
+	log.Info("User logged in with identity provider successfully")
+	return ectx.JSON(http.StatusOK, factory.NewResponseFromAuthTokens(tokens))
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/Bromolima/my-game-list/internal/http/dto"
+	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/service"
+	validation "github.com/Bromolima/my-game-list/internal/validation"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/labstack/echo/v4"
+)
+
+type PasswordResetHandler struct {
+	passwordResetService service.PasswordResetService
+	logger               *slog.Logger
+}
+
+func NewPasswordResetHandler(passwordResetService service.PasswordResetService, logger *slog.Logger) *PasswordResetHandler {
+	return &PasswordResetHandler{
+		passwordResetService: passwordResetService,
+		logger:               logger.With(slog.String("handler", "passwordReset")),
+	}
+}
+
+func (h *PasswordResetHandler) ForgotPassword(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "ForgotPassword"))
+
+	var forgotPasswordRequest dto.ForgotPasswordRequest
+	if err := ectx.Bind(&forgotPasswordRequest); err != nil {
+		log.Warn("Failed to bind request payload")
+		restErr := resterr.NewBadRequestError("An error occurred while binding the request payload")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	if err := ectx.Validate(forgotPasswordRequest); err != nil {
+		log.Warn("Request payload validation failed")
+		restErr := validation.ValidateUserError(err)
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	if restErr := h.passwordResetService.RequestReset(ectx.Request().Context(), forgotPasswordRequest.Email); restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	log.Info("Password reset requested successfully")
+	return ectx.NoContent(http.StatusAccepted)
+}
+
+func (h *PasswordResetHandler) ResetPassword(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "ResetPassword"))
+
+	var resetPasswordRequest dto.ResetPasswordRequest
+	if err := ectx.Bind(&resetPasswordRequest); err != nil {
+		log.Warn("Failed to bind request payload")
+		restErr := resterr.NewBadRequestError("An error occurred while binding the request payload")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	if err := ectx.Validate(resetPasswordRequest); err != nil {
+		log.Warn("Request payload validation failed")
+		restErr := validation.ValidateUserError(err)
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	if restErr := h.passwordResetService.ResetPassword(
+		ectx.Request().Context(),
+		resetPasswordRequest.Token,
+		resetPasswordRequest.Password,
+	); restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	log.Info("Password reset successfully")
+	return ectx.NoContent(http.StatusNoContent)
+}
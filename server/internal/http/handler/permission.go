@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/factory"
+	"github.com/Bromolima/my-game-list/internal/http/dto"
+	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/service"
+	"github.com/Bromolima/my-game-list/internal/validation"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type PermissionHandler struct {
+	permissionService service.PermissionService
+	logger            *slog.Logger
+}
+
+func NewPermissionHandler(permissionService service.PermissionService, logger *slog.Logger) *PermissionHandler {
+	return &PermissionHandler{
+		permissionService: permissionService,
+		logger:            logger.With(slog.String("handler", "permission")),
+	}
+}
+
+func (h *PermissionHandler) ListGrants(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "ListGrants"))
+
+	grants, restErr := h.permissionService.ListGrants(ectx.Request().Context())
+	if restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	grantsResponse := make([]*dto.PermissionGrantResponse, 0, len(grants))
+	for _, grant := range grants {
+		grantsResponse = append(grantsResponse, factory.NewResponseFromPermissionGrant(grant))
+	}
+
+	log.Info("Permission grants listed successfully")
+	return ectx.JSON(http.StatusOK, grantsResponse)
+}
+
+func (h *PermissionHandler) CreateGrant(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "CreateGrant"))
+
+	var createRequest dto.PermissionGrantCreateRequest
+	if err := ectx.Bind(&createRequest); err != nil {
+		log.Warn("Failed to bind request payload", slog.String("error", err.Error()))
+		restErr := resterr.NewBadRequestError("An error occurred while binding the request payload")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	if err := ectx.Validate(createRequest); err != nil {
+		log.Warn("Request payload validation failed", slog.String("error", err.Error()))
+		restErr := validation.ValidateUserError(err)
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	grant, restErr := h.permissionService.CreateGrant(
+		ectx.Request().Context(),
+		createRequest.UserID,
+		createRequest.RoleID,
+		entities.PermissionAction(createRequest.Action),
+		entities.ResourceType(createRequest.ResourceType),
+		createRequest.ResourceID,
+		createRequest.Attribute,
+		createRequest.Value,
+	)
+	if restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	log.Info("Permission grant created successfully")
+	return ectx.JSON(http.StatusCreated, factory.NewResponseFromPermissionGrant(grant))
+}
+
+func (h *PermissionHandler) DeleteGrant(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "DeleteGrant"))
+
+	grantID, err := uuid.Parse(ectx.Param("id"))
+	if err != nil {
+		log.Warn("Failed to parse permission grant ID from path parameter", slog.String("error", err.Error()))
+		restErr := resterr.NewBadRequestError("An error occurred while parsing the id")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	if restErr := h.permissionService.DeleteGrant(ectx.Request().Context(), grantID); restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	log.Info("Permission grant deleted successfully")
+	return ectx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/factory"
+	"github.com/Bromolima/my-game-list/internal/http/dto"
+	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/service"
+	"github.com/Bromolima/my-game-list/internal/validation"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type PersonalAccessTokenHandler struct {
+	personalAccessTokenService service.PersonalAccessTokenService
+	logger                     *slog.Logger
+}
+
+func NewPersonalAccessTokenHandler(personalAccessTokenService service.PersonalAccessTokenService, logger *slog.Logger) *PersonalAccessTokenHandler {
+	return &PersonalAccessTokenHandler{
+		personalAccessTokenService: personalAccessTokenService,
+		logger:                     logger.With(slog.String("handler", "personalAccessToken")),
+	}
+}
+
+func (h *PersonalAccessTokenHandler) CreateToken(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "CreateToken"))
+
+	var createRequest dto.PersonalAccessTokenCreateRequest
+	if err := ectx.Bind(&createRequest); err != nil {
+		log.Warn("Failed to bind request payload", slog.String("error", err.Error()))
+		restErr := resterr.NewBadRequestError("An error occurred while binding the request payload")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	if err := ectx.Validate(createRequest); err != nil {
+		log.Warn("Request payload validation failed", slog.String("error", err.Error()))
+		restErr := validation.ValidateUserError(err)
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	scopes := make([]entities.AccessType, 0, len(createRequest.Scopes))
+	for _, scope := range createRequest.Scopes {
+		scopes = append(scopes, entities.AccessType(scope))
+	}
+
+	userClaims := GetUserClaims(ectx)
+	personalAccessToken, plainToken, restErr := h.personalAccessTokenService.CreateToken(
+		ectx.Request().Context(),
+		userClaims.ID,
+		createRequest.Name,
+		scopes,
+		createRequest.ExpiresAt,
+	)
+	if restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	log.Info("Personal access token created successfully")
+	return ectx.JSON(http.StatusCreated, factory.NewCreatedResponseFromPersonalAccessToken(personalAccessToken, plainToken))
+}
+
+func (h *PersonalAccessTokenHandler) ListTokens(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "ListTokens"))
+
+	userClaims := GetUserClaims(ectx)
+	personalAccessTokens, restErr := h.personalAccessTokenService.ListTokens(ectx.Request().Context(), userClaims.ID)
+	if restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	tokensResponse := make([]*dto.PersonalAccessTokenResponse, 0, len(personalAccessTokens))
+	for _, personalAccessToken := range personalAccessTokens {
+		tokensResponse = append(tokensResponse, factory.NewResponseFromPersonalAccessToken(personalAccessToken))
+	}
+
+	log.Info("Personal access tokens listed successfully")
+	return ectx.JSON(http.StatusOK, tokensResponse)
+}
+
+func (h *PersonalAccessTokenHandler) RevokeToken(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "RevokeToken"))
+
+	tokenID, err := uuid.Parse(ectx.Param("id"))
+	if err != nil {
+		log.Warn("Failed to parse token ID from path parameter", slog.String("error", err.Error()))
+		restErr := resterr.NewBadRequestError("An error occurred while parsing the id")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	userClaims := GetUserClaims(ectx)
+	if restErr := h.personalAccessTokenService.RevokeToken(ectx.Request().Context(), tokenID, userClaims.ID); restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	log.Info("Personal access token revoked successfully")
+	return ectx.NoContent(http.StatusNoContent)
+}
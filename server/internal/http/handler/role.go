@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/factory"
+	"github.com/Bromolima/my-game-list/internal/http/dto"
+	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/service"
+	"github.com/Bromolima/my-game-list/internal/validation"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type RoleHandler struct {
+	roleService service.RoleService
+	logger      *slog.Logger
+}
+
+func NewRoleHandler(roleService service.RoleService, logger *slog.Logger) *RoleHandler {
+	return &RoleHandler{
+		roleService: roleService,
+		logger:      logger.With(slog.String("handler", "role")),
+	}
+}
+
+func (h *RoleHandler) ListRoles(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "ListRoles"))
+
+	roles, restErr := h.roleService.ListRoles(ectx.Request().Context())
+	if restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	rolesResponse := make([]*dto.RoleResponse, 0, len(roles))
+	for _, role := range roles {
+		rolesResponse = append(rolesResponse, factory.NewResponseFromRole(role))
+	}
+
+	log.Info("Roles listed successfully")
+	return ectx.JSON(http.StatusOK, rolesResponse)
+}
+
+func (h *RoleHandler) CreateRole(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "CreateRole"))
+
+	var createRequest dto.RoleCreateRequest
+	if err := ectx.Bind(&createRequest); err != nil {
+		log.Warn("Failed to bind request payload", slog.String("error", err.Error()))
+		restErr := resterr.NewBadRequestError("An error occurred while binding the request payload")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	if err := ectx.Validate(createRequest); err != nil {
+		log.Warn("Request payload validation failed", slog.String("error", err.Error()))
+		restErr := validation.ValidateUserError(err)
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	accessTypes := make([]entities.AccessType, 0, len(createRequest.Access))
+	for _, access := range createRequest.Access {
+		accessTypes = append(accessTypes, entities.AccessType(access))
+	}
+
+	role, restErr := h.roleService.CreateRole(ectx.Request().Context(), createRequest.Name, accessTypes)
+	if restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	log.Info("Role created successfully")
+	return ectx.JSON(http.StatusCreated, factory.NewResponseFromRole(role))
+}
+
+func (h *RoleHandler) GrantAccess(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "GrantAccess"))
+
+	roleID, restErr := parseRoleID(ectx)
+	if restErr != nil {
+		log.Warn("Failed to parse role ID from path parameter")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	if restErr := h.roleService.GrantAccess(ectx.Request().Context(), roleID, entities.AccessType(ectx.Param("access"))); restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	log.Info("Access granted successfully")
+	return ectx.NoContent(http.StatusNoContent)
+}
+
+func (h *RoleHandler) RevokeAccess(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "RevokeAccess"))
+
+	roleID, restErr := parseRoleID(ectx)
+	if restErr != nil {
+		log.Warn("Failed to parse role ID from path parameter")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	if restErr := h.roleService.RevokeAccess(ectx.Request().Context(), roleID, entities.AccessType(ectx.Param("access"))); restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	log.Info("Access revoked successfully")
+	return ectx.NoContent(http.StatusNoContent)
+}
+
+func (h *RoleHandler) ListRoleUsers(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "ListRoleUsers"))
+
+	roleID, restErr := parseRoleID(ectx)
+	if restErr != nil {
+		log.Warn("Failed to parse role ID from path parameter")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	var usersRequest dto.RoleUsersRequest
+	if err := ectx.Bind(&usersRequest); err != nil {
+		log.Warn("Failed to bind request payload", slog.String("error", err.Error()))
+		restErr := resterr.NewBadRequestError("An error occurred while binding the request payload")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	page, restErr := h.roleService.ListRoleUsers(
+		ectx.Request().Context(),
+		factory.NewPage[entities.User](usersRequest.Page, usersRequest.Limit),
+		roleID,
+	)
+	if restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	log.Info("Role users listed successfully")
+	return ectx.JSON(http.StatusOK, factory.NewReponseFromPage(page, factory.NewRoleMemberResponseFromUser))
+}
+
+func (h *RoleHandler) AssignRole(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "AssignRole"))
+
+	userID, err := uuid.Parse(ectx.Param("id"))
+	if err != nil {
+		log.Warn("Failed to parse user ID from path parameter", slog.String("error", err.Error()))
+		restErr := resterr.NewBadRequestError("An error occurred while parsing the id")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	var assignRequest dto.RoleAssignRequest
+	if err := ectx.Bind(&assignRequest); err != nil {
+		log.Warn("Failed to bind request payload", slog.String("error", err.Error()))
+		restErr := resterr.NewBadRequestError("An error occurred while binding the request payload")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	if err := ectx.Validate(assignRequest); err != nil {
+		log.Warn("Request payload validation failed", slog.String("error", err.Error()))
+		restErr := validation.ValidateUserError(err)
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	if restErr := h.roleService.AssignRole(ectx.Request().Context(), userID, assignRequest.RoleID); restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	log.Info("Role assigned successfully")
+	return ectx.NoContent(http.StatusNoContent)
+}
+
+func parseRoleID(ectx echo.Context) (uint, *resterr.RestErr) {
+	roleID, err := strconv.ParseUint(ectx.Param("id"), 10, 16)
+	if err != nil {
+		return 0, resterr.NewBadRequestError("An error occurred while parsing the id")
+	}
+
+	return uint(roleID), nil
+}
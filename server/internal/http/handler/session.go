@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/Bromolima/my-game-list/internal/factory"
+	"github.com/Bromolima/my-game-list/internal/http/cookie"
+	"github.com/Bromolima/my-game-list/internal/http/dto"
+	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/service"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type SessionHandler struct {
+	sessionService service.SessionService
+	logger         *slog.Logger
+}
+
+func NewSessionHandler(sessionService service.SessionService, logger *slog.Logger) *SessionHandler {
+	return &SessionHandler{
+		sessionService: sessionService,
+		logger:         logger.With(slog.String("handler", "session")),
+	}
+}
+
+func (h *SessionHandler) Refresh(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "Refresh"))
+
+	refreshToken, restErr := getRefreshToken(ectx)
+	if restErr != nil {
+		log.Warn("Refresh token missing from request")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	tokens, restErr := h.sessionService.RefreshSession(
+		ectx.Request().Context(),
+		refreshToken,
+		ectx.RealIP(),
+		ectx.Request().UserAgent(),
+	)
+	if restErr != nil {
+		cookie.DeleteCookie(ectx)
+		cookie.DeleteRefreshCookie(ectx)
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	cookie.SetCookie(ectx, tokens.AccessToken, tokens.AccessTokenExpiresAt)
+	cookie.SetRefreshCookie(ectx, tokens.RefreshToken, tokens.RefreshTokenExpiresAt)
+	log.Info("Session refreshed successfully")
+	return ectx.JSON(http.StatusOK, factory.NewResponseFromAuthTokens(tokens))
+}
+
+func (h *SessionHandler) Logout(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "Logout"))
+
+	refreshToken, restErr := getRefreshToken(ectx)
+	if restErr != nil {
+		log.Warn("Refresh token missing from request")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	if restErr := h.sessionService.Logout(ectx.Request().Context(), refreshToken); restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	cookie.DeleteCookie(ectx)
+	cookie.DeleteRefreshCookie(ectx)
+	log.Info("User logged out successfully")
+	return ectx.NoContent(http.StatusNoContent)
+}
+
+func (h *SessionHandler) ListSessions(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "ListSessions"))
+
+	userClaims := GetUserClaims(ectx)
+
+	sessions, restErr := h.sessionService.ListActiveSessions(ectx.Request().Context(), userClaims.ID)
+	if restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	sessionsResponse := make([]*dto.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		sessionsResponse = append(sessionsResponse, factory.NewResponseFromSession(session, userClaims.SessionID))
+	}
+
+	log.Info("Sessions listed successfully")
+	return ectx.JSON(http.StatusOK, sessionsResponse)
+}
+
+func (h *SessionHandler) RevokeSession(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "RevokeSession"))
+
+	userClaims := GetUserClaims(ectx)
+
+	sessionID, err := uuid.Parse(ectx.Param("id"))
+	if err != nil {
+		log.Warn("Failed to parse session ID from path parameter", slog.String("error", err.Error()))
+		restErr := resterr.NewBadRequestError("An error occurred while parsing the id")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	if restErr := h.sessionService.RevokeSession(ectx.Request().Context(), sessionID, userClaims.ID); restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	log.Info("Session revoked successfully")
+	return ectx.NoContent(http.StatusNoContent)
+}
+
+func (h *SessionHandler) RevokeAllSessions(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "RevokeAllSessions"))
+
+	userClaims := GetUserClaims(ectx)
+
+	if restErr := h.sessionService.RevokeAllSessions(ectx.Request().Context(), userClaims.ID); restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	log.Info("Sessions revoked successfully")
+	return ectx.NoContent(http.StatusNoContent)
+}
+
+func getRefreshToken(ectx echo.Context) (string, *resterr.RestErr) {
+	var refreshRequest dto.RefreshTokenRequest
+	if err := ectx.Bind(&refreshRequest); err == nil && refreshRequest.RefreshToken != "" {
+		return refreshRequest.RefreshToken, nil
+	}
+
+	refreshCookie, err := cookie.GetRefreshCookie(ectx)
+	if err != nil || refreshCookie.Value == "" {
+		return "", resterr.NewUnauthorizedError("The refresh token was not provided")
+	}
+
+	return refreshCookie.Value, nil
+}
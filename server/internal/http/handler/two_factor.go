@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/Bromolima/my-game-list/internal/factory"
+	"github.com/Bromolima/my-game-list/internal/http/dto"
+	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/service"
+	validation "github.com/Bromolima/my-game-list/internal/validation"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/labstack/echo/v4"
+)
+
+type TwoFactorHandler struct {
+	twoFactorService service.TwoFactorService
+	logger           *slog.Logger
+}
+
+func NewTwoFactorHandler(twoFactorService service.TwoFactorService, logger *slog.Logger) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		twoFactorService: twoFactorService,
+		logger:           logger.With(slog.String("handler", "twoFactor")),
+	}
+}
+
+func (h *TwoFactorHandler) Enroll(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "Enroll"))
+
+	userClaims := GetUserClaims(ectx)
+
+	secret, uri, restErr := h.twoFactorService.BeginEnrollment(ectx.Request().Context(), userClaims.ID)
+	if restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	log.Info("Two-factor enrolment started successfully")
+	return ectx.JSON(http.StatusOK, factory.NewTwoFactorEnrollmentResponse(secret, uri))
+}
+
+func (h *TwoFactorHandler) Confirm(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "Confirm"))
+
+	var codeRequest dto.TwoFactorCodeRequest
+	if err := ectx.Bind(&codeRequest); err != nil {
+		log.Warn("Failed to bind request payload")
+		restErr := resterr.NewBadRequestError("An error occurred while binding the request payload")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	if err := ectx.Validate(codeRequest); err != nil {
+		log.Warn("Request payload validation failed")
+		restErr := validation.ValidateUserError(err)
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	userClaims := GetUserClaims(ectx)
+
+	recoveryCodes, restErr := h.twoFactorService.ConfirmEnrollment(ectx.Request().Context(), userClaims.ID, codeRequest.Code)
+	if restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	log.Info("Two-factor authentication enabled successfully")
+	return ectx.JSON(http.StatusOK, &dto.RecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+}
+
+func (h *TwoFactorHandler) Disable(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "Disable"))
+
+	var codeRequest dto.TwoFactorCodeRequest
+	if err := ectx.Bind(&codeRequest); err != nil {
+		log.Warn("Failed to bind request payload")
+		restErr := resterr.NewBadRequestError("An error occurred while binding the request payload")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	if err := ectx.Validate(codeRequest); err != nil {
+		log.Warn("Request payload validation failed")
+		restErr := validation.ValidateUserError(err)
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	userClaims := GetUserClaims(ectx)
+
+	if restErr := h.twoFactorService.Disable(ectx.Request().Context(), userClaims.ID, codeRequest.Code); restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	log.Info("Two-factor authentication disabled successfully")
+	return ectx.NoContent(http.StatusNoContent)
+}
@@ -10,29 +10,28 @@ import (
 	"github.com/Bromolima/my-game-list/internal/http/dto"
 	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
 	"github.com/Bromolima/my-game-list/internal/service"
-	"github.com/Bromolima/my-game-list/internal/token"
 	validation "github.com/Bromolima/my-game-list/internal/validation"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
 type UserHandler struct {
 	userService     service.UserService
 	gameListService service.GameListService
-	jwtService      token.JwtService
 	logger          *slog.Logger
 }
 
-func NewUserHandler(userService service.UserService, gameListService service.GameListService, jwtService token.JwtService, logger *slog.Logger) *UserHandler {
+func NewUserHandler(userService service.UserService, gameListService service.GameListService, logger *slog.Logger) *UserHandler {
 	return &UserHandler{
 		userService:     userService,
 		gameListService: gameListService,
 		logger:          logger.With(slog.String("handler", "user")),
-		jwtService:      jwtService,
 	}
 }
 
 func (h *UserHandler) RegisterUser(ectx echo.Context) error {
-	log := h.logger.With(slog.String("func", "RegisterUser"))
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "RegisterUser"))
 
 	var registerRequest dto.UserRegisterRequest
 	if err := ectx.Bind(&registerRequest); err != nil {
@@ -62,7 +61,7 @@ func (h *UserHandler) RegisterUser(ectx echo.Context) error {
 }
 
 func (h *UserHandler) Login(ectx echo.Context) error {
-	log := h.logger.With(slog.String("func", "Login"))
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "Login"))
 
 	var loginPayload dto.UserLoginRequest
 	if err := ectx.Bind(&loginPayload); err != nil {
@@ -77,19 +76,65 @@ func (h *UserHandler) Login(ectx echo.Context) error {
 		return ectx.JSON(restErr.Code, restErr)
 	}
 
-	token, restErr := h.userService.Login(ectx.Request().Context(), loginPayload.Email, loginPayload.Password)
+	loginResult, restErr := h.userService.Login(
+		ectx.Request().Context(),
+		loginPayload.Email,
+		loginPayload.Password,
+		ectx.RealIP(),
+		ectx.Request().UserAgent(),
+	)
 
 	if restErr != nil {
 		return ectx.JSON(restErr.Code, restErr)
 	}
 
-	cookie.SetCookie(ectx, token)
+	if loginResult.Challenge != nil {
+		log.Info("Two-factor challenge issued successfully")
+		return ectx.JSON(http.StatusOK, factory.NewResponseFromTwoFactorChallenge(loginResult.Challenge))
+	}
+
+	tokens := loginResult.AuthTokens
+	cookie.SetCookie(ectx, tokens.AccessToken, tokens.AccessTokenExpiresAt)
+	cookie.SetRefreshCookie(ectx, tokens.RefreshToken, tokens.RefreshTokenExpiresAt)
 	log.Info("User logged in successfully")
-	return ectx.NoContent(http.StatusOK)
+	return ectx.JSON(http.StatusOK, factory.NewResponseFromAuthTokens(tokens))
+}
+
+func (h *UserHandler) LoginTwoFactor(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "LoginTwoFactor"))
+
+	var loginPayload dto.TwoFactorLoginRequest
+	if err := ectx.Bind(&loginPayload); err != nil {
+		log.Warn("Failed to bind request payload")
+		restErr := resterr.NewBadRequestError("An error occurred while binding the request payload")
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	if err := ectx.Validate(loginPayload); err != nil {
+		log.Warn("Request payload validation failed")
+		restErr := validation.ValidateUserError(err)
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	tokens, restErr := h.userService.CompleteTwoFactorLogin(
+		ectx.Request().Context(),
+		loginPayload.ChallengeToken,
+		loginPayload.Code,
+		ectx.RealIP(),
+		ectx.Request().UserAgent(),
+	)
+	if restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	cookie.SetCookie(ectx, tokens.AccessToken, tokens.AccessTokenExpiresAt)
+	cookie.SetRefreshCookie(ectx, tokens.RefreshToken, tokens.RefreshTokenExpiresAt)
+	log.Info("User logged in successfully")
+	return ectx.JSON(http.StatusOK, factory.NewResponseFromAuthTokens(tokens))
 }
 
 func (h *UserHandler) SearchUsers(ectx echo.Context) error {
-	log := h.logger.With(slog.String("func", "SearchUsers"))
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "SearchUsers"))
 
 	var searchRequest dto.UserSearchRequest
 	if err := ectx.Bind(&searchRequest); err != nil {
@@ -114,14 +159,9 @@ func (h *UserHandler) SearchUsers(ectx echo.Context) error {
 }
 
 func (h *UserHandler) UpdateUser(ectx echo.Context) error {
-	log := h.logger.With(slog.String("func", "UpdateUser"))
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "UpdateUser"))
 
-	userClaims, err := h.jwtService.ExtractToken(ectx)
-	if err != nil {
-		log.Warn("Failed to extract token from context")
-		restErr := resterr.NewUnauthorizedError("Failed to extract token")
-		return ectx.JSON(restErr.Code, restErr)
-	}
+	userClaims := GetUserClaims(ectx)
 
 	var updateRequest dto.UserUpdateRequest
 	if err := ectx.Bind(&updateRequest); err != nil {
@@ -139,6 +179,7 @@ func (h *UserHandler) UpdateUser(ectx echo.Context) error {
 	if restErr := h.userService.UpdateUser(
 		ectx.Request().Context(),
 		userClaims.ID,
+		userClaims.SessionID,
 		*updateRequest.Email,
 		*updateRequest.Password,
 		*updateRequest.Username,
@@ -152,19 +193,32 @@ func (h *UserHandler) UpdateUser(ectx echo.Context) error {
 }
 
 func (h *UserHandler) DeleteUser(ectx echo.Context) error {
-	log := h.logger.With(slog.String("func", "DeleteUser"))
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "DeleteUser"))
+
+	userClaims := GetUserClaims(ectx)
+
+	if restErr := h.userService.DeleteUser(ectx.Request().Context(), userClaims.ID.String()); restErr != nil {
+		return ectx.JSON(restErr.Code, restErr)
+	}
+
+	log.Info("User deleted successfully")
+	return ectx.NoContent(http.StatusNoContent)
+}
+
+func (h *UserHandler) UnlockUser(ectx echo.Context) error {
+	log := logger.Scoped(ectx.Request().Context(), h.logger).With(slog.String("func", "UnlockUser"))
 
-	userClaims, err := h.jwtService.ExtractToken(ectx)
+	userID, err := uuid.Parse(ectx.Param("id"))
 	if err != nil {
-		log.Warn("Failed to extract token from context")
-		restErr := resterr.NewUnauthorizedError("Failed to extract token")
+		log.Warn("Failed to parse user ID from path parameter", slog.String("error", err.Error()))
+		restErr := resterr.NewBadRequestError("An error occurred while parsing the id")
 		return ectx.JSON(restErr.Code, restErr)
 	}
 
-	if restErr := h.userService.DeleteUser(ectx.Request().Context(), userClaims.ID.String()); restErr != nil {
+	if restErr := h.userService.UnlockAccount(ectx.Request().Context(), userID); restErr != nil {
 		return ectx.JSON(restErr.Code, restErr)
 	}
 
-	log.Info("User deleted successfully")
+	log.Info("User unlocked successfully")
 	return ectx.NoContent(http.StatusNoContent)
 }
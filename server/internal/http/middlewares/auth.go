@@ -1,36 +1,68 @@
 package middlewares
 
 import (
+	"errors"
 	"log/slog"
+	"slices"
+	"strings"
 
 	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/http/handler"
 	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
-	"github.com/Bromolima/my-game-list/internal/repository"
+	"github.com/Bromolima/my-game-list/internal/policy"
+	"github.com/Bromolima/my-game-list/internal/service"
 	"github.com/Bromolima/my-game-list/internal/token"
 	"github.com/labstack/echo/v4"
 )
 
 type AuthMiddleware struct {
-	jwtService     token.JwtService
-	roleRepository repository.RoleRepository
-	loggerr        *slog.Logger
+	jwtService                 token.JwtService
+	sessionService             service.SessionService
+	personalAccessTokenService service.PersonalAccessTokenService
+	emailVerificationService   service.EmailVerificationService
+	twoFactorService           service.TwoFactorService
+	accessCache                policy.AccessCache
+	policyEngine               policy.Engine
+	loggerr                    *slog.Logger
 }
 
-func NewAuthMiddleware(jwtService token.JwtService, roleRepository repository.RoleRepository, logger *slog.Logger) *AuthMiddleware {
+func NewAuthMiddleware(
+	jwtService token.JwtService,
+	sessionService service.SessionService,
+	personalAccessTokenService service.PersonalAccessTokenService,
+	emailVerificationService service.EmailVerificationService,
+	twoFactorService service.TwoFactorService,
+	accessCache policy.AccessCache,
+	policyEngine policy.Engine,
+	logger *slog.Logger,
+) *AuthMiddleware {
 	return &AuthMiddleware{
-		jwtService:     jwtService,
-		roleRepository: roleRepository,
-		loggerr:        logger.With(slog.String("middleware", "auth")),
+		jwtService:                 jwtService,
+		sessionService:             sessionService,
+		personalAccessTokenService: personalAccessTokenService,
+		emailVerificationService:   emailVerificationService,
+		twoFactorService:           twoFactorService,
+		accessCache:                accessCache,
+		policyEngine:               policyEngine,
+		loggerr:                    logger.With(slog.String("middleware", "auth")),
 	}
 }
 
+// AuthMiddleware only authenticates the request, so it refuses personal access
+// tokens, whose scopes it cannot check. The routes open to them chain
+// RequireScope instead.
 func (m *AuthMiddleware) AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
-	log := m.loggerr.With(slog.String("func", "AuthMiddleware"))
-
 	return func(ectx echo.Context) error {
-		if err := m.jwtService.ValidateToken(ectx); err != nil {
-			log.Warn("An error occurred while validating the token")
-			restErr := resterr.NewUnauthorizedError("An error occurred while validating the token")
+		log := m.loggerr.With(slog.String("func", "AuthMiddleware"))
+
+		userClaims, restErr := m.authenticate(ectx)
+		if restErr != nil {
+			return ectx.JSON(restErr.Code, restErr)
+		}
+
+		if userClaims.TokenType == entities.TokenTypePersonalAccessToken {
+			log.Warn("Personal access token used on a route without a scope")
+			restErr := resterr.NewForbiddenError("The token does not grant access to this feature")
 			return ectx.JSON(restErr.Code, restErr)
 		}
 
@@ -43,20 +75,14 @@ func (m *AuthMiddleware) RequireAccess(access entities.AccessType) echo.Middlewa
 		return func(c echo.Context) error {
 			log := m.loggerr.With(slog.String("func", "RequireAccess"))
 
-			if err := m.jwtService.ValidateToken(c); err != nil {
-				log.Warn("Invalid token")
-				restErr := resterr.NewUnauthorizedError("Invalid token")
+			userClaims, restErr := m.authenticate(c)
+			if restErr != nil {
 				return c.JSON(restErr.Code, restErr)
 			}
 
-			userClaims, err := m.jwtService.ExtractToken(c)
-			if err != nil {
-				log.Warn("Invalid token claims")
-				restErr := resterr.NewUnauthorizedError("Invalid token")
-				return c.JSON(restErr.Code, restErr)
-			}
-
-			err, permitted := m.roleRepository.HasAccess(c.Request().Context(), userClaims.ID, access)
+			// The role comes from the token, a role change reaches it when the
+			// session is refreshed or revoked.
+			permitted, err := m.accessCache.HasAccess(c.Request().Context(), userClaims.RoleID, access)
 			if err != nil {
 				log.Error("Error checking access", "error", err.Error())
 				restErr := resterr.NewInternalServerErr("Failed to validate access")
@@ -69,7 +95,168 @@ func (m *AuthMiddleware) RequireAccess(access entities.AccessType) echo.Middlewa
 				return c.JSON(restErr.Code, restErr)
 			}
 
+			if restErr := m.requireScope(c, userClaims, access); restErr != nil {
+				return c.JSON(restErr.Code, restErr)
+			}
+
 			return next(c)
 		}
 	}
 }
+
+// RequireScope only checks the personal access token scope and the two-factor
+// enrollment, leaving the decision on the resource to the policy engine.
+func (m *AuthMiddleware) RequireScope(access entities.AccessType) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ectx echo.Context) error {
+			userClaims, restErr := m.authenticate(ectx)
+			if restErr != nil {
+				return ectx.JSON(restErr.Code, restErr)
+			}
+
+			if restErr := m.requireScope(ectx, userClaims, access); restErr != nil {
+				return ectx.JSON(restErr.Code, restErr)
+			}
+
+			return next(ectx)
+		}
+	}
+}
+
+// OptionalScope lets the requests without a token through anonymously, leaving
+// the decision to the policy engine, and authenticates the others like
+// RequireScope, so a wrong token is still refused.
+func (m *AuthMiddleware) OptionalScope(access entities.AccessType) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ectx echo.Context) error {
+			if _, err := token.GetTokenFromRequest(ectx); errors.Is(err, token.ErrMissingToken) {
+				return next(ectx)
+			}
+
+			return m.RequireScope(access)(next)(ectx)
+		}
+	}
+}
+
+// RequirePermission asks the policy engine whether the user may perform the
+// action on some resource of the type. The service that loads the resource
+// runs the precise check.
+func (m *AuthMiddleware) RequirePermission(resourceType entities.ResourceType, action entities.PermissionAction) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ectx echo.Context) error {
+			log := m.loggerr.With(slog.String("func", "RequirePermission"))
+
+			userClaims, restErr := m.authenticate(ectx)
+			if restErr != nil {
+				return ectx.JSON(restErr.Code, restErr)
+			}
+
+			allowed, err := m.policyEngine.Authorize(ectx.Request().Context(), userClaims.ID, action, policy.NewResource(resourceType))
+			if err != nil {
+				log.Error("Error checking permission", "error", err.Error())
+				restErr := resterr.NewInternalServerErr("Failed to validate access")
+				return ectx.JSON(restErr.Code, restErr)
+			}
+
+			if !allowed {
+				log.Warn("User forbidden to access this feature")
+				restErr := resterr.NewForbiddenError("User is forbidden to access this feature")
+				return ectx.JSON(restErr.Code, restErr)
+			}
+
+			return next(ectx)
+		}
+	}
+}
+
+// RequireSession rejects requests authenticated with a personal access token,
+// keeping account management (profile, sessions, tokens) behind an interactive
+// login.
+func (m *AuthMiddleware) RequireSession(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ectx echo.Context) error {
+		log := m.loggerr.With(slog.String("func", "RequireSession"))
+
+		userClaims, restErr := m.authenticate(ectx)
+		if restErr != nil {
+			return ectx.JSON(restErr.Code, restErr)
+		}
+
+		if userClaims.TokenType != entities.TokenTypeSession {
+			log.Warn("Session-only feature accessed with a personal access token")
+			restErr := resterr.NewForbiddenError("This feature requires an interactive session")
+			return ectx.JSON(restErr.Code, restErr)
+		}
+
+		return next(ectx)
+	}
+}
+
+// RequireVerifiedEmail rejects users without a verified email address when the
+// action is part of the configured verification policy.
+func (m *AuthMiddleware) RequireVerifiedEmail(action entities.VerifiedAction) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ectx echo.Context) error {
+			userClaims, restErr := m.authenticate(ectx)
+			if restErr != nil {
+				return ectx.JSON(restErr.Code, restErr)
+			}
+
+			if restErr := m.emailVerificationService.RequireVerifiedEmail(ectx.Request().Context(), userClaims.ID, action); restErr != nil {
+				return ectx.JSON(restErr.Code, restErr)
+			}
+
+			return next(ectx)
+		}
+	}
+}
+
+func (m *AuthMiddleware) requireScope(ectx echo.Context, userClaims *entities.UserClaims, access entities.AccessType) *resterr.RestErr {
+	log := m.loggerr.With(slog.String("func", "requireScope"))
+
+	if userClaims.TokenType == entities.TokenTypePersonalAccessToken && !slices.Contains(userClaims.Scopes, access) {
+		log.Warn("Personal access token is missing the required scope")
+		return resterr.NewForbiddenError("The token does not grant access to this feature")
+	}
+
+	return m.twoFactorService.RequireEnrollment(ectx.Request().Context(), userClaims)
+}
+
+// authenticate parses the request token and validates its session once per
+// request, storing the resulting claims in the echo context so that chained
+// middlewares and handlers can read them without parsing the token again.
+func (m *AuthMiddleware) authenticate(ectx echo.Context) (*entities.UserClaims, *resterr.RestErr) {
+	log := m.loggerr.With(slog.String("func", "authenticate"))
+
+	if userClaims, ok := ectx.Get(handler.UserClaimsKey).(*entities.UserClaims); ok {
+		return userClaims, nil
+	}
+
+	tokenString, err := token.GetTokenFromRequest(ectx)
+	if err != nil {
+		log.Warn("No valid token was found in the request")
+		return nil, resterr.NewUnauthorizedError("An error occurred while validating the token")
+	}
+
+	if strings.HasPrefix(tokenString, entities.PersonalAccessTokenPrefix) {
+		userClaims, restErr := m.personalAccessTokenService.Authenticate(ectx.Request().Context(), tokenString)
+		if restErr != nil {
+			return nil, restErr
+		}
+
+		handler.SetUserClaims(ectx, userClaims)
+		return userClaims, nil
+	}
+
+	userClaims, err := m.jwtService.ParseToken(tokenString)
+	if err != nil {
+		log.Warn("An error occurred while validating the token")
+		return nil, resterr.NewUnauthorizedError("An error occurred while validating the token")
+	}
+
+	if restErr := m.sessionService.ValidateSession(ectx.Request().Context(), userClaims.SessionID, userClaims.ID); restErr != nil {
+		return nil, restErr
+	}
+
+	handler.SetUserClaims(ectx, userClaims)
+	return userClaims, nil
+}
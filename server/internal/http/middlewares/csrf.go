@@ -0,0 +1,96 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"log/slog"
+	"net/http"
+
+	"github.com/Bromolima/my-game-list/internal/http/cookie"
+	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/security"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/labstack/echo/v4"
+)
+
+const csrfTokenSize = 32
+
+type CSRFMiddleware struct {
+	logger *slog.Logger
+}
+
+func NewCSRFMiddleware(logger *slog.Logger) *CSRFMiddleware {
+	return &CSRFMiddleware{
+		logger: logger.With(slog.String("middleware", "csrf")),
+	}
+}
+
+// CSRF implements the double-submit cookie pattern. Every client gets a random
+// token in a cookie readable by the frontend, and unsafe requests that carry
+// the session cookies must repeat it in the X-CSRF-Token header, which a
+// cross-site form or script cannot do. Requests authenticated with an
+// Authorization header, such as bearer and personal access tokens, are not
+// sent automatically by the browser and are left alone.
+func (m *CSRFMiddleware) CSRF(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ectx echo.Context) error {
+		log := logger.Scoped(ectx.Request().Context(), m.logger).With(slog.String("func", "CSRF"))
+
+		// The Authorization header takes precedence over the cookies when the
+		// token is read, so such requests never rely on the browser cookies.
+		if ectx.Request().Header.Get(echo.HeaderAuthorization) != "" {
+			return next(ectx)
+		}
+
+		var csrfToken string
+		if csrfCookie, err := cookie.GetCSRFCookie(ectx); err == nil {
+			csrfToken = csrfCookie.Value
+		}
+
+		if csrfToken == "" {
+			token, err := security.GenerateRandomToken(csrfTokenSize)
+			if err != nil {
+				log.Error("Failed to generate CSRF token", slog.String("error", err.Error()))
+				restErr := resterr.NewInternalServerErr("An error occurred while generating the CSRF token")
+				return ectx.JSON(restErr.Code, restErr)
+			}
+
+			csrfToken = token
+			cookie.SetCSRFCookie(ectx, csrfToken)
+		}
+
+		if isSafeMethod(ectx.Request().Method) || !isCookieAuthenticated(ectx) {
+			return next(ectx)
+		}
+
+		headerToken := ectx.Request().Header.Get(echo.HeaderXCSRFToken)
+		if headerToken == "" || subtle.ConstantTimeCompare([]byte(headerToken), []byte(csrfToken)) != 1 {
+			log.Warn("Cookie-authenticated request without a valid CSRF token")
+			restErr := resterr.NewForbiddenError("The CSRF token is missing or invalid")
+			return ectx.JSON(restErr.Code, restErr)
+		}
+
+		return next(ectx)
+	}
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
+
+// isCookieAuthenticated reports whether the browser attached session
+// credentials to the request on its own.
+func isCookieAuthenticated(ectx echo.Context) bool {
+	if sessionCookie, err := cookie.GetCookie(ectx); err == nil && sessionCookie.Value != "" {
+		return true
+	}
+
+	if refreshCookie, err := cookie.GetRefreshCookie(ectx); err == nil && refreshCookie.Value != "" {
+		return true
+	}
+
+	return false
+}
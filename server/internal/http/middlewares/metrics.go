@@ -0,0 +1,65 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Bromolima/my-game-list/internal/metrics"
+	"github.com/labstack/echo/v4"
+)
+
+// unmatchedRoute labels the requests that matched no route, so that scanners
+// probing random paths cannot create a series per path.
+const unmatchedRoute = "unmatched"
+
+type MetricsMiddleware struct {
+	metrics *metrics.Metrics
+}
+
+func NewMetricsMiddleware(metrics *metrics.Metrics) *MetricsMiddleware {
+	return &MetricsMiddleware{
+		metrics: metrics,
+	}
+}
+
+// routeOf returns the route pattern the request matched, which is used
+// instead of the path so that IDs do not end up in labels and attributes.
+func routeOf(ectx echo.Context) string {
+	route := ectx.Path()
+	if route == "" || route == "/*" {
+		return unmatchedRoute
+	}
+
+	return route
+}
+
+// responseStatus returns the status code of the response. The response is
+// written by the error handler after the middlewares return, so the status
+// comes from the error when there is one.
+func responseStatus(ectx echo.Context, err error) int {
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code
+	}
+
+	if err != nil {
+		return http.StatusInternalServerError
+	}
+
+	return ectx.Response().Status
+}
+
+// Metrics records the count and latency of every request, labelled with the
+// route pattern rather than the path, which would include IDs.
+func (m *MetricsMiddleware) Metrics(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ectx echo.Context) error {
+		start := time.Now()
+		err := next(ectx)
+
+		status := responseStatus(ectx, err)
+		m.metrics.ObserveHTTPRequest(ectx.Request().Method, routeOf(ectx), strconv.Itoa(status), time.Since(start).Seconds())
+		return err
+	}
+}
@@ -0,0 +1,28 @@
+package middlewares
+
+import (
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// requestIDPattern restricts the IDs accepted from clients, which end up in
+// every log line of the request, to a reasonable length and character set.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID reuses the X-Request-ID header sent by the client or the proxy in
+// front of the API, so that a request can be followed across services, and
+// generates a new ID otherwise. The ID is sent back in the same header.
+func RequestID(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ectx echo.Context) error {
+		requestID := ectx.Request().Header.Get(echo.HeaderXRequestID)
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		ectx.Request().Header.Set(echo.HeaderXRequestID, requestID)
+		ectx.Response().Header().Set(echo.HeaderXRequestID, requestID)
+		return next(ectx)
+	}
+}
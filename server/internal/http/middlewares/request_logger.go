@@ -0,0 +1,71 @@
+package middlewares
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Bromolima/my-game-list/internal/requestctx"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type RequestLoggerMiddleware struct {
+	logger       *slog.Logger
+	accessLogger *slog.Logger
+}
+
+func NewRequestLoggerMiddleware(logger *slog.Logger) *RequestLoggerMiddleware {
+	return &RequestLoggerMiddleware{
+		logger:       logger,
+		accessLogger: logger.With(slog.String("middleware", "access_log")),
+	}
+}
+
+// RequestLogger stores the request-scoped logger in the request context, from
+// which the handlers, services and repositories add the request ID to their
+// lines, and writes one access log line once the request is handled. It must
+// run after the request metadata and tracing middlewares.
+func (m *RequestLoggerMiddleware) RequestLogger(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ectx echo.Context) error {
+		start := time.Now()
+		req := ectx.Request()
+		metadata := requestctx.MetadataFrom(req.Context())
+
+		ctx := logger.WithRequest(req.Context(), m.logger, slog.String("request_id", metadata.RequestID))
+		ectx.SetRequest(req.WithContext(ctx))
+
+		err := next(ectx)
+		if probeRoutes[ectx.Path()] {
+			return err
+		}
+
+		status := responseStatus(ectx, err)
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("route", routeOf(ectx)),
+			slog.String("path", req.URL.Path),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.Int64("bytes", ectx.Response().Size),
+			slog.String("ip_address", metadata.IPAddress),
+		}
+
+		if metadata.ActorID != uuid.Nil {
+			attrs = append(attrs, slog.String("user_id", metadata.ActorID.String()))
+		}
+
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+		}
+
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+
+		logger.Scoped(ctx, m.accessLogger).LogAttrs(ctx, level, "Request handled", attrs...)
+		return err
+	}
+}
@@ -0,0 +1,29 @@
+package middlewares
+
+import (
+	"github.com/Bromolima/my-game-list/internal/requestctx"
+	"github.com/labstack/echo/v4"
+)
+
+// RequestMetadata stores the request ID, client IP and user agent in the
+// request context, where the audit log reads them. It must run after the
+// request ID middleware.
+func RequestMetadata(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ectx echo.Context) error {
+		req := ectx.Request()
+
+		requestID := ectx.Response().Header().Get(echo.HeaderXRequestID)
+		if requestID == "" {
+			requestID = req.Header.Get(echo.HeaderXRequestID)
+		}
+
+		metadata := &requestctx.Metadata{
+			RequestID: requestID,
+			IPAddress: ectx.RealIP(),
+			UserAgent: req.UserAgent(),
+		}
+
+		ectx.SetRequest(req.WithContext(requestctx.WithMetadata(req.Context(), metadata)))
+		return next(ectx)
+	}
+}
@@ -0,0 +1,74 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/Bromolima/my-game-list/internal/tracing"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// probeRoutes are polled by the orchestrator and the metrics scraper, and are
+// left out of the traces and the access log, which they would fill with noise.
+var probeRoutes = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+type TracingMiddleware struct {
+	tracer trace.Tracer
+}
+
+func NewTracingMiddleware(tp trace.TracerProvider) *TracingMiddleware {
+	return &TracingMiddleware{
+		tracer: tp.Tracer(tracing.InstrumentationName),
+	}
+}
+
+// Tracing starts the server span of the request, continuing the trace of the
+// caller when the request carries a W3C traceparent header. The trace ID is
+// returned in the traceparent response header so clients can report it.
+func (m *TracingMiddleware) Tracing(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ectx echo.Context) error {
+		route := routeOf(ectx)
+		if probeRoutes[route] {
+			return next(ectx)
+		}
+
+		req := ectx.Request()
+		ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+		ctx, span := m.tracer.Start(ctx, req.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(req.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(req.URL.Path),
+				semconv.ClientAddress(ectx.RealIP()),
+				semconv.UserAgentOriginal(req.UserAgent()),
+			),
+		)
+		defer span.End()
+
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(ectx.Response().Header()))
+		ectx.SetRequest(req.WithContext(ctx))
+
+		err := next(ectx)
+
+		status := responseStatus(ectx, err)
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+
+		if err != nil {
+			span.RecordError(err)
+		}
+
+		return err
+	}
+}
@@ -91,3 +91,11 @@ func NewInternalServerErr(message string) *RestErr {
 		Code:    http.StatusInternalServerError,
 	}
 }
+
+func NewTooManyRequestsError(message string) *RestErr {
+	return &RestErr{
+		Message: message,
+		Err:     "too many requests",
+		Code:    http.StatusTooManyRequests,
+	}
+}
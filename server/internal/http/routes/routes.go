@@ -1,14 +1,28 @@
 package routes
 
 import (
+	"github.com/Bromolima/my-game-list/config"
 	"github.com/Bromolima/my-game-list/internal/entities"
 	"github.com/Bromolima/my-game-list/internal/http/handler"
 	"github.com/Bromolima/my-game-list/internal/http/middlewares"
+	"github.com/Bromolima/my-game-list/internal/metrics"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/dig"
 )
 
 func SetupRoutes(e *echo.Echo, c *dig.Container) error {
+	if err := setupMiddlewares(e, c); err != nil {
+		return err
+	}
+
+	if err := setupHealthRoutes(e, c); err != nil {
+		return err
+	}
+
+	if err := setupMetricsRoutes(e, c); err != nil {
+		return err
+	}
+
 	if err := setupUserRoutes(e, c); err != nil {
 		return err
 	}
@@ -17,6 +31,26 @@ func SetupRoutes(e *echo.Echo, c *dig.Container) error {
 		return err
 	}
 
+	if err := setupWellKnownRoutes(e, c); err != nil {
+		return err
+	}
+
+	if err := setupSessionRoutes(e, c); err != nil {
+		return err
+	}
+
+	if err := setupPersonalAccessTokenRoutes(e, c); err != nil {
+		return err
+	}
+
+	if err := setupTwoFactorRoutes(e, c); err != nil {
+		return err
+	}
+
+	if err := setupAdminRoutes(e, c); err != nil {
+		return err
+	}
+
 	if err := setupGamesRoutes(e, c); err != nil {
 		return err
 	}
@@ -32,12 +66,69 @@ func SetupRoutes(e *echo.Echo, c *dig.Container) error {
 	return nil
 }
 
+func setupMiddlewares(e *echo.Echo, c *dig.Container) error {
+	return c.Invoke(func(
+		tm *middlewares.TracingMiddleware,
+		rm *middlewares.RequestLoggerMiddleware,
+		mm *middlewares.MetricsMiddleware,
+		m *middlewares.CSRFMiddleware,
+	) {
+		e.Use(tm.Tracing)
+		e.Use(rm.RequestLogger)
+		e.Use(mm.Metrics)
+		e.Use(m.CSRF)
+	})
+}
+
+func setupHealthRoutes(e *echo.Echo, c *dig.Container) error {
+	return c.Invoke(func(h *handler.HealthHandler) {
+		e.GET("/healthz", h.Liveness)
+		e.GET("/readyz", h.Readiness)
+	})
+}
+
+// setupMetricsRoutes exposes the metrics on the API unless they are served on
+// a separate admin port.
+func setupMetricsRoutes(e *echo.Echo, c *dig.Container) error {
+	if config.Env.Metrics.Port != "" {
+		return nil
+	}
+
+	return c.Invoke(func(m *metrics.Metrics) {
+		e.GET("/metrics", echo.WrapHandler(m.Handler()))
+	})
+}
+
 func setupAuthRoutes(e *echo.Echo, c *dig.Container) error {
-	return c.Invoke(func(h *handler.UserHandler, m *middlewares.AuthMiddleware) {
+	return c.Invoke(func(
+		h *handler.UserHandler,
+		sh *handler.SessionHandler,
+		ph *handler.PasswordResetHandler,
+		eh *handler.EmailVerificationHandler,
+		oh *handler.OIDCHandler,
+		m *middlewares.AuthMiddleware,
+	) {
 		g := e.Group("/auth")
 
 		g.POST("/register", h.RegisterUser)
 		g.POST("/login", h.Login)
+		g.POST("/login/2fa", h.LoginTwoFactor)
+		g.POST("/refresh", sh.Refresh)
+		g.POST("/logout", sh.Logout)
+		g.POST("/forgot-password", ph.ForgotPassword)
+		g.POST("/reset-password", ph.ResetPassword)
+		g.POST("/verify-email", eh.ConfirmEmail)
+		g.POST("/verify-email/resend", eh.ResendVerification, m.RequireSession)
+		g.GET("/oidc/:provider/login", oh.Login)
+		g.GET("/oidc/:provider/callback", oh.Callback)
+	})
+}
+
+func setupWellKnownRoutes(e *echo.Echo, c *dig.Container) error {
+	return c.Invoke(func(h *handler.JwksHandler) {
+		g := e.Group("/.well-known")
+
+		g.GET("/jwks.json", h.GetJwks)
 	})
 }
 
@@ -46,8 +137,62 @@ func setupUserRoutes(e *echo.Echo, c *dig.Container) error {
 		g := e.Group("/users")
 
 		g.GET("/", h.SearchUsers)
-		g.PUT("/:id", h.UpdateUser, m.AuthMiddleware)
-		g.DELETE("/:id", h.DeleteUser, m.AuthMiddleware)
+		g.PUT("/:id", h.UpdateUser, m.RequireSession)
+		g.DELETE("/:id", h.DeleteUser, m.RequireSession)
+	})
+}
+
+func setupSessionRoutes(e *echo.Echo, c *dig.Container) error {
+	return c.Invoke(func(h *handler.SessionHandler, m *middlewares.AuthMiddleware) {
+		g := e.Group("/sessions", m.RequireSession)
+
+		g.GET("", h.ListSessions)
+		g.DELETE("/:id", h.RevokeSession)
+		g.DELETE("", h.RevokeAllSessions)
+	})
+}
+
+func setupPersonalAccessTokenRoutes(e *echo.Echo, c *dig.Container) error {
+	return c.Invoke(func(h *handler.PersonalAccessTokenHandler, m *middlewares.AuthMiddleware) {
+		g := e.Group("/tokens", m.RequireSession)
+
+		g.POST("", h.CreateToken)
+		g.GET("", h.ListTokens)
+		g.DELETE("/:id", h.RevokeToken)
+	})
+}
+
+func setupTwoFactorRoutes(e *echo.Echo, c *dig.Container) error {
+	return c.Invoke(func(h *handler.TwoFactorHandler, m *middlewares.AuthMiddleware) {
+		g := e.Group("/2fa", m.RequireSession)
+
+		g.POST("/enroll", h.Enroll)
+		g.POST("/confirm", h.Confirm)
+		g.DELETE("", h.Disable)
+	})
+}
+
+func setupAdminRoutes(e *echo.Echo, c *dig.Container) error {
+	return c.Invoke(func(
+		h *handler.UserHandler,
+		rh *handler.RoleHandler,
+		ph *handler.PermissionHandler,
+		ah *handler.AuditLogHandler,
+		m *middlewares.AuthMiddleware,
+	) {
+		g := e.Group("/admin")
+
+		g.POST("/users/:id/unlock", h.UnlockUser, m.RequireAccess(entities.ManageUsersAccess))
+		g.PUT("/users/:id/role", rh.AssignRole, m.RequireAccess(entities.ManageRolesAccess))
+		g.GET("/roles", rh.ListRoles, m.RequireAccess(entities.ManageRolesAccess))
+		g.POST("/roles", rh.CreateRole, m.RequireAccess(entities.ManageRolesAccess))
+		g.GET("/roles/:id/users", rh.ListRoleUsers, m.RequireAccess(entities.ManageRolesAccess))
+		g.PUT("/roles/:id/access/:access", rh.GrantAccess, m.RequireAccess(entities.ManageRolesAccess))
+		g.DELETE("/roles/:id/access/:access", rh.RevokeAccess, m.RequireAccess(entities.ManageRolesAccess))
+		g.GET("/permissions", ph.ListGrants, m.RequireAccess(entities.ManageRolesAccess))
+		g.POST("/permissions", ph.CreateGrant, m.RequireAccess(entities.ManageRolesAccess))
+		g.DELETE("/permissions/:id", ph.DeleteGrant, m.RequireAccess(entities.ManageRolesAccess))
+		g.GET("/audit-logs", ah.SearchAuditLogs, m.RequireAccess(entities.ViewAuditLogAccess))
 	})
 }
 
@@ -55,10 +200,10 @@ func setupGamesRoutes(e *echo.Echo, c *dig.Container) error {
 	return c.Invoke(func(h *handler.GameHandler, m *middlewares.AuthMiddleware) {
 		g := e.Group("/games")
 
-		g.POST("", h.CreateGame, m.RequireAccess(entities.CreateAcess))
+		g.POST("", h.CreateGame, m.RequireScope(entities.CreateAcess), m.RequirePermission(entities.ResourceGame, entities.PermissionCreate))
 		g.GET("", h.SearchGames, m.RequireAccess(entities.ReadAccess))
-		g.PUT("/:id", h.UpdateGame, m.RequireAccess(entities.UpdateAccess))
-		g.DELETE("/id", h.DeleteGame, m.RequireAccess(entities.DeleteAcess))
+		g.PUT("/:id", h.UpdateGame, m.RequireScope(entities.UpdateAccess), m.RequirePermission(entities.ResourceGame, entities.PermissionEdit))
+		g.DELETE("/:id", h.DeleteGame, m.RequireScope(entities.DeleteAcess), m.RequirePermission(entities.ResourceGame, entities.PermissionDelete))
 	})
 }
 
@@ -66,17 +211,19 @@ func setupGameListRoutes(e *echo.Echo, c *dig.Container) error {
 	return c.Invoke(func(h *handler.GameListHandler, m *middlewares.AuthMiddleware) {
 		g := e.Group("/list")
 
-		g.POST("", h.CreateGameList)
-		g.GET("/:id", h.FindGamesFromList)
-		g.PUT("", h.UpdateGameList)
-		g.DELETE("", h.DeleteGameList)
+		g.POST("", h.CreateGameList, m.RequireScope(entities.CreateAcess), m.RequireVerifiedEmail(entities.VerifiedActionCreateList))
+		g.GET("/:id", h.FindGamesFromList, m.OptionalScope(entities.ReadAccess))
+		g.PUT("/:id", h.UpdateGameList, m.RequireScope(entities.UpdateAccess))
+		g.DELETE("/:id", h.DeleteGameList, m.RequireScope(entities.DeleteAcess))
+		g.POST("/:id/collaborators", h.AddCollaborator, m.RequireScope(entities.UpdateAccess))
+		g.DELETE("/:id/collaborators/:userId", h.RemoveCollaborator, m.RequireScope(entities.UpdateAccess))
 	})
 }
 
 func setupListItemRoutes(e *echo.Echo, c *dig.Container) error {
 	return c.Invoke(func(h *handler.ListItemHandler, m *middlewares.AuthMiddleware) {
-		e.POST("", h.AddGameToList, m.AuthMiddleware)
-		e.PUT("", h.UpdateGameFromList, m.AuthMiddleware)
-		e.DELETE("", h.DeleteGameFromList, m.AuthMiddleware)
+		e.POST("", h.AddGameToList, m.RequireScope(entities.CreateAcess))
+		e.PUT("", h.UpdateGameFromList, m.RequireScope(entities.UpdateAccess))
+		e.DELETE("", h.DeleteGameFromList, m.RequireScope(entities.DeleteAcess))
 	})
 }
@@ -1,40 +1,138 @@
 package injector
 
 import (
+	"context"
+	"log/slog"
+
 	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/health"
 	"github.com/Bromolima/my-game-list/internal/http/handler"
 	"github.com/Bromolima/my-game-list/internal/http/middlewares"
+	"github.com/Bromolima/my-game-list/internal/lifecycle"
+	"github.com/Bromolima/my-game-list/internal/mailer"
+	"github.com/Bromolima/my-game-list/internal/metrics"
+	"github.com/Bromolima/my-game-list/internal/oidc"
+	"github.com/Bromolima/my-game-list/internal/policy"
 	"github.com/Bromolima/my-game-list/internal/repository"
 	"github.com/Bromolima/my-game-list/internal/service"
 	"github.com/Bromolima/my-game-list/internal/token"
+	"github.com/Bromolima/my-game-list/internal/tracing"
 	"github.com/Bromolima/my-game-list/logger"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/dig"
 	"gorm.io/gorm"
 )
 
+// readinessCheckers collects the checkers provided in the readiness group.
+type readinessCheckers struct {
+	dig.In
+
+	Checkers []health.Checker `group:"readiness_checkers"`
+}
+
 func SetupDependecies(c *dig.Container, db *gorm.DB) {
-	c.Provide(func() *gorm.DB {
-		return db
+	c.Provide(metrics.NewMetrics)
+	c.Provide(tracing.NewTracerProvider)
+	c.Provide(func(m *metrics.Metrics, tp trace.TracerProvider) (*gorm.DB, error) {
+		if err := db.Use(metrics.NewGormPlugin(m)); err != nil {
+			return nil, err
+		}
+
+		if err := db.Use(tracing.NewGormPlugin(tp)); err != nil {
+			return nil, err
+		}
+
+		return db, nil
 	})
 
 	c.Provide(logger.NewLogger)
+	c.Provide(func(logger *slog.Logger) *lifecycle.Lifecycle {
+		lc := lifecycle.NewLifecycle(logger)
+
+		// Registered first so the connection pool is closed last, once
+		// everything using it has stopped.
+		lc.Append(lifecycle.Hook{
+			Name: "database",
+			OnStop: func(context.Context) error {
+				sqlDB, err := db.DB()
+				if err != nil {
+					return err
+				}
+
+				return sqlDB.Close()
+			},
+		})
+
+		return lc
+	})
 
 	c.Provide(repository.NewRoleRepository)
 	c.Provide(repository.NewPageRepository[entities.Game])
 	c.Provide(repository.NewPageRepository[entities.User])
 	c.Provide(repository.NewUserRepository)
+	c.Provide(repository.NewSessionRepository)
+	c.Provide(repository.NewPersonalAccessTokenRepository)
+	c.Provide(repository.NewUserTokenRepository)
+	c.Provide(repository.NewTwoFactorRepository)
+	c.Provide(repository.NewRecoveryCodeRepository)
+	c.Provide(repository.NewLoginThrottleRepository)
+	c.Provide(repository.NewUserIdentityRepository)
+	c.Provide(repository.NewOIDCLoginStateRepository)
 	c.Provide(repository.NewGameRepository)
+	c.Provide(repository.NewGameListRepository)
 	c.Provide(repository.NewListItemRepository)
+	c.Provide(repository.NewPermissionGrantRepository)
+	c.Provide(repository.NewAuditLogRepository)
 
+	c.Provide(mailer.NewMailer)
+	c.Provide(oidc.NewClient)
+	c.Provide(policy.NewEngine)
+	c.Provide(policy.NewAccessCache)
+
+	c.Provide(health.NewDatabaseChecker, dig.Group(health.ReadinessGroup))
+	c.Provide(health.NewMigrationChecker, dig.Group(health.ReadinessGroup))
+	c.Provide(health.NewMailerChecker, dig.Group(health.ReadinessGroup))
+
+	c.Provide(token.NewKeyStore)
+	c.Provide(token.NewKeyRotator)
 	c.Provide(token.NewJwtService)
+	c.Provide(service.NewAuditService)
 	c.Provide(service.NewGameListService)
 	c.Provide(service.NewListItemService)
 	c.Provide(service.NewGameService)
 	c.Provide(service.NewUserService)
+	c.Provide(service.NewSessionService)
+	c.Provide(service.NewPersonalAccessTokenService)
+	c.Provide(service.NewPasswordResetService)
+	c.Provide(service.NewEmailVerificationService)
+	c.Provide(service.NewTwoFactorService)
+	c.Provide(service.NewOIDCService)
+	c.Provide(service.NewRoleService)
+	c.Provide(service.NewPermissionService)
+	c.Provide(service.NewAdminService)
+	c.Provide(func(checkers readinessCheckers, logger *slog.Logger) service.HealthService {
+		return service.NewHealthService(checkers.Checkers, logger)
+	})
 
 	c.Provide(middlewares.NewAuthMiddleware)
+	c.Provide(middlewares.NewCSRFMiddleware)
+	c.Provide(middlewares.NewMetricsMiddleware)
+	c.Provide(middlewares.NewTracingMiddleware)
+	c.Provide(middlewares.NewRequestLoggerMiddleware)
 
 	c.Provide(handler.NewGameListHandler)
 	c.Provide(handler.NewGameHandler)
 	c.Provide(handler.NewUserHandler)
+	c.Provide(handler.NewSessionHandler)
+	c.Provide(handler.NewPersonalAccessTokenHandler)
+	c.Provide(handler.NewPasswordResetHandler)
+	c.Provide(handler.NewEmailVerificationHandler)
+	c.Provide(handler.NewTwoFactorHandler)
+	c.Provide(handler.NewOIDCHandler)
+	c.Provide(handler.NewRoleHandler)
+	c.Provide(handler.NewPermissionHandler)
+	c.Provide(handler.NewAuditLogHandler)
+	c.Provide(handler.NewListItemHandler)
+	c.Provide(handler.NewJwksHandler)
+	c.Provide(handler.NewHealthHandler)
 }
@@ -0,0 +1,131 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// Hook is run when the application starts and stops. Either function may be
+// nil. OnStart must not block: long running work belongs in a goroutine, see
+// Worker.
+type Hook struct {
+	Name    string
+	OnStart func(ctx context.Context) error
+	OnStop  func(ctx context.Context) error
+}
+
+// Lifecycle is the registry of hooks shared through the DI container.
+// Components append their hooks from their constructors, so a hook is only
+// registered when the component is actually used.
+type Lifecycle struct {
+	mu      sync.Mutex
+	hooks   []Hook
+	started int
+	logger  *slog.Logger
+}
+
+func NewLifecycle(logger *slog.Logger) *Lifecycle {
+	return &Lifecycle{
+		logger: logger.With(slog.String("app", "lifecycle")),
+	}
+}
+
+func (l *Lifecycle) Append(hook Hook) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.hooks = append(l.hooks, hook)
+}
+
+// Start runs the OnStart functions in registration order. When one fails,
+// the hooks already started are stopped before the error is returned.
+func (l *Lifecycle) Start(ctx context.Context) error {
+	log := l.logger.With(slog.String("func", "Start"))
+
+	l.mu.Lock()
+	hooks := l.hooks[l.started:]
+	l.mu.Unlock()
+
+	for _, hook := range hooks {
+		if hook.OnStart != nil {
+			if err := hook.OnStart(ctx); err != nil {
+				log.Error("Failed to start component", slog.String("hook", hook.Name), slog.String("error", err.Error()))
+				return errors.Join(fmt.Errorf("start %s: %w", hook.Name, err), l.Stop(ctx))
+			}
+		}
+
+		l.mu.Lock()
+		l.started++
+		l.mu.Unlock()
+	}
+
+	return nil
+}
+
+// Stop runs the OnStop functions of the started hooks in reverse order, so a
+// component is stopped before the ones it depends on. Every hook is stopped
+// even if another one fails, and the errors are returned together.
+func (l *Lifecycle) Stop(ctx context.Context) error {
+	log := l.logger.With(slog.String("func", "Stop"))
+
+	l.mu.Lock()
+	hooks := l.hooks[:l.started]
+	l.started = 0
+	l.mu.Unlock()
+
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hook := hooks[i]
+		if hook.OnStop == nil {
+			continue
+		}
+
+		if err := hook.OnStop(ctx); err != nil {
+			log.Error("Failed to stop component", slog.String("hook", hook.Name), slog.String("error", err.Error()))
+			errs = append(errs, fmt.Errorf("stop %s: %w", hook.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Worker runs fn in a goroutine from start to stop. The context given to fn is
+// cancelled on stop, which then waits for fn to return or for the stop
+// context to expire.
+func Worker(name string, fn func(ctx context.Context)) Hook {
+	var (
+		cancel context.CancelFunc
+		done   chan struct{}
+	)
+
+	return Hook{
+		Name: name,
+		OnStart: func(context.Context) error {
+			// The start context only covers the startup, the worker keeps
+			// running until it is stopped.
+			var ctx context.Context
+			ctx, cancel = context.WithCancel(context.Background())
+			done = make(chan struct{})
+
+			go func() {
+				defer close(done)
+				fn(ctx)
+			}()
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	}
+}
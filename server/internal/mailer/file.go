@@ -0,0 +1,60 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Bromolima/my-game-list/config"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/google/uuid"
+)
+
+type fileMailer struct {
+	dir    string
+	from   string
+	logger *slog.Logger
+}
+
+// NewFileMailer writes every message as an .eml file in the configured
+// directory instead of delivering it.
+func NewFileMailer(cfg config.Mail, logger *slog.Logger) (Mailer, error) {
+	if err := os.MkdirAll(cfg.Dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	return &fileMailer{
+		dir:    cfg.Dir,
+		from:   cfg.From,
+		logger: logger.With(slog.String("mailer", "file")),
+	}, nil
+}
+
+func (m *fileMailer) Send(ctx context.Context, message *Message) error {
+	log := logger.Scoped(ctx, m.logger).With(slog.String("func", "Send"))
+
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405"), uuid.NewString())
+	if err := os.WriteFile(filepath.Join(m.dir, name), formatMessage(m.from, message), 0o600); err != nil {
+		log.Error("Failed to write email file", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// Ping checks that the message directory still exists.
+func (m *fileMailer) Ping(ctx context.Context) error {
+	info, err := os.Stat(m.dir)
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", m.dir)
+	}
+
+	return nil
+}
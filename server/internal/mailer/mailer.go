@@ -0,0 +1,48 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/Bromolima/my-game-list/config"
+)
+
+const (
+	DriverSMTP   = "smtp"
+	DriverFile   = "file"
+	DriverMemory = "memory"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+//go:generate mockgen -source=mailer.go -destination=../../mocks/mailer.go -package=mocks
+type Mailer interface {
+	Send(ctx context.Context, message *Message) error
+}
+
+// Pinger is implemented by the mailers that depend on something which can be
+// unavailable, such as an SMTP server.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// NewMailer builds the mailer selected by the MAIL_DRIVER setting. The file
+// and memory drivers never leave the machine and are meant for development
+// and tests.
+func NewMailer(logger *slog.Logger) (Mailer, error) {
+	switch config.Env.Mail.Driver {
+	case DriverSMTP:
+		return NewSMTPMailer(config.Env.Mail, logger), nil
+	case DriverFile:
+		return NewFileMailer(config.Env.Mail, logger)
+	case DriverMemory, "":
+		return NewMemoryMailer(), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", config.Env.Mail.Driver)
+	}
+}
@@ -0,0 +1,36 @@
+package mailer
+
+import (
+	"fmt"
+	"time"
+)
+
+func NewPasswordResetMessage(to, resetURL string, expiresIn time.Duration) *Message {
+	return &Message{
+		To:      to,
+		Subject: "Reset your My Game List password",
+		Body: fmt.Sprintf(
+			"We received a request to reset your password.\n\n"+
+				"Use the link below to choose a new one. It expires in %d minutes and can only be used once.\n\n"+
+				"%s\n\n"+
+				"If you did not request a password reset you can ignore this email.\n",
+			int(expiresIn.Minutes()),
+			resetURL,
+		),
+	}
+}
+
+func NewEmailVerificationMessage(to, verificationURL string, expiresIn time.Duration) *Message {
+	return &Message{
+		To:      to,
+		Subject: "Confirm your My Game List email address",
+		Body: fmt.Sprintf(
+			"Welcome to My Game List!\n\n"+
+				"Confirm your email address with the link below. It expires in %d hours.\n\n"+
+				"%s\n\n"+
+				"If you did not create an account you can ignore this email.\n",
+			int(expiresIn.Hours()),
+			verificationURL,
+		),
+	}
+}
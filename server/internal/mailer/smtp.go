@@ -0,0 +1,84 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/Bromolima/my-game-list/config"
+	"github.com/Bromolima/my-game-list/logger"
+)
+
+type smtpMailer struct {
+	address  string
+	host     string
+	username string
+	password string
+	from     string
+	logger   *slog.Logger
+}
+
+func NewSMTPMailer(cfg config.Mail, logger *slog.Logger) Mailer {
+	return &smtpMailer{
+		address:  net.JoinHostPort(cfg.Host, cfg.Port),
+		host:     cfg.Host,
+		username: cfg.Username,
+		password: cfg.Password,
+		from:     cfg.From,
+		logger:   logger.With(slog.String("mailer", "smtp")),
+	}
+}
+
+func (m *smtpMailer) Send(ctx context.Context, message *Message) error {
+	log := logger.Scoped(ctx, m.logger).With(slog.String("func", "Send"))
+
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	if err := smtp.SendMail(m.address, auth, m.from, []string{message.To}, formatMessage(m.from, message)); err != nil {
+		log.Error("Failed to send email through SMTP", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// Ping opens a connection to the SMTP server and checks its greeting, without
+// authenticating or sending anything.
+func (m *smtpMailer) Ping(ctx context.Context) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", m.address)
+	if err != nil {
+		return err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	return client.Quit()
+}
+
+func formatMessage(from string, message *Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", message.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", message.Subject)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(message.Body)
+
+	return []byte(b.String())
+}
@@ -0,0 +1,180 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+
+	"github.com/Bromolima/my-game-list/config"
+	"github.com/Bromolima/my-game-list/logger"
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+var (
+	ErrUnknownProvider = errors.New("the identity provider is not configured")
+	ErrMissingIDToken  = errors.New("the token response does not contain an id token")
+	ErrNonceMismatch   = errors.New("the id token nonce does not match the login attempt")
+)
+
+// Identity is the verified subset of the ID token claims used to find or
+// create the local user.
+type Identity struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+	Picture           string
+}
+
+// Client runs the authorization code flow with PKCE against the configured
+// OpenID Connect providers.
+type Client interface {
+	AuthCodeURL(ctx context.Context, providerName, state, nonce, codeVerifier string) (string, error)
+	Exchange(ctx context.Context, providerName, code, codeVerifier, nonce string) (*Identity, error)
+}
+
+type client struct {
+	providers map[string]*provider
+	logger    *slog.Logger
+}
+
+// provider discovers the issuer metadata on first use, so the API can start
+// while an identity provider is unreachable.
+type provider struct {
+	config   config.OIDCProvider
+	mu       sync.Mutex
+	oauth    *oauth2.Config
+	verifier *gooidc.IDTokenVerifier
+}
+
+func NewClient(logger *slog.Logger) Client {
+	providers := make(map[string]*provider, len(config.Env.OIDC.Providers))
+	for _, providerConfig := range config.Env.OIDC.Providers {
+		providers[providerConfig.Name] = &provider{config: providerConfig}
+	}
+
+	return &client{
+		providers: providers,
+		logger:    logger.With(slog.String("oidc", "client")),
+	}
+}
+
+func (c *client) AuthCodeURL(ctx context.Context, providerName, state, nonce, codeVerifier string) (string, error) {
+	oauthConfig, _, err := c.discover(ctx, providerName)
+	if err != nil {
+		return "", err
+	}
+
+	return oauthConfig.AuthCodeURL(state, gooidc.Nonce(nonce), oauth2.S256ChallengeOption(codeVerifier)), nil
+}
+
+func (c *client) Exchange(ctx context.Context, providerName, code, codeVerifier, nonce string) (*Identity, error) {
+	oauthConfig, verifier, err := c.discover(ctx, providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	oauthToken, err := oauthConfig.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("exchange authorization code: %w", err)
+	}
+
+	rawIDToken, ok := oauthToken.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, ErrMissingIDToken
+	}
+
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("verify id token: %w", err)
+	}
+
+	if idToken.Nonce != nonce {
+		return nil, ErrNonceMismatch
+	}
+
+	var claims struct {
+		Email             string    `json:"email"`
+		EmailVerified     claimBool `json:"email_verified"`
+		Name              string    `json:"name"`
+		PreferredUsername string    `json:"preferred_username"`
+		Picture           string    `json:"picture"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("decode id token claims: %w", err)
+	}
+
+	return &Identity{
+		Subject:           idToken.Subject,
+		Email:             claims.Email,
+		EmailVerified:     bool(claims.EmailVerified),
+		Name:              claims.Name,
+		PreferredUsername: claims.PreferredUsername,
+		Picture:           claims.Picture,
+	}, nil
+}
+
+func (c *client) discover(ctx context.Context, providerName string) (*oauth2.Config, *gooidc.IDTokenVerifier, error) {
+	log := logger.Scoped(ctx, c.logger).With(slog.String("func", "discover"))
+
+	p, ok := c.providers[providerName]
+	if !ok {
+		return nil, nil, ErrUnknownProvider
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.oauth != nil {
+		return p.oauth, p.verifier, nil
+	}
+
+	// The provider keeps the context to refresh its signing keys later, so it
+	// must outlive the request that triggered the discovery.
+	issuer, err := gooidc.NewProvider(context.WithoutCancel(ctx), p.config.IssuerURL)
+	if err != nil {
+		log.Error("Failed to discover identity provider", slog.String("provider", providerName), slog.String("error", err.Error()))
+		return nil, nil, err
+	}
+
+	p.oauth = &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		RedirectURL:  p.config.RedirectURL,
+		Endpoint:     issuer.Endpoint(),
+		Scopes:       p.config.Scopes,
+	}
+	p.verifier = issuer.Verifier(&gooidc.Config{ClientID: p.config.ClientID})
+
+	return p.oauth, p.verifier, nil
+}
+
+// claimBool accepts the email_verified claim as a boolean or as a string,
+// since some providers send it quoted.
+type claimBool bool
+
+func (b *claimBool) UnmarshalJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case bool:
+		*b = claimBool(v)
+	case string:
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+		*b = claimBool(parsed)
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+// Package oidctest provides a local OpenID Connect issuer to exercise the
+// login flow without a real identity provider.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/Bromolima/my-game-list/internal/oidc"
+	"github.com/Bromolima/my-game-list/internal/token"
+	"github.com/golang-jwt/jwt"
+)
+
+const keyID = "oidctest"
+
+type authorization struct {
+	clientID      string
+	nonce         string
+	codeChallenge string
+	identity      oidc.Identity
+}
+
+// Issuer serves discovery, JWKS and token endpoints. Authorize stands in for
+// the user approving the login on the provider's consent screen.
+type Issuer struct {
+	server         *httptest.Server
+	key            *rsa.PrivateKey
+	mu             sync.Mutex
+	authorizations map[string]authorization
+}
+
+func NewIssuer() (*Issuer, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	issuer := &Issuer{
+		key:            key,
+		authorizations: map[string]authorization{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", issuer.discovery)
+	mux.HandleFunc("GET /keys", issuer.keys)
+	mux.HandleFunc("POST /token", issuer.token)
+	issuer.server = httptest.NewServer(mux)
+
+	return issuer, nil
+}
+
+func (i *Issuer) URL() string {
+	return i.server.URL
+}
+
+func (i *Issuer) Close() {
+	i.server.Close()
+}
+
+// Authorize approves the login started at authCodeURL for the given identity
+// and returns the code and state the provider would send to the redirect URL.
+func (i *Issuer) Authorize(authCodeURL string, identity oidc.Identity) (code, state string, err error) {
+	parsed, err := url.Parse(authCodeURL)
+	if err != nil {
+		return "", "", err
+	}
+
+	query := parsed.Query()
+	code = rand.Text()
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.authorizations[code] = authorization{
+		clientID:      query.Get("client_id"),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+		identity:      identity,
+	}
+
+	return code, query.Get("state"), nil
+}
+
+func (i *Issuer) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                i.server.URL,
+		"authorization_endpoint":                i.server.URL + "/authorize",
+		"token_endpoint":                        i.server.URL + "/token",
+		"jwks_uri":                              i.server.URL + "/keys",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (i *Issuer) keys(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, token.NewJwks([]*token.SigningKey{{
+		ID:        keyID,
+		Method:    jwt.SigningMethodRS256,
+		VerifyKey: &i.key.PublicKey,
+	}}))
+}
+
+func (i *Issuer) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	i.mu.Lock()
+	auth, ok := i.authorizations[r.PostForm.Get("code")]
+	delete(i.authorizations, r.PostForm.Get("code"))
+	i.mu.Unlock()
+
+	if !ok || !verifyCodeChallenge(auth.codeChallenge, r.PostForm.Get("code_verifier")) {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":                i.server.URL,
+		"aud":                auth.clientID,
+		"sub":                auth.identity.Subject,
+		"iat":                now.Unix(),
+		"exp":                now.Add(time.Hour).Unix(),
+		"nonce":              auth.nonce,
+		"email":              auth.identity.Email,
+		"email_verified":     auth.identity.EmailVerified,
+		"name":               auth.identity.Name,
+		"preferred_username": auth.identity.PreferredUsername,
+		"picture":            auth.identity.Picture,
+	})
+	idToken.Header["kid"] = keyID
+
+	signedIDToken, err := idToken.SignedString(i.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": rand.Text(),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     signedIDToken,
+	})
+}
+
+func verifyCodeChallenge(codeChallenge, codeVerifier string) bool {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return codeChallenge != "" && base64.RawURLEncoding.EncodeToString(sum[:]) == codeChallenge
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
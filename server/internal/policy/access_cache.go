@@ -0,0 +1,96 @@
+package policy
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Bromolima/my-game-list/config"
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/repository"
+	"github.com/Bromolima/my-game-list/logger"
+)
+
+// AccessCache keeps the access types of every role in memory so that checking
+// a global access does not hit the database on each request. The role
+// management endpoints invalidate it, and the TTL bounds how long other
+// instances of the API keep serving a stale copy.
+//
+//go:generate mockgen -source=access_cache.go -destination=../../mocks/access_cache.go -package=mocks
+type AccessCache interface {
+	HasAccess(ctx context.Context, roleID uint, access entities.AccessType) (bool, error)
+	Invalidate()
+}
+
+type accessCache struct {
+	roleRepository repository.RoleRepository
+	mu             sync.RWMutex
+	roles          map[uint]map[entities.AccessType]struct{}
+	loadedAt       time.Time
+	logger         *slog.Logger
+}
+
+func NewAccessCache(roleRepository repository.RoleRepository, logger *slog.Logger) AccessCache {
+	return &accessCache{
+		roleRepository: roleRepository,
+		logger:         logger.With(slog.String("policy", "accessCache")),
+	}
+}
+
+func (c *accessCache) HasAccess(ctx context.Context, roleID uint, access entities.AccessType) (bool, error) {
+	roles, err := c.load(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := roles[roleID][access]
+	return ok, nil
+}
+
+func (c *accessCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.roles = nil
+}
+
+func (c *accessCache) load(ctx context.Context) (map[uint]map[entities.AccessType]struct{}, error) {
+	log := logger.Scoped(ctx, c.logger).With(slog.String("func", "load"))
+
+	c.mu.RLock()
+	roles, loadedAt := c.roles, c.loadedAt
+	c.mu.RUnlock()
+
+	if roles != nil && time.Since(loadedAt) < config.Env.Authorization.AccessCacheTTL {
+		return roles, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Another request may have reloaded the roles while waiting for the lock.
+	if c.roles != nil && time.Since(c.loadedAt) < config.Env.Authorization.AccessCacheTTL {
+		return c.roles, nil
+	}
+
+	found, err := c.roleRepository.FindAll(ctx)
+	if err != nil {
+		log.Error("Failed to load role access", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	roles = make(map[uint]map[entities.AccessType]struct{}, len(found))
+	for _, role := range found {
+		accessTypes := make(map[entities.AccessType]struct{}, len(role.Access))
+		for _, roleAccess := range role.Access {
+			accessTypes[roleAccess.Type()] = struct{}{}
+		}
+
+		roles[role.ID] = accessTypes
+	}
+
+	c.roles = roles
+	c.loadedAt = time.Now()
+	return roles, nil
+}
@@ -0,0 +1,85 @@
+package policy
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/repository"
+	"github.com/Bromolima/my-game-list/internal/requestctx"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/google/uuid"
+)
+
+// Engine decides whether a user may perform an action on a resource. Every
+// service and middleware authorizes through it so that the rules live in a
+// single place.
+type Engine interface {
+	Authorize(ctx context.Context, userID uuid.UUID, action entities.PermissionAction, resource Resource) (bool, error)
+}
+
+type ruleKey struct {
+	resourceType entities.ResourceType
+	action       entities.PermissionAction
+}
+
+type engine struct {
+	rules  map[ruleKey][]Rule
+	logger *slog.Logger
+}
+
+func NewEngine(accessCache AccessCache, grantRepository repository.PermissionGrantRepository, logger *slog.Logger) Engine {
+	e := &engine{
+		rules:  make(map[ruleKey][]Rule),
+		logger: logger.With(slog.String("policy", "engine")),
+	}
+
+	e.register(entities.ResourceGameList, entities.PermissionView, Public(), Owner(), Granted(grantRepository, entities.PermissionView, entities.PermissionEdit))
+	e.register(entities.ResourceGameList, entities.PermissionEdit, Owner(), Granted(grantRepository))
+	e.register(entities.ResourceGameList, entities.PermissionDelete, Owner())
+	e.register(entities.ResourceGameList, entities.PermissionShare, Owner())
+
+	e.register(entities.ResourceGame, entities.PermissionCreate, RoleAccess(accessCache, entities.CreateAcess), Granted(grantRepository))
+	e.register(entities.ResourceGame, entities.PermissionEdit, RoleAccess(accessCache, entities.UpdateAccess), Granted(grantRepository))
+	e.register(entities.ResourceGame, entities.PermissionDelete, RoleAccess(accessCache, entities.DeleteAcess), Granted(grantRepository))
+
+	return e
+}
+
+func (e *engine) register(resourceType entities.ResourceType, action entities.PermissionAction, rules ...Rule) {
+	key := ruleKey{resourceType: resourceType, action: action}
+	e.rules[key] = append(e.rules[key], rules...)
+}
+
+// Authorize denies by default: the request is allowed by the first rule that
+// allows it, and denied when no rule is registered for the resource type and
+// action.
+func (e *engine) Authorize(ctx context.Context, userID uuid.UUID, action entities.PermissionAction, resource Resource) (bool, error) {
+	log := logger.Scoped(ctx, e.logger).With(slog.String("func", "Authorize"))
+
+	request := Request{
+		UserID:   userID,
+		Action:   action,
+		Resource: resource,
+	}
+
+	// The role is only known when the user is the one authenticated by the
+	// request.
+	if metadata := requestctx.MetadataFrom(ctx); metadata.ActorID == userID {
+		request.RoleID = metadata.ActorRoleID
+	}
+
+	for _, rule := range e.rules[ruleKey{resourceType: resource.Type, action: action}] {
+		allowed, err := rule.Allows(ctx, request)
+		if err != nil {
+			log.Error("Failed to evaluate policy rule", slog.String("error", err.Error()))
+			return false, err
+		}
+
+		if allowed {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,46 @@
+package policy
+
+import (
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/google/uuid"
+)
+
+// Resource is what a request acts on. A resource without an ID stands for the
+// whole type, which is how middlewares ask whether a user may act on a type
+// before the service loads the resource itself.
+type Resource struct {
+	Type       entities.ResourceType
+	ID         uuid.UUID
+	OwnerID    uuid.UUID
+	Public     bool
+	Attributes map[string]string
+}
+
+func NewResource(resourceType entities.ResourceType) Resource {
+	return Resource{
+		Type: resourceType,
+	}
+}
+
+func NewGameResource(game *entities.Game) Resource {
+	return Resource{
+		Type: entities.ResourceGame,
+		ID:   game.ID,
+		Attributes: map[string]string{
+			entities.ResourceAttributeGenre: game.Genre,
+		},
+	}
+}
+
+func NewGameListResource(gameList *entities.GameList) Resource {
+	return Resource{
+		Type:    entities.ResourceGameList,
+		ID:      gameList.ID,
+		OwnerID: gameList.UserID,
+		Public:  gameList.IsPublic,
+	}
+}
+
+func (r Resource) isType() bool {
+	return r.ID == uuid.Nil
+}
@@ -0,0 +1,110 @@
+package policy
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/repository"
+	"github.com/google/uuid"
+)
+
+// Request is the question asked to the engine: may the subject perform the
+// action on the resource.
+// RoleID is the role of the subject when it is known from its token, and zero
+// otherwise.
+type Request struct {
+	UserID   uuid.UUID
+	RoleID   uint
+	Action   entities.PermissionAction
+	Resource Resource
+}
+
+// Rule allows a request. Rules never deny, a request is denied when none of
+// the rules registered for its resource type and action allows it.
+type Rule interface {
+	Allows(ctx context.Context, request Request) (bool, error)
+}
+
+type RuleFunc func(ctx context.Context, request Request) (bool, error)
+
+func (f RuleFunc) Allows(ctx context.Context, request Request) (bool, error) {
+	return f(ctx, request)
+}
+
+// Owner allows the user that owns the resource.
+func Owner() Rule {
+	return RuleFunc(func(ctx context.Context, request Request) (bool, error) {
+		return request.Resource.OwnerID != uuid.Nil && request.Resource.OwnerID == request.UserID, nil
+	})
+}
+
+// Public allows everyone when the resource is public.
+func Public() Rule {
+	return RuleFunc(func(ctx context.Context, request Request) (bool, error) {
+		return request.Resource.Public, nil
+	})
+}
+
+// RoleAccess allows the users whose role holds the global access type. The
+// role is the one of the token, checked against the cached role access, and a
+// subject whose role is unknown is not allowed.
+func RoleAccess(accessCache AccessCache, access entities.AccessType) Rule {
+	return RuleFunc(func(ctx context.Context, request Request) (bool, error) {
+		if request.RoleID == 0 {
+			return false, nil
+		}
+
+		return accessCache.HasAccess(ctx, request.RoleID, access)
+	})
+}
+
+// Granted allows the users holding a permission grant that matches the
+// resource, directly or through their role. The grant must be for one of the
+// given actions, or for the requested action when none is given, so that e.g.
+// collaborators allowed to edit a list may also view it.
+func Granted(grantRepository repository.PermissionGrantRepository, actions ...entities.PermissionAction) Rule {
+	return RuleFunc(func(ctx context.Context, request Request) (bool, error) {
+		// Anonymous users hold no grant.
+		if request.UserID == uuid.Nil {
+			return false, nil
+		}
+
+		grantActions := actions
+		if len(grantActions) == 0 {
+			grantActions = []entities.PermissionAction{request.Action}
+		}
+
+		grants, err := grantRepository.FindForSubject(ctx, request.UserID, request.Resource.Type, grantActions)
+		if err != nil {
+			return false, err
+		}
+
+		for _, grant := range grants {
+			if grantMatches(grant, request.Resource) {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	})
+}
+
+// grantMatches checks whether the grant covers the resource. Every grant of the
+// type matches a type-level resource, the service that loads the resource runs
+// the precise check afterwards.
+func grantMatches(grant *entities.PermissionGrant, resource Resource) bool {
+	if resource.isType() {
+		return true
+	}
+
+	if grant.ResourceID != nil && *grant.ResourceID != resource.ID {
+		return false
+	}
+
+	if grant.Attribute != "" && !strings.EqualFold(resource.Attributes[grant.Attribute], grant.Value) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/logger"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=audit_log.go -destination=../../mocks/audit_log_repository.go -package=mocks
+type AuditLogRepository interface {
+	Create(ctx context.Context, auditLog *entities.AuditLog) error
+	Search(ctx context.Context, page *entities.Page[entities.AuditLog], filter *entities.AuditLogFilter) (*entities.Page[entities.AuditLog], error)
+}
+
+type auditLogRepository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewAuditLogRepository(db *gorm.DB, logger *slog.Logger) AuditLogRepository {
+	return &auditLogRepository{
+		db:     db,
+		logger: logger.With(slog.String("auditLog", "repository")),
+	}
+}
+
+func (r *auditLogRepository) Create(ctx context.Context, auditLog *entities.AuditLog) error {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "Create"))
+
+	if err := r.db.WithContext(ctx).Create(auditLog).Error; err != nil {
+		log.Error("Failed to create audit log in database", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+func (r *auditLogRepository) Search(ctx context.Context, page *entities.Page[entities.AuditLog], filter *entities.AuditLogFilter) (*entities.Page[entities.AuditLog], error) {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "Search"))
+
+	query := r.db.WithContext(ctx).Model(&entities.AuditLog{})
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+
+	if filter.TargetType != "" {
+		query = query.Where("target_type = ?", filter.TargetType)
+	}
+
+	if filter.TargetID != "" {
+		query = query.Where("target_id = ?", filter.TargetID)
+	}
+
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	// The filtered query is shared by the count and the page lookup.
+	query = query.Session(&gorm.Session{})
+
+	var totalItems int64
+	if err := query.Count(&totalItems).Error; err != nil {
+		log.Error("Failed to count audit logs in database", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	var data []entities.AuditLog
+	if err := query.
+		Order("created_at DESC").
+		Offset(page.Offset).
+		Limit(page.Limit).
+		Find(&data).Error; err != nil {
+		log.Error("Failed to search audit logs in database", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	page.Data = data
+	page.TotalItems = totalItems
+	page.TotalPages = int((totalItems + int64(page.Limit) - 1) / int64(page.Limit))
+	return page, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 
+	"github.com/Bromolima/my-game-list/logger"
 	"gorm.io/gorm"
 )
 
@@ -28,7 +29,7 @@ func NewBaseRepository[T any, K any](db *gorm.DB, logger *slog.Logger) BaseRepos
 }
 
 func (r *baseRepository[T, K]) Create(ctx context.Context, entity *T) error {
-	log := r.logger.With(slog.String("func", "Create"))
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "Create"))
 
 	if err := r.db.WithContext(ctx).Create(entity).Error; err != nil {
 		log.Error("Failed to create entity in database", slog.String("error", err.Error()))
@@ -38,7 +39,7 @@ func (r *baseRepository[T, K]) Create(ctx context.Context, entity *T) error {
 }
 
 func (r *baseRepository[T, K]) Find(ctx context.Context, id K) (*T, error) {
-	log := r.logger.With(slog.String("func", "Find"))
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "Find"))
 	var entity T
 	if err := r.db.WithContext(ctx).First(&entity, id).Error; err != nil {
 		log.Error("Failed to find entity in database", slog.String("error", err.Error()))
@@ -48,7 +49,7 @@ func (r *baseRepository[T, K]) Find(ctx context.Context, id K) (*T, error) {
 }
 
 func (r *baseRepository[T, K]) Update(ctx context.Context, entity *T) error {
-	log := r.logger.With(slog.String("func", "Update"))
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "Update"))
 	if err := r.db.WithContext(ctx).Save(entity).Error; err != nil {
 		log.Error("Failed to update entity in database", slog.String("error", err.Error()))
 		return err
@@ -57,7 +58,7 @@ func (r *baseRepository[T, K]) Update(ctx context.Context, entity *T) error {
 }
 
 func (r *baseRepository[T, K]) Delete(ctx context.Context, id K) error {
-	log := r.logger.With(slog.String("func", "Delete"))
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "Delete"))
 	var entity T
 	if err := r.db.WithContext(ctx).Delete(&entity, id).Error; err != nil {
 		log.Error("Failed to delete entity from database", slog.String("error", err.Error()))
@@ -5,6 +5,7 @@ import (
 	"log/slog"
 
 	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/logger"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -13,6 +14,7 @@ import (
 type GameRepository interface {
 	BaseRepository[entities.Game, uuid.UUID]
 	Search(ctx context.Context, page *entities.Page[entities.Game], query string) (*entities.Page[entities.Game], error)
+	RecalculateRatings(ctx context.Context) (int64, error)
 }
 
 type gameRepository struct {
@@ -32,7 +34,7 @@ func NewGameRepository(db *gorm.DB, logger *slog.Logger, pageRepository PageRepo
 }
 
 func (r *gameRepository) Search(ctx context.Context, page *entities.Page[entities.Game], query string) (*entities.Page[entities.Game], error) {
-	log := r.logger.With(slog.String("func", "Search"))
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "Search"))
 
 	search := "%" + query + "%"
 	var data []entities.Game
@@ -47,3 +49,24 @@ func (r *gameRepository) Search(ctx context.Context, page *entities.Page[entitie
 	page.Data = data
 	return page, nil
 }
+
+// RecalculateRatings sets the rating of every game to the average of the
+// ratings given in list items, ignoring unrated items, and returns the number
+// of games whose rating changed.
+func (r *gameRepository) RecalculateRatings(ctx context.Context) (int64, error) {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "RecalculateRatings"))
+
+	result := r.db.WithContext(ctx).Exec(`UPDATE games SET rating = ratings.rating, updated_at = now()
+		FROM (
+			SELECT games.id, COALESCE(AVG(li.rating) FILTER (WHERE li.rating > 0), 0)::real AS rating
+			FROM games LEFT JOIN list_items li ON li.game_id = games.id
+			GROUP BY games.id
+		) AS ratings
+		WHERE games.id = ratings.id AND games.rating <> ratings.rating`)
+	if result.Error != nil {
+		log.Error("Failed to recalculate game ratings in database", slog.String("error", result.Error.Error()))
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
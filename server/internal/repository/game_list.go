@@ -5,6 +5,7 @@ import (
 	"log/slog"
 
 	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/logger"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -13,6 +14,7 @@ import (
 type GameListRepository interface {
 	BaseRepository[entities.GameList, uuid.UUID]
 	FindGamesByListID(ctx context.Context, listID uuid.UUID) ([]*entities.Game, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.GameList, error)
 }
 
 type gameListRepository struct {
@@ -30,14 +32,31 @@ func NewGameListRepository(db *gorm.DB, logger *slog.Logger) GameListRepository
 }
 
 func (r *gameListRepository) FindGamesByListID(ctx context.Context, listID uuid.UUID) ([]*entities.Game, error) {
-	log := r.logger.With(slog.String("func", "GetGamesByListID"))
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "GetGamesByListID"))
 
 	var games []*entities.Game
-	if err := r.db.Joins("JOIN list_items li ON li.game_id = games.id").
-		Where("li.list_id = ?", listID).
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN list_items li ON li.game_id = games.id").
+		Where("li.game_list_id = ?", listID).
 		Find(&games).Error; err != nil {
-		log.Error("Failed to find games by list id", "error", err.Error())
+		log.Error("Failed to find games by list id", slog.String("error", err.Error()))
+		return nil, err
 	}
 
 	return games, nil
 }
+
+func (r *gameListRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.GameList, error) {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "FindByUserID"))
+
+	var gameLists []*entities.GameList
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at").
+		Find(&gameLists).Error; err != nil {
+		log.Error("Failed to find game lists by user id", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return gameLists, nil
+}
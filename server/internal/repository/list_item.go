@@ -5,6 +5,7 @@ import (
 	"log/slog"
 
 	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/logger"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -30,7 +31,7 @@ func NewListItemRepository(db *gorm.DB, logger *slog.Logger) ListItemRepository
 }
 
 func (r *listItemRepository) Create(ctx context.Context, listItem *entities.ListItem) error {
-	log := r.logger.With(slog.String("func", "Create"))
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "Create"))
 	if err := r.db.WithContext(ctx).Create(listItem).Error; err != nil {
 		log.Error("Failed to create list item in database", slog.String("error", err.Error()))
 		return err
@@ -39,7 +40,7 @@ func (r *listItemRepository) Create(ctx context.Context, listItem *entities.List
 }
 
 func (r *listItemRepository) Find(ctx context.Context, gameID, gameListID uuid.UUID) (*entities.ListItem, error) {
-	log := r.logger.With(slog.String("func", "Find"))
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "Find"))
 	var listItem entities.ListItem
 	if err := r.db.WithContext(ctx).Where("game_id = ? AND game_list_id = ?", gameID, gameListID).First(&listItem).Error; err != nil {
 		log.Error("Failed to find list item in database", slog.String("error", err.Error()))
@@ -49,7 +50,7 @@ func (r *listItemRepository) Find(ctx context.Context, gameID, gameListID uuid.U
 }
 
 func (r *listItemRepository) Update(ctx context.Context, gameID uuid.UUID, gameListID uuid.UUID, rating float32, status string) error {
-	log := r.logger.With(slog.String("func", "Update"))
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "Update"))
 	if err := r.db.WithContext(ctx).Where("game_id = ? and game_list_id = ?", gameID, gameListID).Updates(&entities.ListItem{
 		Rating: rating,
 		Status: status,
@@ -61,7 +62,7 @@ func (r *listItemRepository) Update(ctx context.Context, gameID uuid.UUID, gameL
 }
 
 func (r *listItemRepository) Delete(ctx context.Context, gameID, gameListID uuid.UUID) error {
-	log := r.logger.With(slog.String("func", "Delete"))
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "Delete"))
 	if err := r.db.WithContext(ctx).
 		Where("game_id = ? AND game_list_id = ?", gameID, gameListID).
 		Delete(&entities.ListItem{}).Error; err != nil {
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Bromolima/my-game-list/config"
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/logger"
+	"gorm.io/gorm"
+)
+
+const (
+	LoginThrottleStorePostgres = "postgres"
+	LoginThrottleStoreMemory   = "memory"
+)
+
+//go:generate mockgen -source=login_throttle.go -destination=../../mocks/login_throttle_repository.go -package=mocks
+type LoginThrottleRepository interface {
+	Find(ctx context.Context, key string) (*entities.LoginThrottle, error)
+	RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (*entities.LoginThrottle, error)
+	Lock(ctx context.Context, key string, until time.Time) error
+	Reset(ctx context.Context, key string) error
+}
+
+// NewLoginThrottleRepository returns the store selected by
+// LOGIN_PROTECTION_STORE. The in-memory store is only suitable for a single
+// instance since the counters are not shared between replicas.
+func NewLoginThrottleRepository(db *gorm.DB, logger *slog.Logger) LoginThrottleRepository {
+	if config.Env.LoginProtection.Store == LoginThrottleStoreMemory {
+		return NewMemoryLoginThrottleRepository()
+	}
+
+	return NewPostgresLoginThrottleRepository(db, logger)
+}
+
+type postgresLoginThrottleRepository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewPostgresLoginThrottleRepository(db *gorm.DB, logger *slog.Logger) LoginThrottleRepository {
+	return &postgresLoginThrottleRepository{
+		db:     db,
+		logger: logger.With(slog.String("loginThrottle", "repository")),
+	}
+}
+
+func (r *postgresLoginThrottleRepository) Find(ctx context.Context, key string) (*entities.LoginThrottle, error) {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "Find"))
+
+	var loginThrottle entities.LoginThrottle
+	if err := r.db.WithContext(ctx).Where("key = ?", key).First(&loginThrottle).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		log.Error("Failed to find login throttle in database", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return &loginThrottle, nil
+}
+
+// RecordFailure increments the failure counter atomically, starting over when
+// the previous failure is older than the window.
+func (r *postgresLoginThrottleRepository) RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (*entities.LoginThrottle, error) {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "RecordFailure"))
+
+	var loginThrottle entities.LoginThrottle
+	if err := r.db.WithContext(ctx).Raw(`
+		INSERT INTO login_throttles (key, failures, last_failure_at)
+		VALUES (?, 1, ?)
+		ON CONFLICT (key) DO UPDATE SET
+			failures = CASE WHEN login_throttles.last_failure_at < ? THEN 1 ELSE login_throttles.failures + 1 END,
+			last_failure_at = EXCLUDED.last_failure_at
+		RETURNING *`,
+		key, at, at.Add(-window),
+	).Scan(&loginThrottle).Error; err != nil {
+		log.Error("Failed to record login failure in database", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return &loginThrottle, nil
+}
+
+func (r *postgresLoginThrottleRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "Lock"))
+
+	if err := r.db.WithContext(ctx).Model(&entities.LoginThrottle{}).
+		Where("key = ?", key).
+		Update("locked_until", until).Error; err != nil {
+		log.Error("Failed to lock login throttle in database", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresLoginThrottleRepository) Reset(ctx context.Context, key string) error {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "Reset"))
+
+	if err := r.db.WithContext(ctx).Where("key = ?", key).Delete(&entities.LoginThrottle{}).Error; err != nil {
+		log.Error("Failed to reset login throttle in database", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+const (
+	memoryLoginThrottleSweepSize = 10000
+)
+
+type memoryLoginThrottleRepository struct {
+	mu        sync.Mutex
+	throttles map[string]entities.LoginThrottle
+}
+
+func NewMemoryLoginThrottleRepository() LoginThrottleRepository {
+	return &memoryLoginThrottleRepository{
+		throttles: map[string]entities.LoginThrottle{},
+	}
+}
+
+func (r *memoryLoginThrottleRepository) Find(ctx context.Context, key string) (*entities.LoginThrottle, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	loginThrottle, ok := r.throttles[key]
+	if !ok {
+		return nil, nil
+	}
+
+	return &loginThrottle, nil
+}
+
+func (r *memoryLoginThrottleRepository) RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (*entities.LoginThrottle, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	loginThrottle, ok := r.throttles[key]
+	if !ok && len(r.throttles) >= memoryLoginThrottleSweepSize {
+		r.sweep(at, window)
+	}
+
+	if !ok || loginThrottle.LastFailureAt.Before(at.Add(-window)) {
+		loginThrottle = entities.LoginThrottle{Key: key, LockedUntil: loginThrottle.LockedUntil}
+	}
+
+	loginThrottle.Failures++
+	loginThrottle.LastFailureAt = at
+	r.throttles[key] = loginThrottle
+
+	return &loginThrottle, nil
+}
+
+func (r *memoryLoginThrottleRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if loginThrottle, ok := r.throttles[key]; ok {
+		loginThrottle.LockedUntil = &until
+		r.throttles[key] = loginThrottle
+	}
+
+	return nil
+}
+
+func (r *memoryLoginThrottleRepository) Reset(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.throttles, key)
+	return nil
+}
+
+// sweep drops the counters that are outside the window and no longer locked,
+// keeping the map bounded when many addresses fail to log in.
+func (r *memoryLoginThrottleRepository) sweep(at time.Time, window time.Duration) {
+	for key, loginThrottle := range r.throttles {
+		locked := loginThrottle.LockedUntil != nil && loginThrottle.LockedUntil.After(at)
+		if !locked && loginThrottle.LastFailureAt.Before(at.Add(-window)) {
+			delete(r.throttles, key)
+		}
+	}
+}
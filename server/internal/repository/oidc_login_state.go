@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -source=oidc_login_state.go -destination=../../mocks/oidc_login_state_repository.go -package=mocks
+type OIDCLoginStateRepository interface {
+	Create(ctx context.Context, loginState *entities.OIDCLoginState) error
+	Consume(ctx context.Context, hashedState string) (*entities.OIDCLoginState, error)
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
+
+type oidcLoginStateRepository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewOIDCLoginStateRepository(db *gorm.DB, logger *slog.Logger) OIDCLoginStateRepository {
+	return &oidcLoginStateRepository{
+		db:     db,
+		logger: logger.With(slog.String("oidcLoginState", "repository")),
+	}
+}
+
+func (r *oidcLoginStateRepository) Create(ctx context.Context, loginState *entities.OIDCLoginState) error {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "Create"))
+
+	if err := r.db.WithContext(ctx).Create(loginState).Error; err != nil {
+		log.Error("Failed to create login state in database", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// Consume deletes the state and returns it, so the same state can complete
+// at most one login even when the callback is replayed concurrently.
+func (r *oidcLoginStateRepository) Consume(ctx context.Context, hashedState string) (*entities.OIDCLoginState, error) {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "Consume"))
+
+	var loginStates []entities.OIDCLoginState
+	if err := r.db.WithContext(ctx).Clauses(clause.Returning{}).
+		Where("state = ?", hashedState).
+		Delete(&loginStates).Error; err != nil {
+		log.Error("Failed to consume login state in database", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if len(loginStates) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return &loginStates[0], nil
+}
+
+func (r *oidcLoginStateRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "DeleteExpired"))
+
+	if err := r.db.WithContext(ctx).Where("expires_at < ?", now).Delete(&entities.OIDCLoginState{}).Error; err != nil {
+		log.Error("Failed to delete expired login states from database", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=permission_grant.go -destination=../../mocks/permission_grant_repository.go -package=mocks
+type PermissionGrantRepository interface {
+	BaseRepository[entities.PermissionGrant, uuid.UUID]
+	FindAll(ctx context.Context) ([]*entities.PermissionGrant, error)
+	FindForSubject(ctx context.Context, userID uuid.UUID, resourceType entities.ResourceType, actions []entities.PermissionAction) ([]*entities.PermissionGrant, error)
+	DeleteForResource(ctx context.Context, userID uuid.UUID, resourceType entities.ResourceType, resourceID uuid.UUID) error
+}
+
+type permissionGrantRepository struct {
+	BaseRepository[entities.PermissionGrant, uuid.UUID]
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewPermissionGrantRepository(db *gorm.DB, logger *slog.Logger) PermissionGrantRepository {
+	return &permissionGrantRepository{
+		BaseRepository: NewBaseRepository[entities.PermissionGrant, uuid.UUID](db, logger),
+		db:             db,
+		logger:         logger.With(slog.String("permissionGrant", "repository")),
+	}
+}
+
+func (r *permissionGrantRepository) FindAll(ctx context.Context) ([]*entities.PermissionGrant, error) {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "FindAll"))
+
+	var grants []*entities.PermissionGrant
+	if err := r.db.WithContext(ctx).Order("created_at").Find(&grants).Error; err != nil {
+		log.Error("Failed to find permission grants in database", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return grants, nil
+}
+
+// FindForSubject returns the grants given to the user directly or through the
+// role the user currently holds.
+func (r *permissionGrantRepository) FindForSubject(ctx context.Context, userID uuid.UUID, resourceType entities.ResourceType, actions []entities.PermissionAction) ([]*entities.PermissionGrant, error) {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "FindForSubject"))
+
+	var grants []*entities.PermissionGrant
+	if err := r.db.WithContext(ctx).
+		Where("resource_type = ? AND action IN ?", resourceType, actions).
+		Where("user_id = ? OR role_id = (SELECT role_id FROM users WHERE id = ?)", userID, userID).
+		Find(&grants).Error; err != nil {
+		log.Error("Failed to find permission grants for subject in database", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return grants, nil
+}
+
+func (r *permissionGrantRepository) DeleteForResource(ctx context.Context, userID uuid.UUID, resourceType entities.ResourceType, resourceID uuid.UUID) error {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "DeleteForResource"))
+
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND resource_type = ? AND resource_id = ?", userID, resourceType, resourceID).
+		Delete(&entities.PermissionGrant{}).Error; err != nil {
+		log.Error("Failed to delete permission grants in database", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
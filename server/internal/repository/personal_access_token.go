@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=personal_access_token.go -destination=../../mocks/personal_access_token_repository.go -package=mocks
+type PersonalAccessTokenRepository interface {
+	BaseRepository[entities.PersonalAccessToken, uuid.UUID]
+	FindByToken(ctx context.Context, hashedToken string) (*entities.PersonalAccessToken, error)
+	FindActiveByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.PersonalAccessToken, error)
+	Revoke(ctx context.Context, id uuid.UUID) error
+	RevokeAllByUserID(ctx context.Context, userID uuid.UUID) error
+	UpdateLastUsed(ctx context.Context, id uuid.UUID, lastUsedAt time.Time) error
+}
+
+type personalAccessTokenRepository struct {
+	BaseRepository[entities.PersonalAccessToken, uuid.UUID]
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewPersonalAccessTokenRepository(db *gorm.DB, logger *slog.Logger) PersonalAccessTokenRepository {
+	return &personalAccessTokenRepository{
+		BaseRepository: NewBaseRepository[entities.PersonalAccessToken, uuid.UUID](db, logger),
+		db:             db,
+		logger:         logger.With(slog.String("repository", "personalAccessToken")),
+	}
+}
+
+func (r *personalAccessTokenRepository) FindByToken(ctx context.Context, hashedToken string) (*entities.PersonalAccessToken, error) {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "FindByToken"))
+
+	var personalAccessToken entities.PersonalAccessToken
+	if err := r.db.WithContext(ctx).Where("token = ?", hashedToken).First(&personalAccessToken).Error; err != nil {
+		log.Error("Failed to find personal access token in database", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return &personalAccessToken, nil
+}
+
+func (r *personalAccessTokenRepository) FindActiveByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.PersonalAccessToken, error) {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "FindActiveByUserID"))
+
+	var personalAccessTokens []*entities.PersonalAccessToken
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", userID, time.Now()).
+		Order("created_at DESC").
+		Find(&personalAccessTokens).Error; err != nil {
+		log.Error("Failed to find personal access tokens in database", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return personalAccessTokens, nil
+}
+
+func (r *personalAccessTokenRepository) Revoke(ctx context.Context, id uuid.UUID) error {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "Revoke"))
+
+	if err := r.db.WithContext(ctx).Model(&entities.PersonalAccessToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error; err != nil {
+		log.Error("Failed to revoke personal access token in database", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+func (r *personalAccessTokenRepository) RevokeAllByUserID(ctx context.Context, userID uuid.UUID) error {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "RevokeAllByUserID"))
+
+	if err := r.db.WithContext(ctx).Model(&entities.PersonalAccessToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error; err != nil {
+		log.Error("Failed to revoke user personal access tokens in database", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+func (r *personalAccessTokenRepository) UpdateLastUsed(ctx context.Context, id uuid.UUID, lastUsedAt time.Time) error {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "UpdateLastUsed"))
+
+	if err := r.db.WithContext(ctx).Model(&entities.PersonalAccessToken{}).
+		Where("id = ?", id).
+		Update("last_used_at", lastUsedAt).Error; err != nil {
+		log.Error("Failed to update personal access token usage in database", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=recovery_code.go -destination=../../mocks/recovery_code_repository.go -package=mocks
+type RecoveryCodeRepository interface {
+	ReplaceForUser(ctx context.Context, userID uuid.UUID, recoveryCodes []*entities.RecoveryCode) error
+	Consume(ctx context.Context, userID uuid.UUID, hashedCode string) (bool, error)
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
+
+type recoveryCodeRepository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewRecoveryCodeRepository(db *gorm.DB, logger *slog.Logger) RecoveryCodeRepository {
+	return &recoveryCodeRepository{
+		db:     db,
+		logger: logger.With(slog.String("recoveryCode", "repository")),
+	}
+}
+
+func (r *recoveryCodeRepository) ReplaceForUser(ctx context.Context, userID uuid.UUID, recoveryCodes []*entities.RecoveryCode) error {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "ReplaceForUser"))
+
+	if err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&entities.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(recoveryCodes).Error
+	}); err != nil {
+		log.Error("Failed to replace recovery codes in database", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+func (r *recoveryCodeRepository) Consume(ctx context.Context, userID uuid.UUID, hashedCode string) (bool, error) {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "Consume"))
+
+	result := r.db.WithContext(ctx).Model(&entities.RecoveryCode{}).
+		Where("user_id = ? AND code = ? AND used_at IS NULL", userID, hashedCode).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		log.Error("Failed to consume recovery code in database", slog.String("error", result.Error.Error()))
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (r *recoveryCodeRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "DeleteByUserID"))
+
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&entities.RecoveryCode{}).Error; err != nil {
+		log.Error("Failed to delete recovery codes from database", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
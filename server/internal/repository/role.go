@@ -5,12 +5,24 @@ import (
 	"log/slog"
 
 	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/logger"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+//go:generate mockgen -source=role.go -destination=../../mocks/role_repository.go -package=mocks
 type RoleRepository interface {
 	HasAccess(ctx context.Context, userID uuid.UUID, accessName entities.AccessType) (error, bool)
+	FindAll(ctx context.Context) ([]*entities.Role, error)
+	Find(ctx context.Context, id uint) (*entities.Role, error)
+	FindByName(ctx context.Context, name string) (*entities.Role, error)
+	Create(ctx context.Context, role *entities.Role) error
+	GrantAccess(ctx context.Context, roleID uint, accessType entities.AccessType) error
+	RevokeAccess(ctx context.Context, roleID uint, accessType entities.AccessType) error
+	AssignRole(ctx context.Context, userID uuid.UUID, roleID uint) error
+	CountUsers(ctx context.Context, roleID uint) (int64, error)
+	FindUsers(ctx context.Context, page *entities.Page[entities.User], roleID uint) (*entities.Page[entities.User], error)
 }
 
 type roleRepository struct {
@@ -26,7 +38,7 @@ func NewRoleRepository(db *gorm.DB, logger *slog.Logger) RoleRepository {
 }
 
 func (r *roleRepository) HasAccess(ctx context.Context, userID uuid.UUID, accessName entities.AccessType) (error, bool) {
-	log := r.logger.With(slog.String("func", "HasAccess"))
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "HasAccess"))
 	var count int64
 	if err := r.db.WithContext(ctx).Model(&entities.Access{}).
 		Joins("JOIN role_accesses ra ON ra.access_id = accesses.id").
@@ -39,3 +51,147 @@ func (r *roleRepository) HasAccess(ctx context.Context, userID uuid.UUID, access
 
 	return nil, count > 0
 }
+
+func (r *roleRepository) FindAll(ctx context.Context) ([]*entities.Role, error) {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "FindAll"))
+
+	var roles []*entities.Role
+	if err := r.db.WithContext(ctx).Preload("Access").Order("id").Find(&roles).Error; err != nil {
+		log.Error("Failed to find roles in database", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (r *roleRepository) Find(ctx context.Context, id uint) (*entities.Role, error) {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "Find"))
+
+	var role entities.Role
+	if err := r.db.WithContext(ctx).Preload("Access").First(&role, id).Error; err != nil {
+		log.Error("Failed to find role in database", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+func (r *roleRepository) FindByName(ctx context.Context, name string) (*entities.Role, error) {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "FindByName"))
+
+	var role entities.Role
+	if err := r.db.WithContext(ctx).Preload("Access").Where("name = ?", name).First(&role).Error; err != nil {
+		log.Error("Failed to find role by name in database", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+// Create picks the next free id itself, since the built-in roles are seeded
+// with fixed ids and the sequence of the table does not account for them.
+func (r *roleRepository) Create(ctx context.Context, role *entities.Role) error {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "Create"))
+
+	if err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("LOCK TABLE roles IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+			return err
+		}
+
+		var lastID uint
+		if err := tx.Model(&entities.Role{}).Select("COALESCE(MAX(id), 0)").Scan(&lastID).Error; err != nil {
+			return err
+		}
+
+		role.ID = lastID + 1
+		return tx.Omit("Access.*").Create(role).Error
+	}); err != nil {
+		log.Error("Failed to create role in database", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+func (r *roleRepository) GrantAccess(ctx context.Context, roleID uint, accessType entities.AccessType) error {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "GrantAccess"))
+
+	if err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var access entities.Access
+		if err := tx.Where(entities.Access{AccessType: accessType}).FirstOrCreate(&access).Error; err != nil {
+			return err
+		}
+
+		return tx.Table("role_accesses").
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(map[string]any{"role_id": roleID, "access_id": access.ID}).Error
+	}); err != nil {
+		log.Error("Failed to grant access to role in database", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+func (r *roleRepository) RevokeAccess(ctx context.Context, roleID uint, accessType entities.AccessType) error {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "RevokeAccess"))
+
+	if err := r.db.WithContext(ctx).
+		Exec("DELETE FROM role_accesses WHERE role_id = ? AND access_id IN (SELECT id FROM accesses WHERE access_type = ?)", roleID, accessType).
+		Error; err != nil {
+		log.Error("Failed to revoke access from role in database", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+func (r *roleRepository) AssignRole(ctx context.Context, userID uuid.UUID, roleID uint) error {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "AssignRole"))
+
+	if err := r.db.WithContext(ctx).Model(&entities.User{}).
+		Where("id = ?", userID).
+		Update("role_id", roleID).Error; err != nil {
+		log.Error("Failed to assign role to user in database", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+func (r *roleRepository) CountUsers(ctx context.Context, roleID uint) (int64, error) {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "CountUsers"))
+
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&entities.User{}).Where("role_id = ?", roleID).Count(&count).Error; err != nil {
+		log.Error("Failed to count role users in database", slog.String("error", err.Error()))
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *roleRepository) FindUsers(ctx context.Context, page *entities.Page[entities.User], roleID uint) (*entities.Page[entities.User], error) {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "FindUsers"))
+
+	totalItems, err := r.CountUsers(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []entities.User
+	if err := r.db.WithContext(ctx).
+		Where("role_id = ?", roleID).
+		Order("username").
+		Offset(page.Offset).
+		Limit(page.Limit).
+		Find(&data).Error; err != nil {
+		log.Error("Failed to find role users in database", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	page.Data = data
+	page.TotalItems = totalItems
+	page.TotalPages = int((totalItems + int64(page.Limit) - 1) / int64(page.Limit))
+	return page, nil
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=session.go -destination=../../mocks/session_repository.go -package=mocks
+type SessionRepository interface {
+	BaseRepository[entities.Session, uuid.UUID]
+	FindActiveByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Session, error)
+	Rotate(ctx context.Context, session *entities.Session, previousToken string) (bool, error)
+	Revoke(ctx context.Context, id uuid.UUID) error
+	RevokeAllByUserID(ctx context.Context, userID uuid.UUID) error
+	RevokeOthersByUserID(ctx context.Context, userID, keptSessionID uuid.UUID) error
+}
+
+type sessionRepository struct {
+	BaseRepository[entities.Session, uuid.UUID]
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewSessionRepository(db *gorm.DB, logger *slog.Logger) SessionRepository {
+	return &sessionRepository{
+		BaseRepository: NewBaseRepository[entities.Session, uuid.UUID](db, logger),
+		db:             db,
+		logger:         logger.With(slog.String("session", "repository")),
+	}
+}
+
+func (r *sessionRepository) FindActiveByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Session, error) {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "FindActiveByUserID"))
+
+	var sessions []*entities.Session
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("created_at DESC").
+		Find(&sessions).Error; err != nil {
+		log.Error("Failed to find active sessions in database", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func (r *sessionRepository) Rotate(ctx context.Context, session *entities.Session, previousToken string) (bool, error) {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "Rotate"))
+
+	result := r.db.WithContext(ctx).Model(&entities.Session{}).
+		Where("id = ? AND token = ? AND revoked_at IS NULL", session.ID, previousToken).
+		Updates(map[string]any{
+			"token":      session.Token,
+			"ip_adress":  session.IPAdress,
+			"user_agent": session.UserAgent,
+			"expires_at": session.ExpiresAt,
+		})
+	if result.Error != nil {
+		log.Error("Failed to rotate session token in database", slog.String("error", result.Error.Error()))
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *sessionRepository) Revoke(ctx context.Context, id uuid.UUID) error {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "Revoke"))
+
+	if err := r.db.WithContext(ctx).Model(&entities.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error; err != nil {
+		log.Error("Failed to revoke session in database", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+func (r *sessionRepository) RevokeAllByUserID(ctx context.Context, userID uuid.UUID) error {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "RevokeAllByUserID"))
+
+	if err := r.db.WithContext(ctx).Model(&entities.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error; err != nil {
+		log.Error("Failed to revoke user sessions in database", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// RevokeOthersByUserID revokes every session of the user but the one kept,
+// which is the session the request was made from.
+func (r *sessionRepository) RevokeOthersByUserID(ctx context.Context, userID, keptSessionID uuid.UUID) error {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "RevokeOthersByUserID"))
+
+	if err := r.db.WithContext(ctx).Model(&entities.Session{}).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userID, keptSessionID).
+		Update("revoked_at", time.Now()).Error; err != nil {
+		log.Error("Failed to revoke other user sessions in database", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
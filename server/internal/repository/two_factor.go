@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=two_factor.go -destination=../../mocks/two_factor_repository.go -package=mocks
+type TwoFactorRepository interface {
+	BaseRepository[entities.TwoFactor, uuid.UUID]
+	UpdateLastUsedStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+}
+
+type twoFactorRepository struct {
+	BaseRepository[entities.TwoFactor, uuid.UUID]
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewTwoFactorRepository(db *gorm.DB, logger *slog.Logger) TwoFactorRepository {
+	return &twoFactorRepository{
+		BaseRepository: NewBaseRepository[entities.TwoFactor, uuid.UUID](db, logger),
+		db:             db,
+		logger:         logger.With(slog.String("twoFactor", "repository")),
+	}
+}
+
+// UpdateLastUsedStep records the time step of an accepted code only if it is
+// newer than the last accepted one, which prevents a code from being replayed.
+func (r *twoFactorRepository) UpdateLastUsedStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "UpdateLastUsedStep"))
+
+	result := r.db.WithContext(ctx).Model(&entities.TwoFactor{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		log.Error("Failed to update two-factor last used step in database", slog.String("error", result.Error.Error()))
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
@@ -3,8 +3,10 @@ package repository
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/logger"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -14,6 +16,8 @@ type UserRepository interface {
 	BaseRepository[entities.User, uuid.UUID]
 	FindByEmail(ctx context.Context, email string) (*entities.User, error)
 	Search(ctx context.Context, page *entities.Page[entities.User], query string) (*entities.Page[entities.User], error)
+	MarkEmailVerified(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error
+	UpdateColumns(ctx context.Context, user *entities.User, columns map[string]any) error
 }
 
 type userRepository struct {
@@ -33,7 +37,7 @@ func NewUserRepository(db *gorm.DB, pageRepository PageRepository[entities.User]
 }
 
 func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entities.User, error) {
-	log := r.logger.With(slog.String("func", "FindByEmail"))
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "FindByEmail"))
 
 	var user entities.User
 	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
@@ -44,7 +48,7 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entiti
 }
 
 func (r *userRepository) Search(ctx context.Context, page *entities.Page[entities.User], query string) (*entities.Page[entities.User], error) {
-	log := r.logger.With(slog.String("func", "Search"))
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "Search"))
 
 	search := "%" + query + "%"
 	var data []entities.User
@@ -59,3 +63,31 @@ func (r *userRepository) Search(ctx context.Context, page *entities.Page[entitie
 	page.Data = data
 	return page, nil
 }
+
+// UpdateColumns only writes the given columns, leaving the role, the creation
+// date and the other columns of the user untouched.
+func (r *userRepository) UpdateColumns(ctx context.Context, user *entities.User, columns map[string]any) error {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "UpdateColumns"))
+
+	if err := r.db.WithContext(ctx).Model(user).Updates(columns).Error; err != nil {
+		log.Error("Failed to update user columns in database", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// MarkEmailVerified only verifies the user while the account still has the
+// given email, so a concurrent email change is never marked verified.
+func (r *userRepository) MarkEmailVerified(ctx context.Context, id uuid.UUID, email string, verifiedAt time.Time) error {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "MarkEmailVerified"))
+
+	if err := r.db.WithContext(ctx).Model(&entities.User{}).
+		Where("id = ? AND email = ?", id, email).
+		Updates(map[string]any{"email_verified": true, "email_verified_at": verifiedAt}).Error; err != nil {
+		log.Error("Failed to mark user email as verified in database", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=user_identity.go -destination=../../mocks/user_identity_repository.go -package=mocks
+type UserIdentityRepository interface {
+	BaseRepository[entities.UserIdentity, uuid.UUID]
+	FindByProviderSubject(ctx context.Context, provider, subject string) (*entities.UserIdentity, error)
+}
+
+type userIdentityRepository struct {
+	BaseRepository[entities.UserIdentity, uuid.UUID]
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewUserIdentityRepository(db *gorm.DB, logger *slog.Logger) UserIdentityRepository {
+	return &userIdentityRepository{
+		BaseRepository: NewBaseRepository[entities.UserIdentity, uuid.UUID](db, logger),
+		db:             db,
+		logger:         logger.With(slog.String("userIdentity", "repository")),
+	}
+}
+
+func (r *userIdentityRepository) FindByProviderSubject(ctx context.Context, provider, subject string) (*entities.UserIdentity, error) {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "FindByProviderSubject"))
+
+	var userIdentity entities.UserIdentity
+	if err := r.db.WithContext(ctx).Where("provider = ? AND subject = ?", provider, subject).First(&userIdentity).Error; err != nil {
+		log.Error("Failed to find user identity in database", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return &userIdentity, nil
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=user_token.go -destination=../../mocks/user_token_repository.go -package=mocks
+type UserTokenRepository interface {
+	BaseRepository[entities.UserToken, uuid.UUID]
+	FindByToken(ctx context.Context, hashedToken, purpose string) (*entities.UserToken, error)
+	FindLatestByUserID(ctx context.Context, userID uuid.UUID, purpose string) (*entities.UserToken, error)
+	MarkUsed(ctx context.Context, id uuid.UUID) (bool, error)
+	InvalidateByUserID(ctx context.Context, userID uuid.UUID, purpose string) error
+}
+
+type userTokenRepository struct {
+	BaseRepository[entities.UserToken, uuid.UUID]
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewUserTokenRepository(db *gorm.DB, logger *slog.Logger) UserTokenRepository {
+	return &userTokenRepository{
+		BaseRepository: NewBaseRepository[entities.UserToken, uuid.UUID](db, logger),
+		db:             db,
+		logger:         logger.With(slog.String("repository", "userToken")),
+	}
+}
+
+func (r *userTokenRepository) FindByToken(ctx context.Context, hashedToken, purpose string) (*entities.UserToken, error) {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "FindByToken"))
+
+	var userToken entities.UserToken
+	if err := r.db.WithContext(ctx).Where("token = ? AND purpose = ?", hashedToken, purpose).First(&userToken).Error; err != nil {
+		log.Error("Failed to find user token in database", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return &userToken, nil
+}
+
+func (r *userTokenRepository) FindLatestByUserID(ctx context.Context, userID uuid.UUID, purpose string) (*entities.UserToken, error) {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "FindLatestByUserID"))
+
+	var userToken entities.UserToken
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND purpose = ?", userID, purpose).
+		Order("created_at DESC").
+		First(&userToken).Error; err != nil {
+		log.Error("Failed to find latest user token in database", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return &userToken, nil
+}
+
+// MarkUsed consumes the token only if it was not used yet, so concurrent
+// requests with the same token cannot both succeed.
+func (r *userTokenRepository) MarkUsed(ctx context.Context, id uuid.UUID) (bool, error) {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "MarkUsed"))
+
+	result := r.db.WithContext(ctx).Model(&entities.UserToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		log.Error("Failed to mark user token as used in database", slog.String("error", result.Error.Error()))
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (r *userTokenRepository) InvalidateByUserID(ctx context.Context, userID uuid.UUID, purpose string) error {
+	log := logger.Scoped(ctx, r.logger).With(slog.String("func", "InvalidateByUserID"))
+
+	if err := r.db.WithContext(ctx).Model(&entities.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", time.Now()).Error; err != nil {
+		log.Error("Failed to invalidate user tokens in database", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package requestctx
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type metadataKey struct{}
+
+// Metadata describes the HTTP request a context belongs to, so that code deep
+// in the services can record who did what from where without every method
+// taking those values as parameters.
+type Metadata struct {
+	RequestID string
+	IPAddress string
+	UserAgent string
+	ActorID   uuid.UUID
+	// ActorRoleID is the role carried by the token of the actor, which the
+	// policy engine checks the global access of.
+	ActorRoleID uint
+}
+
+func WithMetadata(ctx context.Context, metadata *Metadata) context.Context {
+	return context.WithValue(ctx, metadataKey{}, metadata)
+}
+
+// MetadataFrom returns the metadata of the request, or an empty value when the
+// context does not come from an HTTP request.
+func MetadataFrom(ctx context.Context) *Metadata {
+	if metadata, ok := ctx.Value(metadataKey{}).(*Metadata); ok {
+		return metadata
+	}
+
+	return &Metadata{}
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/factory"
+	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/repository"
+	"github.com/Bromolima/my-game-list/internal/security"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const exportAuditLogPageSize = 100
+
+// AdminService holds the maintenance operations run by operators from the
+// command line. Users are looked up by email since that is what operators are
+// given in support requests.
+type AdminService interface {
+	CreateAdmin(ctx context.Context, email, username, password string) (*entities.User, bool, *resterr.RestErr)
+	ResetPassword(ctx context.Context, email, password string) *resterr.RestErr
+	RevokeSessions(ctx context.Context, email string) *resterr.RestErr
+	RecalculateGameRatings(ctx context.Context) (int64, *resterr.RestErr)
+	ExportUser(ctx context.Context, email string) (*entities.UserExport, *resterr.RestErr)
+}
+
+type adminService struct {
+	userRepository                repository.UserRepository
+	sessionRepository             repository.SessionRepository
+	personalAccessTokenRepository repository.PersonalAccessTokenRepository
+	gameRepository                repository.GameRepository
+	gameListRepository            repository.GameListRepository
+	auditLogRepository            repository.AuditLogRepository
+	auditService                  AuditService
+	logger                        *slog.Logger
+}
+
+func NewAdminService(
+	userRepository repository.UserRepository,
+	sessionRepository repository.SessionRepository,
+	personalAccessTokenRepository repository.PersonalAccessTokenRepository,
+	gameRepository repository.GameRepository,
+	gameListRepository repository.GameListRepository,
+	auditLogRepository repository.AuditLogRepository,
+	auditService AuditService,
+	logger *slog.Logger,
+) AdminService {
+	return &adminService{
+		userRepository:                userRepository,
+		sessionRepository:             sessionRepository,
+		personalAccessTokenRepository: personalAccessTokenRepository,
+		gameRepository:                gameRepository,
+		gameListRepository:            gameListRepository,
+		auditLogRepository:            auditLogRepository,
+		auditService:                  auditService,
+		logger:                        logger.With(slog.String("service", "admin")),
+	}
+}
+
+// CreateAdmin creates an administrator with a verified email, or promotes the
+// account already registered with the email, whose password is then left
+// untouched. The boolean reports whether a new account was created.
+func (s *adminService) CreateAdmin(ctx context.Context, email, username, password string) (_ *entities.User, _ bool, restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "AdminService", "CreateAdmin")
+	defer endSpan(span, &restErr)
+
+	user, err := s.userRepository.FindByEmail(ctx, email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error("Failed to find user by email", slog.String("error", err.Error()))
+		return nil, false, resterr.NewInternalServerErr("An error occurred while finding the user by email")
+	}
+
+	if user != nil {
+		if user.RoleID == entities.RoleAdminID {
+			return user, false, nil
+		}
+
+		previousRoleID := user.RoleID
+		user.RoleID = entities.RoleAdminID
+		if err := s.userRepository.Update(ctx, user); err != nil {
+			log.Error("Failed to update user role in database", slog.String("error", err.Error()))
+			return nil, false, resterr.NewInternalServerErr("An error occurred while assigning the role")
+		}
+
+		s.auditService.Record(
+			ctx,
+			entities.AuditUserRoleChanged,
+			entities.AuditTargetUser,
+			user.ID.String(),
+			map[string]any{"role_id": previousRoleID},
+			map[string]any{"role_id": user.RoleID},
+		)
+		return user, false, nil
+	}
+
+	hashedPassword, err := security.HashPassword(password)
+	if err != nil {
+		log.Error("Failed to hash password", slog.String("error", err.Error()))
+		return nil, false, resterr.NewInternalServerErr("An error occurred while hashing the password")
+	}
+
+	now := time.Now()
+	user = factory.NewUser(email, hashedPassword, username, "", entities.RoleAdminID)
+	user.EmailVerified = true
+	user.EmailVerifiedAt = &now
+	if err := s.userRepository.Create(ctx, user); err != nil {
+		log.Error("Failed to create user in database", slog.String("error", err.Error()))
+		return nil, false, resterr.NewInternalServerErr("An error occurred while creating the user")
+	}
+
+	if err := s.gameListRepository.Create(ctx, factory.NewDefaultGameList(user.ID)); err != nil {
+		log.Error("Failed to create default game list in database", slog.String("error", err.Error()))
+		return nil, false, resterr.NewInternalServerErr("An error occurred while creating the user")
+	}
+
+	s.auditService.Record(
+		ctx,
+		entities.AuditUserRoleChanged,
+		entities.AuditTargetUser,
+		user.ID.String(),
+		nil,
+		map[string]any{"role_id": user.RoleID},
+	)
+	return user, true, nil
+}
+
+// ResetPassword sets a new password and signs the user out everywhere, like a
+// password reset requested by email.
+func (s *adminService) ResetPassword(ctx context.Context, email, password string) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "AdminService", "ResetPassword")
+	defer endSpan(span, &restErr)
+
+	user, restErr := s.findUserByEmail(ctx, email)
+	if restErr != nil {
+		return restErr
+	}
+
+	hashedPassword, err := security.HashPassword(password)
+	if err != nil {
+		log.Error("Failed to hash password", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while hashing the password")
+	}
+
+	user.Password = hashedPassword
+	if err := s.userRepository.Update(ctx, user); err != nil {
+		log.Error("Failed to update user password in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while resetting the password")
+	}
+
+	if err := s.sessionRepository.RevokeAllByUserID(ctx, user.ID); err != nil {
+		log.Error("Failed to revoke user sessions in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while revoking the sessions")
+	}
+
+	s.auditService.Record(ctx, entities.AuditPasswordReset, entities.AuditTargetUser, user.ID.String(), nil, nil)
+	return nil
+}
+
+func (s *adminService) RevokeSessions(ctx context.Context, email string) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "AdminService", "RevokeSessions")
+	defer endSpan(span, &restErr)
+
+	user, restErr := s.findUserByEmail(ctx, email)
+	if restErr != nil {
+		return restErr
+	}
+
+	if err := s.sessionRepository.RevokeAllByUserID(ctx, user.ID); err != nil {
+		log.Error("Failed to revoke user sessions in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while revoking the sessions")
+	}
+
+	s.auditService.Record(ctx, entities.AuditSessionsRevoked, entities.AuditTargetUser, user.ID.String(), nil, nil)
+	return nil
+}
+
+// RecalculateGameRatings rebuilds the rating shown for every game from the
+// ratings in the lists, and returns the number of games that changed.
+func (s *adminService) RecalculateGameRatings(ctx context.Context) (_ int64, restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "AdminService", "RecalculateGameRatings")
+	defer endSpan(span, &restErr)
+
+	updated, err := s.gameRepository.RecalculateRatings(ctx)
+	if err != nil {
+		log.Error("Failed to recalculate game ratings", slog.String("error", err.Error()))
+		return 0, resterr.NewInternalServerErr("An error occurred while recalculating the game ratings")
+	}
+
+	return updated, nil
+}
+
+// ExportUser gathers the profile, lists, active sessions and tokens of a user,
+// together with the audit trail of the account.
+func (s *adminService) ExportUser(ctx context.Context, email string) (_ *entities.UserExport, restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "AdminService", "ExportUser")
+	defer endSpan(span, &restErr)
+
+	user, restErr := s.findUserByEmail(ctx, email)
+	if restErr != nil {
+		return nil, restErr
+	}
+
+	gameLists, err := s.gameListRepository.FindByUserID(ctx, user.ID)
+	if err != nil {
+		log.Error("Failed to find user game lists in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while exporting the user")
+	}
+
+	userExport := &entities.UserExport{User: user}
+	for _, gameList := range gameLists {
+		games, err := s.gameListRepository.FindGamesByListID(ctx, gameList.ID)
+		if err != nil {
+			log.Error("Failed to find games of list in database", slog.String("error", err.Error()))
+			return nil, resterr.NewInternalServerErr("An error occurred while exporting the user")
+		}
+
+		userExport.GameLists = append(userExport.GameLists, &entities.GameListExport{GameList: gameList, Games: games})
+	}
+
+	if userExport.Sessions, err = s.sessionRepository.FindActiveByUserID(ctx, user.ID); err != nil {
+		log.Error("Failed to find user sessions in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while exporting the user")
+	}
+
+	if userExport.PersonalAccessTokens, err = s.personalAccessTokenRepository.FindActiveByUserID(ctx, user.ID); err != nil {
+		log.Error("Failed to find user personal access tokens in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while exporting the user")
+	}
+
+	if userExport.AuditLogs, err = s.findUserAuditLogs(ctx, user.ID); err != nil {
+		log.Error("Failed to find user audit logs in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while exporting the user")
+	}
+
+	return userExport, nil
+}
+
+func (s *adminService) findUserByEmail(ctx context.Context, email string) (*entities.User, *resterr.RestErr) {
+	log := logger.Scoped(ctx, s.logger).With(slog.String("func", "findUserByEmail"))
+
+	user, err := s.userRepository.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("The requested user was not found")
+			return nil, resterr.NewNotFoundError("The requested user was not found")
+		}
+
+		log.Error("Failed to find user by email", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while finding the user")
+	}
+
+	return user, nil
+}
+
+// findUserAuditLogs reads every page of the audit entries targeting the user.
+func (s *adminService) findUserAuditLogs(ctx context.Context, userID uuid.UUID) ([]*entities.AuditLog, error) {
+	filter := &entities.AuditLogFilter{TargetType: entities.AuditTargetUser, TargetID: userID.String()}
+
+	var auditLogs []*entities.AuditLog
+	for offset := 0; ; offset += exportAuditLogPageSize {
+		page, err := s.auditLogRepository.Search(ctx, &entities.Page[entities.AuditLog]{
+			Limit:  exportAuditLogPageSize,
+			Offset: offset,
+		}, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range page.Data {
+			auditLogs = append(auditLogs, &page.Data[i])
+		}
+
+		if len(page.Data) < exportAuditLogPageSize {
+			return auditLogs, nil
+		}
+	}
+}
@@ -0,0 +1,333 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/security"
+	"github.com/Bromolima/my-game-list/internal/service"
+	"github.com/Bromolima/my-game-list/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestAdminService_CreateAdmin(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	personalAccessTokenRepository := mocks.NewMockPersonalAccessTokenRepository(mockCtrl)
+	gameRepository := mocks.NewMockGameRepository(mockCtrl)
+	gameListRepository := mocks.NewMockGameListRepository(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	adminService := service.NewAdminService(
+		userRepository,
+		sessionRepository,
+		personalAccessTokenRepository,
+		gameRepository,
+		gameListRepository,
+		auditLogRepository,
+		auditService,
+		logger,
+	)
+
+	ctx := context.Background()
+	email := "admin@example.com"
+	password := "password@"
+
+	t.Run("should create admin successfully when email is not registered", func(t *testing.T) {
+		userRepository.EXPECT().FindByEmail(ctx, email).Return(nil, gorm.ErrRecordNotFound)
+		userRepository.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, user *entities.User) error {
+			assert.Equal(t, email, user.Email)
+			assert.Equal(t, uint(entities.RoleAdminID), user.RoleID)
+			assert.True(t, user.EmailVerified)
+			assert.True(t, security.CheckPassword(user.Password, password))
+			return nil
+		})
+		gameListRepository.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, gameList *entities.GameList) error {
+			assert.True(t, gameList.IsDefault)
+			return nil
+		})
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		user, created, err := adminService.CreateAdmin(ctx, email, "admin", password)
+
+		assert.Nil(t, err)
+		assert.True(t, created)
+		assert.Equal(t, email, user.Email)
+	})
+
+	t.Run("should promote existing user without changing the password", func(t *testing.T) {
+		existingUser := &entities.User{ID: uuid.New(), Email: email, Password: "hash", RoleID: entities.RoleUserID}
+		userRepository.EXPECT().FindByEmail(ctx, email).Return(existingUser, nil)
+		userRepository.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, user *entities.User) error {
+			assert.Equal(t, uint(entities.RoleAdminID), user.RoleID)
+			assert.Equal(t, "hash", user.Password)
+			return nil
+		})
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		_, created, err := adminService.CreateAdmin(ctx, email, "admin", password)
+
+		assert.Nil(t, err)
+		assert.False(t, created)
+	})
+
+	t.Run("should do nothing when user is already an admin", func(t *testing.T) {
+		existingUser := &entities.User{ID: uuid.New(), Email: email, RoleID: entities.RoleAdminID}
+		userRepository.EXPECT().FindByEmail(ctx, email).Return(existingUser, nil)
+
+		_, created, err := adminService.CreateAdmin(ctx, email, "admin", password)
+
+		assert.Nil(t, err)
+		assert.False(t, created)
+	})
+
+	t.Run("should return error when Create fails", func(t *testing.T) {
+		userRepository.EXPECT().FindByEmail(ctx, email).Return(nil, gorm.ErrRecordNotFound)
+		userRepository.EXPECT().Create(ctx, gomock.Any()).Return(errors.New("database error"))
+
+		_, _, err := adminService.CreateAdmin(ctx, email, "admin", password)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "An error occurred while creating the user", err.Message)
+	})
+}
+
+func TestAdminService_ResetPassword(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	personalAccessTokenRepository := mocks.NewMockPersonalAccessTokenRepository(mockCtrl)
+	gameRepository := mocks.NewMockGameRepository(mockCtrl)
+	gameListRepository := mocks.NewMockGameListRepository(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	adminService := service.NewAdminService(
+		userRepository,
+		sessionRepository,
+		personalAccessTokenRepository,
+		gameRepository,
+		gameListRepository,
+		auditLogRepository,
+		auditService,
+		logger,
+	)
+
+	ctx := context.Background()
+	password := "new-password@"
+	user := &entities.User{ID: uuid.New(), Email: "player@example.com", RoleID: entities.RoleUserID}
+
+	t.Run("should reset password and revoke sessions successfully", func(t *testing.T) {
+		userRepository.EXPECT().FindByEmail(ctx, user.Email).Return(user, nil)
+		userRepository.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, updated *entities.User) error {
+			assert.True(t, security.CheckPassword(updated.Password, password))
+			assert.Equal(t, uint(entities.RoleUserID), updated.RoleID)
+			return nil
+		})
+		sessionRepository.EXPECT().RevokeAllByUserID(ctx, user.ID).Return(nil)
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, auditLog *entities.AuditLog) error {
+			assert.Equal(t, entities.AuditPasswordReset, auditLog.Action)
+			return nil
+		})
+
+		err := adminService.ResetPassword(ctx, user.Email, password)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return not found when user does not exist", func(t *testing.T) {
+		userRepository.EXPECT().FindByEmail(ctx, user.Email).Return(nil, gorm.ErrRecordNotFound)
+
+		err := adminService.ResetPassword(ctx, user.Email, password)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The requested user was not found", err.Message)
+	})
+
+	t.Run("should return error when RevokeAllByUserID fails", func(t *testing.T) {
+		userRepository.EXPECT().FindByEmail(ctx, user.Email).Return(user, nil)
+		userRepository.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+		sessionRepository.EXPECT().RevokeAllByUserID(ctx, user.ID).Return(errors.New("database error"))
+
+		err := adminService.ResetPassword(ctx, user.Email, password)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "An error occurred while revoking the sessions", err.Message)
+	})
+}
+
+func TestAdminService_RevokeSessions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	personalAccessTokenRepository := mocks.NewMockPersonalAccessTokenRepository(mockCtrl)
+	gameRepository := mocks.NewMockGameRepository(mockCtrl)
+	gameListRepository := mocks.NewMockGameListRepository(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	adminService := service.NewAdminService(
+		userRepository,
+		sessionRepository,
+		personalAccessTokenRepository,
+		gameRepository,
+		gameListRepository,
+		auditLogRepository,
+		auditService,
+		logger,
+	)
+
+	ctx := context.Background()
+	user := &entities.User{ID: uuid.New(), Email: "player@example.com"}
+
+	t.Run("should revoke sessions successfully", func(t *testing.T) {
+		userRepository.EXPECT().FindByEmail(ctx, user.Email).Return(user, nil)
+		sessionRepository.EXPECT().RevokeAllByUserID(ctx, user.ID).Return(nil)
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, auditLog *entities.AuditLog) error {
+			assert.Equal(t, entities.AuditSessionsRevoked, auditLog.Action)
+			assert.Equal(t, user.ID.String(), auditLog.TargetID)
+			return nil
+		})
+
+		err := adminService.RevokeSessions(ctx, user.Email)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return error when FindByEmail fails", func(t *testing.T) {
+		userRepository.EXPECT().FindByEmail(ctx, user.Email).Return(nil, errors.New("database error"))
+
+		err := adminService.RevokeSessions(ctx, user.Email)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "An error occurred while finding the user", err.Message)
+	})
+}
+
+func TestAdminService_RecalculateGameRatings(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	personalAccessTokenRepository := mocks.NewMockPersonalAccessTokenRepository(mockCtrl)
+	gameRepository := mocks.NewMockGameRepository(mockCtrl)
+	gameListRepository := mocks.NewMockGameListRepository(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	adminService := service.NewAdminService(
+		userRepository,
+		sessionRepository,
+		personalAccessTokenRepository,
+		gameRepository,
+		gameListRepository,
+		auditLogRepository,
+		auditService,
+		logger,
+	)
+
+	ctx := context.Background()
+
+	t.Run("should recalculate ratings successfully", func(t *testing.T) {
+		gameRepository.EXPECT().RecalculateRatings(ctx).Return(int64(3), nil)
+
+		updated, err := adminService.RecalculateGameRatings(ctx)
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(3), updated)
+	})
+
+	t.Run("should return error when RecalculateRatings fails", func(t *testing.T) {
+		gameRepository.EXPECT().RecalculateRatings(ctx).Return(int64(0), errors.New("database error"))
+
+		_, err := adminService.RecalculateGameRatings(ctx)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "An error occurred while recalculating the game ratings", err.Message)
+	})
+}
+
+func TestAdminService_ExportUser(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	personalAccessTokenRepository := mocks.NewMockPersonalAccessTokenRepository(mockCtrl)
+	gameRepository := mocks.NewMockGameRepository(mockCtrl)
+	gameListRepository := mocks.NewMockGameListRepository(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	adminService := service.NewAdminService(
+		userRepository,
+		sessionRepository,
+		personalAccessTokenRepository,
+		gameRepository,
+		gameListRepository,
+		auditLogRepository,
+		auditService,
+		logger,
+	)
+
+	ctx := context.Background()
+	user := &entities.User{ID: uuid.New(), Email: "player@example.com"}
+	gameList := &entities.GameList{ID: uuid.New(), Name: entities.DefaultListName, UserID: user.ID}
+	games := []*entities.Game{{ID: uuid.New(), Name: "Hades"}}
+
+	t.Run("should export user successfully", func(t *testing.T) {
+		userRepository.EXPECT().FindByEmail(ctx, user.Email).Return(user, nil)
+		gameListRepository.EXPECT().FindByUserID(ctx, user.ID).Return([]*entities.GameList{gameList}, nil)
+		gameListRepository.EXPECT().FindGamesByListID(ctx, gameList.ID).Return(games, nil)
+		sessionRepository.EXPECT().FindActiveByUserID(ctx, user.ID).Return([]*entities.Session{{ID: uuid.New()}}, nil)
+		personalAccessTokenRepository.EXPECT().FindActiveByUserID(ctx, user.ID).Return(nil, nil)
+		auditLogRepository.EXPECT().Search(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, page *entities.Page[entities.AuditLog], filter *entities.AuditLogFilter) (*entities.Page[entities.AuditLog], error) {
+				assert.Equal(t, entities.AuditTargetUser, filter.TargetType)
+				assert.Equal(t, user.ID.String(), filter.TargetID)
+				page.Data = []entities.AuditLog{{ID: uuid.New(), Action: entities.AuditLoginSucceeded}}
+				return page, nil
+			},
+		)
+
+		userExport, err := adminService.ExportUser(ctx, user.Email)
+
+		assert.Nil(t, err)
+		assert.Equal(t, user, userExport.User)
+		assert.Len(t, userExport.GameLists, 1)
+		assert.Equal(t, games, userExport.GameLists[0].Games)
+		assert.Len(t, userExport.Sessions, 1)
+		assert.Len(t, userExport.AuditLogs, 1)
+	})
+
+	t.Run("should return error when FindByUserID fails", func(t *testing.T) {
+		userRepository.EXPECT().FindByEmail(ctx, user.Email).Return(user, nil)
+		gameListRepository.EXPECT().FindByUserID(ctx, user.ID).Return(nil, errors.New("database error"))
+
+		_, err := adminService.ExportUser(ctx, user.Email)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "An error occurred while exporting the user", err.Message)
+	})
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/factory"
+	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/repository"
+	"github.com/Bromolima/my-game-list/internal/requestctx"
+)
+
+type AuditService interface {
+	Record(ctx context.Context, action entities.AuditAction, targetType entities.AuditTargetType, targetID string, before, after map[string]any)
+	SearchAuditLogs(ctx context.Context, page *entities.Page[entities.AuditLog], filter *entities.AuditLogFilter) (*entities.Page[entities.AuditLog], *resterr.RestErr)
+}
+
+type auditService struct {
+	repository repository.AuditLogRepository
+	logger     *slog.Logger
+}
+
+func NewAuditService(repository repository.AuditLogRepository, logger *slog.Logger) AuditService {
+	return &auditService{
+		repository: repository,
+		logger:     logger.With(slog.String("service", "audit")),
+	}
+}
+
+// Record stores the action together with the actor, IP address and request ID
+// of the request in the context. The before and after snapshots are reduced
+// to the fields that changed. Recording is best effort: a failure is logged
+// and never fails the action being audited.
+func (s *auditService) Record(ctx context.Context, action entities.AuditAction, targetType entities.AuditTargetType, targetID string, before, after map[string]any) {
+	ctx, span, log := startSpan(ctx, s.logger, "AuditService", "Record")
+	defer span.End()
+
+	changes, err := auditChanges(before, after)
+	if err != nil {
+		log.Error("Failed to encode audit changes", slog.String("error", err.Error()))
+	}
+
+	auditLog := factory.NewAuditLog(requestctx.MetadataFrom(ctx), action, targetType, targetID, changes)
+	if err := s.repository.Create(ctx, auditLog); err != nil {
+		log.Error("Failed to record audit log",
+			slog.String("action", string(action)),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func (s *auditService) SearchAuditLogs(ctx context.Context, page *entities.Page[entities.AuditLog], filter *entities.AuditLogFilter) (_ *entities.Page[entities.AuditLog], restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "AuditService", "SearchAuditLogs")
+	defer endSpan(span, &restErr)
+
+	page, err := s.repository.Search(ctx, page, filter)
+	if err != nil {
+		log.Error("Failed to search audit logs in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while searching the audit logs")
+	}
+
+	return page, nil
+}
+
+func auditChanges(before, after map[string]any) (json.RawMessage, error) {
+	changes := make(map[string]entities.AuditChange)
+	for field, value := range after {
+		if previous, ok := before[field]; !ok || !reflect.DeepEqual(previous, value) {
+			changes[field] = entities.AuditChange{Before: before[field], After: value}
+		}
+	}
+
+	for field, value := range before {
+		if _, ok := after[field]; !ok {
+			changes[field] = entities.AuditChange{Before: value}
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(changes)
+}
@@ -0,0 +1,113 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/requestctx"
+	"github.com/Bromolima/my-game-list/internal/service"
+	"github.com/Bromolima/my-game-list/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuditService_Record(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	auditService := service.NewAuditService(auditLogRepository, logger)
+
+	actorID := uuid.New()
+	targetID := uuid.New().String()
+	ctx := requestctx.WithMetadata(context.Background(), &requestctx.Metadata{
+		RequestID: "request-id",
+		IPAddress: "127.0.0.1",
+		UserAgent: "test-agent",
+		ActorID:   actorID,
+	})
+
+	t.Run("should record only the changed fields with the request metadata", func(t *testing.T) {
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, auditLog *entities.AuditLog) error {
+			assert.Equal(t, entities.AuditGameUpdated, auditLog.Action)
+			assert.Equal(t, entities.AuditTargetGame, auditLog.TargetType)
+			assert.Equal(t, targetID, auditLog.TargetID)
+			assert.Equal(t, actorID, *auditLog.ActorID)
+			assert.Equal(t, "request-id", auditLog.RequestID)
+			assert.Equal(t, "127.0.0.1", auditLog.IPAddress)
+			assert.Equal(t, "test-agent", auditLog.UserAgent)
+
+			var changes map[string]entities.AuditChange
+			assert.NoError(t, json.Unmarshal(auditLog.Changes, &changes))
+			assert.Equal(t, map[string]entities.AuditChange{
+				"genre": {Before: "RPG", After: "Action"},
+			}, changes)
+			return nil
+		})
+
+		auditService.Record(ctx, entities.AuditGameUpdated, entities.AuditTargetGame, targetID,
+			map[string]any{"name": "The Witcher 3", "genre": "RPG"},
+			map[string]any{"name": "The Witcher 3", "genre": "Action"},
+		)
+	})
+
+	t.Run("should record without actor when request is anonymous", func(t *testing.T) {
+		auditLogRepository.EXPECT().Create(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, auditLog *entities.AuditLog) error {
+			assert.Nil(t, auditLog.ActorID)
+			assert.Nil(t, auditLog.Changes)
+			return nil
+		})
+
+		auditService.Record(context.Background(), entities.AuditLoginFailed, entities.AuditTargetUser, targetID, nil, nil)
+	})
+
+	t.Run("should not panic when Create fails", func(t *testing.T) {
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).Return(errors.New("database error"))
+
+		assert.NotPanics(t, func() {
+			auditService.Record(ctx, entities.AuditUserDeleted, entities.AuditTargetUser, targetID, map[string]any{"username": "player"}, nil)
+		})
+	})
+}
+
+func TestAuditService_SearchAuditLogs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	auditService := service.NewAuditService(auditLogRepository, logger)
+
+	ctx := context.Background()
+	page := &entities.Page[entities.AuditLog]{Limit: 10}
+	filter := &entities.AuditLogFilter{Action: entities.AuditRoleCreated}
+
+	t.Run("should search audit logs successfully", func(t *testing.T) {
+		expectedPage := &entities.Page[entities.AuditLog]{Limit: 10, TotalItems: 1, Data: []entities.AuditLog{{Action: entities.AuditRoleCreated}}}
+		auditLogRepository.EXPECT().Search(ctx, page, filter).Return(expectedPage, nil)
+
+		result, err := auditService.SearchAuditLogs(ctx, page, filter)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedPage, result)
+	})
+
+	t.Run("should return error when Search fails", func(t *testing.T) {
+		auditLogRepository.EXPECT().Search(ctx, page, filter).Return(nil, errors.New("database error"))
+
+		result, err := auditService.SearchAuditLogs(ctx, page, filter)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "An error occurred while searching the audit logs", err.Message)
+	})
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/policy"
+	"github.com/google/uuid"
+)
+
+// authorize asks the policy engine whether the user may perform the action and
+// turns a denial into a forbidden error carrying the given message.
+func authorize(
+	ctx context.Context,
+	log *slog.Logger,
+	policyEngine policy.Engine,
+	userID uuid.UUID,
+	action entities.PermissionAction,
+	resource policy.Resource,
+	message string,
+) *resterr.RestErr {
+	allowed, err := policyEngine.Authorize(ctx, userID, action, resource)
+	if err != nil {
+		log.Error("Failed to evaluate permissions", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while checking permissions")
+	}
+
+	if !allowed {
+		log.Warn("Permission denied",
+			slog.String("action", string(action)),
+			slog.String("resource", string(resource.Type)),
+		)
+		return resterr.NewForbiddenError(message)
+	}
+
+	return nil
+}
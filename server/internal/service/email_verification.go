@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/Bromolima/my-game-list/config"
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/factory"
+	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/mailer"
+	"github.com/Bromolima/my-game-list/internal/repository"
+	"github.com/Bromolima/my-game-list/internal/security"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	emailVerificationTokenSize = 32
+)
+
+type EmailVerificationService interface {
+	ConfirmEmail(ctx context.Context, verificationToken string) *resterr.RestErr
+	ResendVerification(ctx context.Context, userID uuid.UUID) *resterr.RestErr
+	RequireVerifiedEmail(ctx context.Context, userID uuid.UUID, action entities.VerifiedAction) *resterr.RestErr
+}
+
+type emailVerificationService struct {
+	userRepository      repository.UserRepository
+	userTokenRepository repository.UserTokenRepository
+	mailer              mailer.Mailer
+	logger              *slog.Logger
+}
+
+func NewEmailVerificationService(
+	userRepository repository.UserRepository,
+	userTokenRepository repository.UserTokenRepository,
+	mailer mailer.Mailer,
+	logger *slog.Logger,
+) EmailVerificationService {
+	return &emailVerificationService{
+		userRepository:      userRepository,
+		userTokenRepository: userTokenRepository,
+		mailer:              mailer,
+		logger:              logger.With(slog.String("service", "emailVerification")),
+	}
+}
+
+func (s *emailVerificationService) ConfirmEmail(ctx context.Context, verificationToken string) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "EmailVerificationService", "ConfirmEmail")
+	defer endSpan(span, &restErr)
+
+	userToken, err := s.userTokenRepository.FindByToken(ctx, security.HashToken(verificationToken), entities.UserTokenPurposeEmailVerification)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("Unknown verification token provided")
+			return resterr.NewBadRequestError("The verification token is invalid or has expired")
+		}
+
+		log.Error("Failed to find verification token in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while verifying the email")
+	}
+
+	if userToken.UsedAt != nil || time.Now().After(userToken.ExpiresAt) {
+		log.Warn("Used or expired verification token provided")
+		return resterr.NewBadRequestError("The verification token is invalid or has expired")
+	}
+
+	user, err := s.userRepository.Find(ctx, userToken.UserID)
+	if err != nil {
+		log.Error("Failed to find user in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while verifying the email")
+	}
+
+	// The token only proves ownership of the address it was sent to, which is
+	// no longer the account email once the user changed it.
+	if userToken.Email != user.Email {
+		log.Warn("Verification token sent to a previous email provided")
+		return resterr.NewBadRequestError("The verification token is invalid or has expired")
+	}
+
+	used, err := s.userTokenRepository.MarkUsed(ctx, userToken.ID)
+	if err != nil {
+		log.Error("Failed to mark verification token as used in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while verifying the email")
+	}
+
+	if !used {
+		log.Warn("Verification token was used concurrently")
+		return resterr.NewBadRequestError("The verification token is invalid or has expired")
+	}
+
+	if err := s.userRepository.MarkEmailVerified(ctx, userToken.UserID, userToken.Email, time.Now()); err != nil {
+		log.Error("Failed to mark email as verified in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while verifying the email")
+	}
+
+	return nil
+}
+
+func (s *emailVerificationService) ResendVerification(ctx context.Context, userID uuid.UUID) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "EmailVerificationService", "ResendVerification")
+	defer endSpan(span, &restErr)
+
+	user, err := s.userRepository.Find(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("The requested user was not found")
+			return resterr.NewNotFoundError("The requested user was not found")
+		}
+
+		log.Error("Failed to find user in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while finding the user")
+	}
+
+	if user.EmailVerified {
+		log.Warn("Verification resent for an already verified email")
+		return resterr.NewBadRequestError("The email address is already verified")
+	}
+
+	lastToken, err := s.userTokenRepository.FindLatestByUserID(ctx, userID, entities.UserTokenPurposeEmailVerification)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error("Failed to find latest verification token in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while sending the verification email")
+	}
+
+	if lastToken != nil && time.Since(lastToken.CreatedAt) < config.Env.EmailVerification.ResendCooldown {
+		log.Warn("Verification email resent too soon")
+		return resterr.NewTooManyRequestsError("A verification email was sent recently, please try again later")
+	}
+
+	if err := sendEmailVerification(ctx, s.userTokenRepository, s.mailer, user); err != nil {
+		log.Error("Failed to send verification email", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while sending the verification email")
+	}
+
+	return nil
+}
+
+// RequireVerifiedEmail applies the configured verification policy, only
+// rejecting unverified users for the actions listed in
+// EMAIL_VERIFICATION_REQUIRED_FOR.
+func (s *emailVerificationService) RequireVerifiedEmail(ctx context.Context, userID uuid.UUID, action entities.VerifiedAction) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "EmailVerificationService", "RequireVerifiedEmail")
+	defer endSpan(span, &restErr)
+
+	if !slices.Contains(config.Env.EmailVerification.RequiredFor, string(action)) {
+		return nil
+	}
+
+	user, err := s.userRepository.Find(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("The requested user was not found")
+			return resterr.NewUnauthorizedError("The requested user was not found")
+		}
+
+		log.Error("Failed to find user in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while finding the user")
+	}
+
+	if !user.EmailVerified {
+		log.Warn("Unverified user attempted a restricted action", slog.String("action", string(action)))
+		return resterr.NewForbiddenError("A verified email address is required for this action")
+	}
+
+	return nil
+}
+
+func sendEmailVerification(ctx context.Context, userTokenRepository repository.UserTokenRepository, mailSender mailer.Mailer, user *entities.User) error {
+	if err := userTokenRepository.InvalidateByUserID(ctx, user.ID, entities.UserTokenPurposeEmailVerification); err != nil {
+		return err
+	}
+
+	verificationToken, err := security.GenerateRandomToken(emailVerificationTokenSize)
+	if err != nil {
+		return err
+	}
+
+	userToken := factory.NewUserToken(
+		user.ID,
+		user.Email,
+		entities.UserTokenPurposeEmailVerification,
+		security.HashToken(verificationToken),
+		time.Now().Add(config.Env.EmailVerification.TokenDuration),
+	)
+	if err := userTokenRepository.Create(ctx, userToken); err != nil {
+		return err
+	}
+
+	verificationURL := config.Env.AppURL + "/verify-email?token=" + url.QueryEscape(verificationToken)
+	return mailSender.Send(ctx, mailer.NewEmailVerificationMessage(user.Email, verificationURL, config.Env.EmailVerification.TokenDuration))
+}
@@ -0,0 +1,208 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Bromolima/my-game-list/config"
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/security"
+	"github.com/Bromolima/my-game-list/internal/service"
+	"github.com/Bromolima/my-game-list/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestEmailVerificationService_ConfirmEmail(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	userTokenRepository := mocks.NewMockUserTokenRepository(mockCtrl)
+	mailSender := mocks.NewMockMailer(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	emailVerificationService := service.NewEmailVerificationService(userRepository, userTokenRepository, mailSender, logger)
+
+	ctx := context.Background()
+	verificationToken := "verification-token"
+	hashedToken := security.HashToken(verificationToken)
+	userToken := &entities.UserToken{
+		ID:        uuid.New(),
+		Purpose:   entities.UserTokenPurposeEmailVerification,
+		Token:     hashedToken,
+		Email:     "user@example.com",
+		ExpiresAt: time.Now().Add(time.Hour),
+		UserID:    uuid.New(),
+	}
+	user := &entities.User{ID: userToken.UserID, Email: userToken.Email}
+
+	t.Run("should confirm email successfully", func(t *testing.T) {
+		userTokenRepository.EXPECT().FindByToken(ctx, hashedToken, entities.UserTokenPurposeEmailVerification).Return(userToken, nil)
+		userRepository.EXPECT().Find(ctx, userToken.UserID).Return(user, nil)
+		userTokenRepository.EXPECT().MarkUsed(ctx, userToken.ID).Return(true, nil)
+		userRepository.EXPECT().MarkEmailVerified(ctx, userToken.UserID, userToken.Email, gomock.Any()).Return(nil)
+
+		err := emailVerificationService.ConfirmEmail(ctx, verificationToken)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return error when token is unknown", func(t *testing.T) {
+		userTokenRepository.EXPECT().FindByToken(ctx, hashedToken, entities.UserTokenPurposeEmailVerification).Return(nil, gorm.ErrRecordNotFound)
+
+		err := emailVerificationService.ConfirmEmail(ctx, verificationToken)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The verification token is invalid or has expired", err.Message)
+	})
+
+	t.Run("should return error when token has expired", func(t *testing.T) {
+		userTokenRepository.EXPECT().FindByToken(ctx, hashedToken, entities.UserTokenPurposeEmailVerification).Return(&entities.UserToken{
+			ID:        userToken.ID,
+			ExpiresAt: time.Now().Add(-time.Minute),
+			UserID:    userToken.UserID,
+		}, nil)
+
+		err := emailVerificationService.ConfirmEmail(ctx, verificationToken)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The verification token is invalid or has expired", err.Message)
+	})
+
+	t.Run("should return error when token was sent to a previous email", func(t *testing.T) {
+		userTokenRepository.EXPECT().FindByToken(ctx, hashedToken, entities.UserTokenPurposeEmailVerification).Return(userToken, nil)
+		userRepository.EXPECT().Find(ctx, userToken.UserID).Return(&entities.User{ID: userToken.UserID, Email: "victim@example.com"}, nil)
+
+		err := emailVerificationService.ConfirmEmail(ctx, verificationToken)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The verification token is invalid or has expired", err.Message)
+	})
+
+	t.Run("should return error when MarkEmailVerified fails", func(t *testing.T) {
+		userTokenRepository.EXPECT().FindByToken(ctx, hashedToken, entities.UserTokenPurposeEmailVerification).Return(userToken, nil)
+		userRepository.EXPECT().Find(ctx, userToken.UserID).Return(user, nil)
+		userTokenRepository.EXPECT().MarkUsed(ctx, userToken.ID).Return(true, nil)
+		userRepository.EXPECT().MarkEmailVerified(ctx, userToken.UserID, userToken.Email, gomock.Any()).Return(errors.New("database error"))
+
+		err := emailVerificationService.ConfirmEmail(ctx, verificationToken)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "An error occurred while verifying the email", err.Message)
+	})
+}
+
+func TestEmailVerificationService_ResendVerification(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	userTokenRepository := mocks.NewMockUserTokenRepository(mockCtrl)
+	mailSender := mocks.NewMockMailer(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	emailVerificationService := service.NewEmailVerificationService(userRepository, userTokenRepository, mailSender, logger)
+
+	config.Env.EmailVerification.ResendCooldown = 5 * time.Minute
+
+	ctx := context.Background()
+	user := &entities.User{ID: uuid.New(), Email: "player@example.com"}
+
+	t.Run("should resend verification successfully", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		userTokenRepository.EXPECT().FindLatestByUserID(ctx, user.ID, entities.UserTokenPurposeEmailVerification).Return(&entities.UserToken{
+			CreatedAt: time.Now().Add(-time.Hour),
+		}, nil)
+		userTokenRepository.EXPECT().InvalidateByUserID(ctx, user.ID, entities.UserTokenPurposeEmailVerification).Return(nil)
+		userTokenRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		mailSender.EXPECT().Send(ctx, gomock.Any()).Return(nil)
+
+		err := emailVerificationService.ResendVerification(ctx, user.ID)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return error when email is already verified", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, user.ID).Return(&entities.User{ID: user.ID, EmailVerified: true}, nil)
+
+		err := emailVerificationService.ResendVerification(ctx, user.ID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The email address is already verified", err.Message)
+	})
+
+	t.Run("should return error when resent within the cooldown", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		userTokenRepository.EXPECT().FindLatestByUserID(ctx, user.ID, entities.UserTokenPurposeEmailVerification).Return(&entities.UserToken{
+			CreatedAt: time.Now().Add(-time.Minute),
+		}, nil)
+
+		err := emailVerificationService.ResendVerification(ctx, user.ID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "A verification email was sent recently, please try again later", err.Message)
+	})
+
+	t.Run("should return error when Send fails", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		userTokenRepository.EXPECT().FindLatestByUserID(ctx, user.ID, entities.UserTokenPurposeEmailVerification).Return(nil, gorm.ErrRecordNotFound)
+		userTokenRepository.EXPECT().InvalidateByUserID(ctx, user.ID, entities.UserTokenPurposeEmailVerification).Return(nil)
+		userTokenRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		mailSender.EXPECT().Send(ctx, gomock.Any()).Return(errors.New("smtp error"))
+
+		err := emailVerificationService.ResendVerification(ctx, user.ID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "An error occurred while sending the verification email", err.Message)
+	})
+}
+
+func TestEmailVerificationService_RequireVerifiedEmail(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	userTokenRepository := mocks.NewMockUserTokenRepository(mockCtrl)
+	mailSender := mocks.NewMockMailer(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	emailVerificationService := service.NewEmailVerificationService(userRepository, userTokenRepository, mailSender, logger)
+
+	config.Env.EmailVerification.RequiredFor = []string{string(entities.VerifiedActionCreateList)}
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("should allow verified user", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, userID).Return(&entities.User{ID: userID, EmailVerified: true}, nil)
+
+		err := emailVerificationService.RequireVerifiedEmail(ctx, userID, entities.VerifiedActionCreateList)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should allow unverified user when action is not restricted", func(t *testing.T) {
+		config.Env.EmailVerification.RequiredFor = []string{}
+		defer func() { config.Env.EmailVerification.RequiredFor = []string{string(entities.VerifiedActionCreateList)} }()
+
+		err := emailVerificationService.RequireVerifiedEmail(ctx, userID, entities.VerifiedActionCreateList)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return error when user is not verified", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, userID).Return(&entities.User{ID: userID}, nil)
+
+		err := emailVerificationService.RequireVerifiedEmail(ctx, userID, entities.VerifiedActionCreateList)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "A verified email address is required for this action", err.Message)
+	})
+}
@@ -8,44 +8,56 @@ import (
 	"github.com/Bromolima/my-game-list/internal/entities"
 	"github.com/Bromolima/my-game-list/internal/factory"
 	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/policy"
 	"github.com/Bromolima/my-game-list/internal/repository"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type GameService interface {
-	CreateGame(ctx context.Context, name, genre, developer, description, imageURL string) *resterr.RestErr
+	CreateGame(ctx context.Context, userID uuid.UUID, name, genre, developer, description, imageURL string) *resterr.RestErr
 	FindGame(ctx context.Context, id uuid.UUID) (*entities.Game, *resterr.RestErr)
 	SearchGames(ctx context.Context, page *entities.Page[entities.Game], query string) (*entities.Page[entities.Game], *resterr.RestErr)
-	UpdateGame(ctx context.Context, id uuid.UUID, name, genre, developer, description, imageURL string) *resterr.RestErr
-	DeleteGame(ctx context.Context, id string) *resterr.RestErr
+	UpdateGame(ctx context.Context, userID, id uuid.UUID, name, genre, developer, description, imageURL string) *resterr.RestErr
+	DeleteGame(ctx context.Context, userID uuid.UUID, id string) *resterr.RestErr
 }
 
 type gameService struct {
-	repository repository.GameRepository
-	logger     *slog.Logger
+	repository   repository.GameRepository
+	policyEngine policy.Engine
+	auditService AuditService
+	logger       *slog.Logger
 }
 
-func NewGameService(repository repository.GameRepository, logger *slog.Logger) GameService {
+func NewGameService(repository repository.GameRepository, policyEngine policy.Engine, auditService AuditService, logger *slog.Logger) GameService {
 	return &gameService{
-		repository: repository,
-		logger:     logger.With(slog.String("service", "game")),
+		repository:   repository,
+		policyEngine: policyEngine,
+		auditService: auditService,
+		logger:       logger.With(slog.String("service", "game")),
 	}
 }
 
-func (s *gameService) CreateGame(ctx context.Context, name, genre, developer, description, imageURL string) *resterr.RestErr {
-	log := s.logger.With(slog.String("func", "CreateGame"))
+func (s *gameService) CreateGame(ctx context.Context, userID uuid.UUID, name, genre, developer, description, imageURL string) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "GameService", "CreateGame")
+	defer endSpan(span, &restErr)
 	game := factory.NewGame(name, genre, developer, description, imageURL)
+	if restErr := authorize(ctx, log, s.policyEngine, userID, entities.PermissionCreate, policy.NewGameResource(game), "You do not have permission to create this game"); restErr != nil {
+		return restErr
+	}
+
 	if err := s.repository.Create(ctx, game); err != nil {
 		log.Error("Failed to create game in database", slog.String("error", err.Error()))
 		return resterr.NewInternalServerErr("An error occurred while creating the game")
 	}
 
+	s.auditService.Record(ctx, entities.AuditGameCreated, entities.AuditTargetGame, game.ID.String(), nil, factory.NewAuditSnapshotFromGame(game))
 	return nil
 }
 
-func (s *gameService) FindGame(ctx context.Context, id uuid.UUID) (*entities.Game, *resterr.RestErr) {
-	log := s.logger.With(slog.String("func", "FindGame"))
+func (s *gameService) FindGame(ctx context.Context, id uuid.UUID) (_ *entities.Game, restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "GameService", "FindGame")
+	defer endSpan(span, &restErr)
 
 	game, err := s.repository.Find(ctx, id)
 	if err != nil {
@@ -61,8 +73,9 @@ func (s *gameService) FindGame(ctx context.Context, id uuid.UUID) (*entities.Gam
 	return game, nil
 }
 
-func (s *gameService) SearchGames(ctx context.Context, page *entities.Page[entities.Game], query string) (*entities.Page[entities.Game], *resterr.RestErr) {
-	log := s.logger.With(slog.String("func", "SearchGames"))
+func (s *gameService) SearchGames(ctx context.Context, page *entities.Page[entities.Game], query string) (_ *entities.Page[entities.Game], restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "GameService", "SearchGames")
+	defer endSpan(span, &restErr)
 
 	page, err := s.repository.Search(ctx, page, query)
 	if err != nil {
@@ -78,10 +91,11 @@ func (s *gameService) SearchGames(ctx context.Context, page *entities.Page[entit
 	return page, nil
 }
 
-func (s *gameService) UpdateGame(ctx context.Context, id uuid.UUID, name, genre, developer, description, imageURL string) *resterr.RestErr {
-	log := s.logger.With(slog.String("func", "UpdateGame"))
+func (s *gameService) UpdateGame(ctx context.Context, userID, id uuid.UUID, name, genre, developer, description, imageURL string) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "GameService", "UpdateGame")
+	defer endSpan(span, &restErr)
 
-	_, err := s.repository.Find(ctx, id)
+	currentGame, err := s.repository.Find(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			log.Warn("The requested game was not found")
@@ -91,17 +105,36 @@ func (s *gameService) UpdateGame(ctx context.Context, id uuid.UUID, name, genre,
 		log.Error("Failed to find game in database", slog.String("error", err.Error()))
 		return resterr.NewInternalServerErr("An error occurred while finding the game")
 	}
+
+	if restErr := authorize(ctx, log, s.policyEngine, userID, entities.PermissionEdit, policy.NewGameResource(currentGame), "You do not have permission to edit this game"); restErr != nil {
+		return restErr
+	}
+
+	// A curator may not move a game into a genre outside of their grants.
 	game := factory.NewGameUpdate(id, name, genre, developer, description, imageURL)
+	if restErr := authorize(ctx, log, s.policyEngine, userID, entities.PermissionEdit, policy.NewGameResource(game), "You do not have permission to edit this game"); restErr != nil {
+		return restErr
+	}
+
 	if err := s.repository.Update(ctx, game); err != nil {
 		log.Error("Failed to update game in database", slog.String("error", err.Error()))
 		return resterr.NewInternalServerErr("An error occurred while updating the game")
 	}
 
+	s.auditService.Record(
+		ctx,
+		entities.AuditGameUpdated,
+		entities.AuditTargetGame,
+		id.String(),
+		factory.NewAuditSnapshotFromGame(currentGame),
+		factory.NewAuditSnapshotFromGame(game),
+	)
 	return nil
 }
 
-func (s *gameService) DeleteGame(ctx context.Context, id string) *resterr.RestErr {
-	log := s.logger.With(slog.String("func", "DeleteGame"))
+func (s *gameService) DeleteGame(ctx context.Context, userID uuid.UUID, id string) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "GameService", "DeleteGame")
+	defer endSpan(span, &restErr)
 
 	uuid, err := uuid.Parse(id)
 	if err != nil {
@@ -109,7 +142,7 @@ func (s *gameService) DeleteGame(ctx context.Context, id string) *resterr.RestEr
 		return resterr.NewInternalServerErr("An error occurred while parsing the UUID")
 	}
 
-	_, err = s.repository.Find(ctx, uuid)
+	game, err := s.repository.Find(ctx, uuid)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			log.Warn("The requested game was not found")
@@ -120,10 +153,15 @@ func (s *gameService) DeleteGame(ctx context.Context, id string) *resterr.RestEr
 		return resterr.NewInternalServerErr("An error occurred while finding the game")
 	}
 
+	if restErr := authorize(ctx, log, s.policyEngine, userID, entities.PermissionDelete, policy.NewGameResource(game), "You do not have permission to delete this game"); restErr != nil {
+		return restErr
+	}
+
 	if err := s.repository.Delete(ctx, uuid); err != nil {
 		log.Error("Failed to delete game from database", slog.String("error", err.Error()))
 		return resterr.NewInternalServerErr("An error occurred while deleting the game")
 	}
 
+	s.auditService.Record(ctx, entities.AuditGameDeleted, entities.AuditTargetGame, id, factory.NewAuditSnapshotFromGame(game), nil)
 	return nil
 }
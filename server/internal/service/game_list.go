@@ -8,34 +8,49 @@ import (
 	"github.com/Bromolima/my-game-list/internal/entities"
 	"github.com/Bromolima/my-game-list/internal/factory"
 	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/policy"
 	"github.com/Bromolima/my-game-list/internal/repository"
+	"github.com/Bromolima/my-game-list/logger"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type GameListService interface {
 	CreateGameList(ctx context.Context, userID uuid.UUID, name string, isPublic, isDefault bool) *resterr.RestErr
-	FindGamesFromList(ctx context.Context, gameListID uuid.UUID) ([]*entities.Game, *resterr.RestErr)
+	FindGamesFromList(ctx context.Context, userID, gameListID uuid.UUID) ([]*entities.Game, *resterr.RestErr)
 	UpdateGameList(ctx context.Context, userID, gameListID uuid.UUID, name string, isPublic bool) *resterr.RestErr
 	DeleteGameList(ctx context.Context, gameListID, userID uuid.UUID) *resterr.RestErr
+	AddCollaborator(ctx context.Context, userID, gameListID, collaboratorID uuid.UUID) *resterr.RestErr
+	RemoveCollaborator(ctx context.Context, userID, gameListID, collaboratorID uuid.UUID) *resterr.RestErr
 }
 
 type gameListService struct {
-	gameListRepo repository.GameListRepository
-	userRepo     repository.UserRepository
-	logger       *slog.Logger
+	gameListRepo        repository.GameListRepository
+	userRepo            repository.UserRepository
+	permissionGrantRepo repository.PermissionGrantRepository
+	policyEngine        policy.Engine
+	logger              *slog.Logger
 }
 
-func NewGameListService(gameListRepo repository.GameListRepository, userRepo repository.UserRepository, logger *slog.Logger) GameListService {
+func NewGameListService(
+	gameListRepo repository.GameListRepository,
+	userRepo repository.UserRepository,
+	permissionGrantRepo repository.PermissionGrantRepository,
+	policyEngine policy.Engine,
+	logger *slog.Logger,
+) GameListService {
 	return &gameListService{
-		gameListRepo: gameListRepo,
-		userRepo:     userRepo,
-		logger:       logger.With(slog.String("service", "gameList")),
+		gameListRepo:        gameListRepo,
+		userRepo:            userRepo,
+		permissionGrantRepo: permissionGrantRepo,
+		policyEngine:        policyEngine,
+		logger:              logger.With(slog.String("service", "gameList")),
 	}
 }
 
-func (s *gameListService) CreateGameList(ctx context.Context, userID uuid.UUID, name string, isPublic, isDefault bool) *resterr.RestErr {
-	log := s.logger.With(slog.String("func", "CreateGameList"))
+func (s *gameListService) CreateGameList(ctx context.Context, userID uuid.UUID, name string, isPublic, isDefault bool) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "GameListService", "CreateGameList")
+	defer endSpan(span, &restErr)
 
 	_, err := s.userRepo.Find(ctx, userID)
 	if err != nil {
@@ -60,8 +75,18 @@ func (s *gameListService) CreateGameList(ctx context.Context, userID uuid.UUID,
 	return nil
 }
 
-func (s *gameListService) FindGamesFromList(ctx context.Context, gameListID uuid.UUID) ([]*entities.Game, *resterr.RestErr) {
-	log := s.logger.With(slog.String("func", "FindGamesByList"))
+func (s *gameListService) FindGamesFromList(ctx context.Context, userID, gameListID uuid.UUID) (_ []*entities.Game, restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "GameListService", "FindGamesFromList")
+	defer endSpan(span, &restErr)
+
+	gameList, restErr := s.findGameList(ctx, gameListID)
+	if restErr != nil {
+		return nil, restErr
+	}
+
+	if restErr := authorize(ctx, log, s.policyEngine, userID, entities.PermissionView, policy.NewGameListResource(gameList), "You do not have permission to view this list"); restErr != nil {
+		return nil, restErr
+	}
 
 	games, err := s.gameListRepo.FindGamesByListID(ctx, gameListID)
 	if err != nil {
@@ -77,8 +102,9 @@ func (s *gameListService) FindGamesFromList(ctx context.Context, gameListID uuid
 	return games, nil
 }
 
-func (s *gameListService) UpdateGameList(ctx context.Context, userID, gameListID uuid.UUID, name string, isPublic bool) *resterr.RestErr {
-	log := s.logger.With(slog.String("func", "UpdateGameList"))
+func (s *gameListService) UpdateGameList(ctx context.Context, userID, gameListID uuid.UUID, name string, isPublic bool) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "GameListService", "UpdateGameList")
+	defer endSpan(span, &restErr)
 
 	_, err := s.userRepo.Find(ctx, userID)
 	if err != nil {
@@ -93,7 +119,17 @@ func (s *gameListService) UpdateGameList(ctx context.Context, userID, gameListID
 		return restErr
 	}
 
-	gameList := factory.NewGameListUpdate(gameListID, userID, name, isPublic)
+	gameList, restErr := s.findGameList(ctx, gameListID)
+	if restErr != nil {
+		return restErr
+	}
+
+	if restErr := authorize(ctx, log, s.policyEngine, userID, entities.PermissionEdit, policy.NewGameListResource(gameList), "You do not have permission to modify this list"); restErr != nil {
+		return restErr
+	}
+
+	gameList.Name = name
+	gameList.IsPublic = isPublic
 	if err := s.gameListRepo.Update(ctx, gameList); err != nil {
 		log.Error("Failed to update game list in database", slog.String("error", err.Error()))
 		restErr := resterr.NewInternalServerErr("Failed to create list due to internal error")
@@ -103,8 +139,9 @@ func (s *gameListService) UpdateGameList(ctx context.Context, userID, gameListID
 	return nil
 }
 
-func (s *gameListService) DeleteGameList(ctx context.Context, gameListID, userID uuid.UUID) *resterr.RestErr {
-	log := s.logger.With(slog.String("func", "DeleteGameList"))
+func (s *gameListService) DeleteGameList(ctx context.Context, gameListID, userID uuid.UUID) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "GameListService", "DeleteGameList")
+	defer endSpan(span, &restErr)
 
 	_, err := s.userRepo.Find(ctx, userID)
 	if err != nil {
@@ -119,16 +156,12 @@ func (s *gameListService) DeleteGameList(ctx context.Context, gameListID, userID
 		return restErr
 	}
 
-	_, err = s.gameListRepo.Find(ctx, gameListID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			log.Warn("Game list not found in database")
-			restErr := resterr.NewNotFoundError("Game list does not exists")
-			return restErr
-		}
+	gameList, restErr := s.findGameList(ctx, gameListID)
+	if restErr != nil {
+		return restErr
+	}
 
-		log.Error("Failed to find game list in database", slog.String("error", err.Error()))
-		restErr := resterr.NewInternalServerErr("Failed to search for game list due to internal error")
+	if restErr := authorize(ctx, log, s.policyEngine, userID, entities.PermissionDelete, policy.NewGameListResource(gameList), "You do not have permission to delete this list"); restErr != nil {
 		return restErr
 	}
 
@@ -140,3 +173,94 @@ func (s *gameListService) DeleteGameList(ctx context.Context, gameListID, userID
 
 	return nil
 }
+
+// AddCollaborator grants another user the right to edit the list, which also
+// lets them view it while it is private.
+func (s *gameListService) AddCollaborator(ctx context.Context, userID, gameListID, collaboratorID uuid.UUID) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "GameListService", "AddCollaborator")
+	defer endSpan(span, &restErr)
+
+	gameList, restErr := s.findGameList(ctx, gameListID)
+	if restErr != nil {
+		return restErr
+	}
+
+	if restErr := authorize(ctx, log, s.policyEngine, userID, entities.PermissionShare, policy.NewGameListResource(gameList), "Only the owner of the list can manage its collaborators"); restErr != nil {
+		return restErr
+	}
+
+	if collaboratorID == gameList.UserID {
+		log.Warn("Attempt to add the owner of the list as a collaborator")
+		return resterr.NewBadRequestError("The owner of the list cannot be added as a collaborator")
+	}
+
+	_, err := s.userRepo.Find(ctx, collaboratorID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("Collaborator not found in database")
+			return resterr.NewNotFoundError("User does not exists")
+		}
+
+		log.Error("Failed to find user in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("Failed to search for user due to internal error")
+	}
+
+	grants, err := s.permissionGrantRepo.FindForSubject(ctx, collaboratorID, entities.ResourceGameList, []entities.PermissionAction{entities.PermissionEdit})
+	if err != nil {
+		log.Error("Failed to find permission grants in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("Failed to add collaborator due to internal error")
+	}
+
+	for _, grant := range grants {
+		if grant.UserID != nil && grant.ResourceID != nil && *grant.ResourceID == gameListID {
+			log.Warn("User already collaborates on the list")
+			return resterr.NewConflictErr("The user already collaborates on this list")
+		}
+	}
+
+	grant := factory.NewCollaboratorGrant(collaboratorID, gameListID)
+	if err := s.permissionGrantRepo.Create(ctx, grant); err != nil {
+		log.Error("Failed to create permission grant in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("Failed to add collaborator due to internal error")
+	}
+
+	return nil
+}
+
+func (s *gameListService) RemoveCollaborator(ctx context.Context, userID, gameListID, collaboratorID uuid.UUID) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "GameListService", "RemoveCollaborator")
+	defer endSpan(span, &restErr)
+
+	gameList, restErr := s.findGameList(ctx, gameListID)
+	if restErr != nil {
+		return restErr
+	}
+
+	if restErr := authorize(ctx, log, s.policyEngine, userID, entities.PermissionShare, policy.NewGameListResource(gameList), "Only the owner of the list can manage its collaborators"); restErr != nil {
+		return restErr
+	}
+
+	if err := s.permissionGrantRepo.DeleteForResource(ctx, collaboratorID, entities.ResourceGameList, gameListID); err != nil {
+		log.Error("Failed to delete permission grants from database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("Failed to remove collaborator due to internal error")
+	}
+
+	return nil
+}
+
+func (s *gameListService) findGameList(ctx context.Context, gameListID uuid.UUID) (*entities.GameList, *resterr.RestErr) {
+	log := logger.Scoped(ctx, s.logger).With(slog.String("func", "findGameList"))
+
+	gameList, err := s.gameListRepo.Find(ctx, gameListID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("Game list not found in database")
+			return nil, resterr.NewNotFoundError("Game list does not exists")
+		}
+
+		log.Error("Failed to find game list in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("Failed to search for game list due to internal error")
+	}
+
+	return gameList, nil
+}
@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/factory"
+	"github.com/Bromolima/my-game-list/internal/policy"
 	"github.com/Bromolima/my-game-list/internal/service"
 	"github.com/Bromolima/my-game-list/mocks"
 	"github.com/google/uuid"
@@ -22,9 +24,12 @@ func TestGameListService_CreateGameList(t *testing.T) {
 
 	gameListRepository := mocks.NewMockGameListRepository(mockCtrl)
 	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	accessCache := mocks.NewMockAccessCache(mockCtrl)
+	permissionGrantRepository := mocks.NewMockPermissionGrantRepository(mockCtrl)
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 
-	gameListService := service.NewGameListService(gameListRepository, userRepository, logger)
+	policyEngine := policy.NewEngine(accessCache, permissionGrantRepository, logger)
+	gameListService := service.NewGameListService(gameListRepository, userRepository, permissionGrantRepository, policyEngine, logger)
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -76,9 +81,12 @@ func TestGameListService_DeleteGameList(t *testing.T) {
 
 	gameListRepository := mocks.NewMockGameListRepository(mockCtrl)
 	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	accessCache := mocks.NewMockAccessCache(mockCtrl)
+	permissionGrantRepository := mocks.NewMockPermissionGrantRepository(mockCtrl)
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 
-	gameListService := service.NewGameListService(gameListRepository, userRepository, logger)
+	policyEngine := policy.NewEngine(accessCache, permissionGrantRepository, logger)
+	gameListService := service.NewGameListService(gameListRepository, userRepository, permissionGrantRepository, policyEngine, logger)
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -86,7 +94,7 @@ func TestGameListService_DeleteGameList(t *testing.T) {
 
 	t.Run("should delete game list successfully", func(t *testing.T) {
 		userRepository.EXPECT().Find(ctx, userID).Return(&entities.User{}, nil)
-		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{}, nil)
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, UserID: userID}, nil)
 		gameListRepository.EXPECT().Delete(ctx, gameListID).Return(nil)
 
 		err := gameListService.DeleteGameList(ctx, gameListID, userID)
@@ -113,9 +121,19 @@ func TestGameListService_DeleteGameList(t *testing.T) {
 		assert.Equal(t, "Game list does not exists", err.Message)
 	})
 
+	t.Run("should return error when user does not own the list", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, userID).Return(&entities.User{}, nil)
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, UserID: uuid.New()}, nil)
+
+		err := gameListService.DeleteGameList(ctx, gameListID, userID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "You do not have permission to delete this list", err.Message)
+	})
+
 	t.Run("should return error when Delete game list fails", func(t *testing.T) {
 		userRepository.EXPECT().Find(ctx, userID).Return(&entities.User{}, nil)
-		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{}, nil)
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, UserID: userID}, nil)
 		gameListRepository.EXPECT().Delete(ctx, gameListID).Return(errors.New("database error"))
 
 		err := gameListService.DeleteGameList(ctx, gameListID, userID)
@@ -131,36 +149,108 @@ func TestGameListService_FindGamesFromList(t *testing.T) {
 
 	gameListRepository := mocks.NewMockGameListRepository(mockCtrl)
 	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	accessCache := mocks.NewMockAccessCache(mockCtrl)
+	permissionGrantRepository := mocks.NewMockPermissionGrantRepository(mockCtrl)
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 
-	gameListService := service.NewGameListService(gameListRepository, userRepository, logger)
+	policyEngine := policy.NewEngine(accessCache, permissionGrantRepository, logger)
+	gameListService := service.NewGameListService(gameListRepository, userRepository, permissionGrantRepository, policyEngine, logger)
 
 	ctx := context.Background()
+	userID := uuid.New()
 	gameListID := uuid.New()
 	games := []*entities.Game{{ID: uuid.New()}}
+	viewActions := []entities.PermissionAction{entities.PermissionView, entities.PermissionEdit}
 
 	t.Run("should find games from list successfully", func(t *testing.T) {
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, IsPublic: true}, nil)
 		gameListRepository.EXPECT().FindGamesByListID(ctx, gameListID).Return(games, nil)
 
-		foundGames, err := gameListService.FindGamesFromList(ctx, gameListID)
+		foundGames, err := gameListService.FindGamesFromList(ctx, userID, gameListID)
 
 		assert.Nil(t, err)
 		assert.Equal(t, games, foundGames)
 	})
 
-	t.Run("should return empty slice when game list is not found", func(t *testing.T) {
+	t.Run("should find games from private list when user owns it", func(t *testing.T) {
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, UserID: userID}, nil)
+		gameListRepository.EXPECT().FindGamesByListID(ctx, gameListID).Return(games, nil)
+
+		foundGames, err := gameListService.FindGamesFromList(ctx, userID, gameListID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, games, foundGames)
+	})
+
+	t.Run("should find games from private list when user collaborates on it", func(t *testing.T) {
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, UserID: uuid.New()}, nil)
+		permissionGrantRepository.EXPECT().
+			FindForSubject(ctx, userID, entities.ResourceGameList, viewActions).
+			Return([]*entities.PermissionGrant{factory.NewCollaboratorGrant(userID, gameListID)}, nil)
+		gameListRepository.EXPECT().FindGamesByListID(ctx, gameListID).Return(games, nil)
+
+		foundGames, err := gameListService.FindGamesFromList(ctx, userID, gameListID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, games, foundGames)
+	})
+
+	t.Run("should find games from public list for anonymous users", func(t *testing.T) {
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, UserID: userID, IsPublic: true}, nil)
+		gameListRepository.EXPECT().FindGamesByListID(ctx, gameListID).Return(games, nil)
+
+		foundGames, err := gameListService.FindGamesFromList(ctx, uuid.Nil, gameListID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, games, foundGames)
+	})
+
+	t.Run("should return error when anonymous users read a private list", func(t *testing.T) {
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, UserID: userID}, nil)
+
+		foundGames, err := gameListService.FindGamesFromList(ctx, uuid.Nil, gameListID)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, foundGames)
+		assert.Equal(t, "You do not have permission to view this list", err.Message)
+	})
+
+	t.Run("should return error when list is private", func(t *testing.T) {
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, UserID: uuid.New()}, nil)
+		permissionGrantRepository.EXPECT().FindForSubject(ctx, userID, entities.ResourceGameList, viewActions).Return(nil, nil)
+
+		foundGames, err := gameListService.FindGamesFromList(ctx, userID, gameListID)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, foundGames)
+		assert.Equal(t, "You do not have permission to view this list", err.Message)
+	})
+
+	t.Run("should return error when game list is not found", func(t *testing.T) {
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(nil, gorm.ErrRecordNotFound)
+
+		foundGames, err := gameListService.FindGamesFromList(ctx, userID, gameListID)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, foundGames)
+		assert.Equal(t, "Game list does not exists", err.Message)
+	})
+
+	t.Run("should return empty slice when list has no games", func(t *testing.T) {
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, IsPublic: true}, nil)
 		gameListRepository.EXPECT().FindGamesByListID(ctx, gameListID).Return(nil, gorm.ErrRecordNotFound)
 
-		foundGames, err := gameListService.FindGamesFromList(ctx, gameListID)
+		foundGames, err := gameListService.FindGamesFromList(ctx, userID, gameListID)
 
 		assert.Nil(t, err)
 		assert.Empty(t, foundGames)
 	})
 
 	t.Run("should return error when FindGamesByListID fails", func(t *testing.T) {
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, IsPublic: true}, nil)
 		gameListRepository.EXPECT().FindGamesByListID(ctx, gameListID).Return(nil, errors.New("database error"))
 
-		foundGames, err := gameListService.FindGamesFromList(ctx, gameListID)
+		foundGames, err := gameListService.FindGamesFromList(ctx, userID, gameListID)
 
 		assert.NotNil(t, err)
 		assert.Nil(t, foundGames)
@@ -174,9 +264,12 @@ func TestGameListService_UpdateGameList(t *testing.T) {
 
 	gameListRepository := mocks.NewMockGameListRepository(mockCtrl)
 	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	accessCache := mocks.NewMockAccessCache(mockCtrl)
+	permissionGrantRepository := mocks.NewMockPermissionGrantRepository(mockCtrl)
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 
-	gameListService := service.NewGameListService(gameListRepository, userRepository, logger)
+	policyEngine := policy.NewEngine(accessCache, permissionGrantRepository, logger)
+	gameListService := service.NewGameListService(gameListRepository, userRepository, permissionGrantRepository, policyEngine, logger)
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -186,6 +279,7 @@ func TestGameListService_UpdateGameList(t *testing.T) {
 
 	t.Run("should update game list successfully", func(t *testing.T) {
 		userRepository.EXPECT().Find(ctx, userID).Return(&entities.User{}, nil)
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, UserID: userID}, nil)
 		gameListRepository.EXPECT().Update(ctx, gomock.Any()).Return(nil)
 
 		err := gameListService.UpdateGameList(ctx, userID, gameListID, name, isPublic)
@@ -193,6 +287,37 @@ func TestGameListService_UpdateGameList(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
+	t.Run("should keep the owner when collaborator updates the list", func(t *testing.T) {
+		ownerID := uuid.New()
+		userRepository.EXPECT().Find(ctx, userID).Return(&entities.User{}, nil)
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, UserID: ownerID}, nil)
+		permissionGrantRepository.EXPECT().
+			FindForSubject(ctx, userID, entities.ResourceGameList, []entities.PermissionAction{entities.PermissionEdit}).
+			Return([]*entities.PermissionGrant{factory.NewCollaboratorGrant(userID, gameListID)}, nil)
+		gameListRepository.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, gameList *entities.GameList) error {
+			assert.Equal(t, ownerID, gameList.UserID)
+			assert.Equal(t, name, gameList.Name)
+			return nil
+		})
+
+		err := gameListService.UpdateGameList(ctx, userID, gameListID, name, isPublic)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return error when user cannot edit the list", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, userID).Return(&entities.User{}, nil)
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, UserID: uuid.New()}, nil)
+		permissionGrantRepository.EXPECT().
+			FindForSubject(ctx, userID, entities.ResourceGameList, []entities.PermissionAction{entities.PermissionEdit}).
+			Return(nil, nil)
+
+		err := gameListService.UpdateGameList(ctx, userID, gameListID, name, isPublic)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "You do not have permission to modify this list", err.Message)
+	})
+
 	t.Run("should return error when user is not found", func(t *testing.T) {
 		userRepository.EXPECT().Find(ctx, userID).Return(nil, gorm.ErrRecordNotFound)
 
@@ -213,6 +338,7 @@ func TestGameListService_UpdateGameList(t *testing.T) {
 
 	t.Run("should return error when Update game list fails", func(t *testing.T) {
 		userRepository.EXPECT().Find(ctx, userID).Return(&entities.User{}, nil)
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, UserID: userID}, nil)
 		gameListRepository.EXPECT().Update(ctx, gomock.Any()).Return(errors.New("database error"))
 
 		err := gameListService.UpdateGameList(ctx, userID, gameListID, name, isPublic)
@@ -221,3 +347,127 @@ func TestGameListService_UpdateGameList(t *testing.T) {
 		assert.Equal(t, "Failed to create list due to internal error", err.Message)
 	})
 }
+
+func TestGameListService_AddCollaborator(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	gameListRepository := mocks.NewMockGameListRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	accessCache := mocks.NewMockAccessCache(mockCtrl)
+	permissionGrantRepository := mocks.NewMockPermissionGrantRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	policyEngine := policy.NewEngine(accessCache, permissionGrantRepository, logger)
+	gameListService := service.NewGameListService(gameListRepository, userRepository, permissionGrantRepository, policyEngine, logger)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	collaboratorID := uuid.New()
+	gameListID := uuid.New()
+	editActions := []entities.PermissionAction{entities.PermissionEdit}
+
+	t.Run("should add collaborator successfully", func(t *testing.T) {
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, UserID: userID}, nil)
+		userRepository.EXPECT().Find(ctx, collaboratorID).Return(&entities.User{ID: collaboratorID}, nil)
+		permissionGrantRepository.EXPECT().FindForSubject(ctx, collaboratorID, entities.ResourceGameList, editActions).Return(nil, nil)
+		permissionGrantRepository.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, grant *entities.PermissionGrant) error {
+			assert.Equal(t, collaboratorID, *grant.UserID)
+			assert.Equal(t, gameListID, *grant.ResourceID)
+			assert.Equal(t, entities.PermissionEdit, grant.Action)
+			return nil
+		})
+
+		err := gameListService.AddCollaborator(ctx, userID, gameListID, collaboratorID)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return error when user does not own the list", func(t *testing.T) {
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, UserID: uuid.New()}, nil)
+
+		err := gameListService.AddCollaborator(ctx, userID, gameListID, collaboratorID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Only the owner of the list can manage its collaborators", err.Message)
+	})
+
+	t.Run("should return error when collaborator is the owner", func(t *testing.T) {
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, UserID: userID}, nil)
+
+		err := gameListService.AddCollaborator(ctx, userID, gameListID, userID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The owner of the list cannot be added as a collaborator", err.Message)
+	})
+
+	t.Run("should return error when collaborator is not found", func(t *testing.T) {
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, UserID: userID}, nil)
+		userRepository.EXPECT().Find(ctx, collaboratorID).Return(nil, gorm.ErrRecordNotFound)
+
+		err := gameListService.AddCollaborator(ctx, userID, gameListID, collaboratorID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "User does not exists", err.Message)
+	})
+
+	t.Run("should return error when user already collaborates on the list", func(t *testing.T) {
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, UserID: userID}, nil)
+		userRepository.EXPECT().Find(ctx, collaboratorID).Return(&entities.User{ID: collaboratorID}, nil)
+		permissionGrantRepository.EXPECT().
+			FindForSubject(ctx, collaboratorID, entities.ResourceGameList, editActions).
+			Return([]*entities.PermissionGrant{factory.NewCollaboratorGrant(collaboratorID, gameListID)}, nil)
+
+		err := gameListService.AddCollaborator(ctx, userID, gameListID, collaboratorID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The user already collaborates on this list", err.Message)
+	})
+}
+
+func TestGameListService_RemoveCollaborator(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	gameListRepository := mocks.NewMockGameListRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	accessCache := mocks.NewMockAccessCache(mockCtrl)
+	permissionGrantRepository := mocks.NewMockPermissionGrantRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	policyEngine := policy.NewEngine(accessCache, permissionGrantRepository, logger)
+	gameListService := service.NewGameListService(gameListRepository, userRepository, permissionGrantRepository, policyEngine, logger)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	collaboratorID := uuid.New()
+	gameListID := uuid.New()
+
+	t.Run("should remove collaborator successfully", func(t *testing.T) {
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, UserID: userID}, nil)
+		permissionGrantRepository.EXPECT().DeleteForResource(ctx, collaboratorID, entities.ResourceGameList, gameListID).Return(nil)
+
+		err := gameListService.RemoveCollaborator(ctx, userID, gameListID, collaboratorID)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return error when user does not own the list", func(t *testing.T) {
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, UserID: uuid.New()}, nil)
+
+		err := gameListService.RemoveCollaborator(ctx, userID, gameListID, collaboratorID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Only the owner of the list can manage its collaborators", err.Message)
+	})
+
+	t.Run("should return error when DeleteForResource fails", func(t *testing.T) {
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, UserID: userID}, nil)
+		permissionGrantRepository.EXPECT().DeleteForResource(ctx, collaboratorID, entities.ResourceGameList, gameListID).Return(errors.New("database error"))
+
+		err := gameListService.RemoveCollaborator(ctx, userID, gameListID, collaboratorID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Failed to remove collaborator due to internal error", err.Message)
+	})
+}
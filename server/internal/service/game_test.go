@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/policy"
+	"github.com/Bromolima/my-game-list/internal/requestctx"
 	"github.com/Bromolima/my-game-list/internal/service"
 	"github.com/Bromolima/my-game-list/mocks"
 	"github.com/google/uuid"
@@ -21,11 +23,17 @@ func TestGameService_CreateGame(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	gameRepository := mocks.NewMockGameRepository(mockCtrl)
+	accessCache := mocks.NewMockAccessCache(mockCtrl)
+	permissionGrantRepository := mocks.NewMockPermissionGrantRepository(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 
-	gameService := service.NewGameService(gameRepository, logger)
+	policyEngine := policy.NewEngine(accessCache, permissionGrantRepository, logger)
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	gameService := service.NewGameService(gameRepository, policyEngine, auditService, logger)
 
-	ctx := context.Background()
+	userID := uuid.New()
+	ctx := requestctx.WithMetadata(context.Background(), &requestctx.Metadata{ActorID: userID, ActorRoleID: entities.RoleAdminID})
 	name := "The Witcher 3"
 	genre := "RPG"
 	developer := "CD Projekt Red"
@@ -33,17 +41,57 @@ func TestGameService_CreateGame(t *testing.T) {
 	imageURL := "http://example.com/witcher3.png"
 
 	t.Run("should create game successfully", func(t *testing.T) {
+		accessCache.EXPECT().HasAccess(ctx, uint(entities.RoleAdminID), entities.CreateAcess).Return(true, nil)
+		gameRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		err := gameService.CreateGame(ctx, userID, name, genre, developer, description, imageURL)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should create game when user curates the genre", func(t *testing.T) {
+		accessCache.EXPECT().HasAccess(ctx, uint(entities.RoleAdminID), entities.CreateAcess).Return(false, nil)
+		permissionGrantRepository.EXPECT().
+			FindForSubject(ctx, userID, entities.ResourceGame, []entities.PermissionAction{entities.PermissionCreate}).
+			Return([]*entities.PermissionGrant{{Attribute: entities.ResourceAttributeGenre, Value: "rpg"}}, nil)
 		gameRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
 
-		err := gameService.CreateGame(ctx, name, genre, developer, description, imageURL)
+		err := gameService.CreateGame(ctx, userID, name, genre, developer, description, imageURL)
 
 		assert.Nil(t, err)
 	})
 
+	t.Run("should return error when user curates another genre", func(t *testing.T) {
+		accessCache.EXPECT().HasAccess(ctx, uint(entities.RoleAdminID), entities.CreateAcess).Return(false, nil)
+		permissionGrantRepository.EXPECT().
+			FindForSubject(ctx, userID, entities.ResourceGame, []entities.PermissionAction{entities.PermissionCreate}).
+			Return([]*entities.PermissionGrant{{Attribute: entities.ResourceAttributeGenre, Value: "Racing"}}, nil)
+
+		err := gameService.CreateGame(ctx, userID, name, genre, developer, description, imageURL)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "You do not have permission to create this game", err.Message)
+	})
+
+	t.Run("should only check the grants when the role of the user is unknown", func(t *testing.T) {
+		ctx := context.Background()
+		permissionGrantRepository.EXPECT().
+			FindForSubject(ctx, userID, entities.ResourceGame, []entities.PermissionAction{entities.PermissionCreate}).
+			Return(nil, nil)
+
+		err := gameService.CreateGame(ctx, userID, name, genre, developer, description, imageURL)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "You do not have permission to create this game", err.Message)
+	})
+
 	t.Run("should return error when Create fails", func(t *testing.T) {
+		accessCache.EXPECT().HasAccess(ctx, uint(entities.RoleAdminID), entities.CreateAcess).Return(true, nil)
 		gameRepository.EXPECT().Create(ctx, gomock.Any()).Return(errors.New("database error"))
 
-		err := gameService.CreateGame(ctx, name, genre, developer, description, imageURL)
+		err := gameService.CreateGame(ctx, userID, name, genre, developer, description, imageURL)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, "An error occurred while creating the game", err.Message)
@@ -55,9 +103,14 @@ func TestGameService_FindGame(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	gameRepository := mocks.NewMockGameRepository(mockCtrl)
+	accessCache := mocks.NewMockAccessCache(mockCtrl)
+	permissionGrantRepository := mocks.NewMockPermissionGrantRepository(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 
-	gameService := service.NewGameService(gameRepository, logger)
+	policyEngine := policy.NewEngine(accessCache, permissionGrantRepository, logger)
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	gameService := service.NewGameService(gameRepository, policyEngine, auditService, logger)
 
 	ctx := context.Background()
 	gameID := uuid.New()
@@ -98,9 +151,14 @@ func TestGameService_SearchGames(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	gameRepository := mocks.NewMockGameRepository(mockCtrl)
+	accessCache := mocks.NewMockAccessCache(mockCtrl)
+	permissionGrantRepository := mocks.NewMockPermissionGrantRepository(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 
-	gameService := service.NewGameService(gameRepository, logger)
+	policyEngine := policy.NewEngine(accessCache, permissionGrantRepository, logger)
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	gameService := service.NewGameService(gameRepository, policyEngine, auditService, logger)
 
 	ctx := context.Background()
 	page := &entities.Page[entities.Game]{}
@@ -141,11 +199,17 @@ func TestGameService_UpdateGame(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	gameRepository := mocks.NewMockGameRepository(mockCtrl)
+	accessCache := mocks.NewMockAccessCache(mockCtrl)
+	permissionGrantRepository := mocks.NewMockPermissionGrantRepository(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 
-	gameService := service.NewGameService(gameRepository, logger)
+	policyEngine := policy.NewEngine(accessCache, permissionGrantRepository, logger)
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	gameService := service.NewGameService(gameRepository, policyEngine, auditService, logger)
 
-	ctx := context.Background()
+	userID := uuid.New()
+	ctx := requestctx.WithMetadata(context.Background(), &requestctx.Metadata{ActorID: userID, ActorRoleID: entities.RoleAdminID})
 	gameID := uuid.New()
 	name := "The Witcher 3"
 	genre := "RPG"
@@ -155,17 +219,61 @@ func TestGameService_UpdateGame(t *testing.T) {
 
 	t.Run("should update game successfully", func(t *testing.T) {
 		gameRepository.EXPECT().Find(ctx, gameID).Return(&entities.Game{}, nil)
+		accessCache.EXPECT().HasAccess(ctx, uint(entities.RoleAdminID), entities.UpdateAccess).Return(true, nil).Times(2)
+		gameRepository.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		err := gameService.UpdateGame(ctx, userID, gameID, name, genre, developer, description, imageURL)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should update game when user curates the genre", func(t *testing.T) {
+		gameRepository.EXPECT().Find(ctx, gameID).Return(&entities.Game{ID: gameID, Genre: genre}, nil)
+		accessCache.EXPECT().HasAccess(ctx, uint(entities.RoleAdminID), entities.UpdateAccess).Return(false, nil).Times(2)
+		permissionGrantRepository.EXPECT().
+			FindForSubject(ctx, userID, entities.ResourceGame, []entities.PermissionAction{entities.PermissionEdit}).
+			Return([]*entities.PermissionGrant{{Attribute: entities.ResourceAttributeGenre, Value: genre}}, nil).
+			Times(2)
 		gameRepository.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
 
-		err := gameService.UpdateGame(ctx, gameID, name, genre, developer, description, imageURL)
+		err := gameService.UpdateGame(ctx, userID, gameID, name, genre, developer, description, imageURL)
 
 		assert.Nil(t, err)
 	})
 
+	t.Run("should return error when curator moves the game to another genre", func(t *testing.T) {
+		gameRepository.EXPECT().Find(ctx, gameID).Return(&entities.Game{ID: gameID, Genre: genre}, nil)
+		accessCache.EXPECT().HasAccess(ctx, uint(entities.RoleAdminID), entities.UpdateAccess).Return(false, nil).Times(2)
+		permissionGrantRepository.EXPECT().
+			FindForSubject(ctx, userID, entities.ResourceGame, []entities.PermissionAction{entities.PermissionEdit}).
+			Return([]*entities.PermissionGrant{{Attribute: entities.ResourceAttributeGenre, Value: genre}}, nil).
+			Times(2)
+
+		err := gameService.UpdateGame(ctx, userID, gameID, name, "Racing", developer, description, imageURL)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "You do not have permission to edit this game", err.Message)
+	})
+
+	t.Run("should return error when user has no permission", func(t *testing.T) {
+		gameRepository.EXPECT().Find(ctx, gameID).Return(&entities.Game{ID: gameID, Genre: genre}, nil)
+		accessCache.EXPECT().HasAccess(ctx, uint(entities.RoleAdminID), entities.UpdateAccess).Return(false, nil)
+		permissionGrantRepository.EXPECT().
+			FindForSubject(ctx, userID, entities.ResourceGame, []entities.PermissionAction{entities.PermissionEdit}).
+			Return(nil, nil)
+
+		err := gameService.UpdateGame(ctx, userID, gameID, name, genre, developer, description, imageURL)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "You do not have permission to edit this game", err.Message)
+	})
+
 	t.Run("should return error when game is not found", func(t *testing.T) {
 		gameRepository.EXPECT().Find(ctx, gameID).Return(nil, gorm.ErrRecordNotFound)
 
-		err := gameService.UpdateGame(ctx, gameID, name, genre, developer, description, imageURL)
+		err := gameService.UpdateGame(ctx, userID, gameID, name, genre, developer, description, imageURL)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, "The requested game was not found", err.Message)
@@ -174,7 +282,7 @@ func TestGameService_UpdateGame(t *testing.T) {
 	t.Run("should return error when Find fails", func(t *testing.T) {
 		gameRepository.EXPECT().Find(ctx, gameID).Return(nil, errors.New("database error"))
 
-		err := gameService.UpdateGame(ctx, gameID, name, genre, developer, description, imageURL)
+		err := gameService.UpdateGame(ctx, userID, gameID, name, genre, developer, description, imageURL)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, "An error occurred while finding the game", err.Message)
@@ -182,9 +290,10 @@ func TestGameService_UpdateGame(t *testing.T) {
 
 	t.Run("should return error when Update fails", func(t *testing.T) {
 		gameRepository.EXPECT().Find(ctx, gameID).Return(&entities.Game{}, nil)
+		accessCache.EXPECT().HasAccess(ctx, uint(entities.RoleAdminID), entities.UpdateAccess).Return(true, nil).Times(2)
 		gameRepository.EXPECT().Update(ctx, gomock.Any()).Return(errors.New("database error"))
 
-		err := gameService.UpdateGame(ctx, gameID, name, genre, developer, description, imageURL)
+		err := gameService.UpdateGame(ctx, userID, gameID, name, genre, developer, description, imageURL)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, "An error occurred while updating the game", err.Message)
@@ -196,18 +305,26 @@ func TestGameService_DeleteGame(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	gameRepository := mocks.NewMockGameRepository(mockCtrl)
+	accessCache := mocks.NewMockAccessCache(mockCtrl)
+	permissionGrantRepository := mocks.NewMockPermissionGrantRepository(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 
-	gameService := service.NewGameService(gameRepository, logger)
+	policyEngine := policy.NewEngine(accessCache, permissionGrantRepository, logger)
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	gameService := service.NewGameService(gameRepository, policyEngine, auditService, logger)
 
-	ctx := context.Background()
+	userID := uuid.New()
+	ctx := requestctx.WithMetadata(context.Background(), &requestctx.Metadata{ActorID: userID, ActorRoleID: entities.RoleAdminID})
 	gameID := uuid.New()
 
 	t.Run("should delete game successfully", func(t *testing.T) {
 		gameRepository.EXPECT().Find(ctx, gameID).Return(&entities.Game{}, nil)
+		accessCache.EXPECT().HasAccess(ctx, uint(entities.RoleAdminID), entities.DeleteAcess).Return(true, nil)
 		gameRepository.EXPECT().Delete(ctx, gameID).Return(nil)
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
 
-		err := gameService.DeleteGame(ctx, gameID.String())
+		err := gameService.DeleteGame(ctx, userID, gameID.String())
 
 		assert.Nil(t, err)
 	})
@@ -215,7 +332,7 @@ func TestGameService_DeleteGame(t *testing.T) {
 	t.Run("should return error when game is not found", func(t *testing.T) {
 		gameRepository.EXPECT().Find(ctx, gameID).Return(nil, gorm.ErrRecordNotFound)
 
-		err := gameService.DeleteGame(ctx, gameID.String())
+		err := gameService.DeleteGame(ctx, userID, gameID.String())
 
 		assert.NotNil(t, err)
 		assert.Equal(t, "The requested game was not found", err.Message)
@@ -224,17 +341,31 @@ func TestGameService_DeleteGame(t *testing.T) {
 	t.Run("should return error when Find fails", func(t *testing.T) {
 		gameRepository.EXPECT().Find(ctx, gameID).Return(nil, errors.New("database error"))
 
-		err := gameService.DeleteGame(ctx, gameID.String())
+		err := gameService.DeleteGame(ctx, userID, gameID.String())
 
 		assert.NotNil(t, err)
 		assert.Equal(t, "An error occurred while finding the game", err.Message)
 	})
 
+	t.Run("should return error when user has no permission", func(t *testing.T) {
+		gameRepository.EXPECT().Find(ctx, gameID).Return(&entities.Game{ID: gameID}, nil)
+		accessCache.EXPECT().HasAccess(ctx, uint(entities.RoleAdminID), entities.DeleteAcess).Return(false, nil)
+		permissionGrantRepository.EXPECT().
+			FindForSubject(ctx, userID, entities.ResourceGame, []entities.PermissionAction{entities.PermissionDelete}).
+			Return(nil, nil)
+
+		err := gameService.DeleteGame(ctx, userID, gameID.String())
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "You do not have permission to delete this game", err.Message)
+	})
+
 	t.Run("should return error when Delete fails", func(t *testing.T) {
 		gameRepository.EXPECT().Find(ctx, gameID).Return(&entities.Game{}, nil)
+		accessCache.EXPECT().HasAccess(ctx, uint(entities.RoleAdminID), entities.DeleteAcess).Return(true, nil)
 		gameRepository.EXPECT().Delete(ctx, gameID).Return(errors.New("database error"))
 
-		err := gameService.DeleteGame(ctx, gameID.String())
+		err := gameService.DeleteGame(ctx, userID, gameID.String())
 
 		assert.NotNil(t, err)
 		assert.Equal(t, "An error occurred while deleting the game", err.Message)
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Bromolima/my-game-list/config"
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/health"
+)
+
+type HealthService interface {
+	Liveness(ctx context.Context) *entities.HealthReport
+	Readiness(ctx context.Context) *entities.HealthReport
+}
+
+type healthService struct {
+	checkers []health.Checker
+	logger   *slog.Logger
+}
+
+func NewHealthService(checkers []health.Checker, logger *slog.Logger) HealthService {
+	return &healthService{
+		checkers: checkers,
+		logger:   logger.With(slog.String("service", "health")),
+	}
+}
+
+// Liveness only reports that the process is able to serve requests. It does
+// not check the dependencies, an unreachable database must not get the server
+// restarted.
+func (s *healthService) Liveness(ctx context.Context) *entities.HealthReport {
+	return &entities.HealthReport{Status: entities.HealthStatusOK, Checks: []*entities.HealthCheck{}}
+}
+
+// Readiness runs every checker concurrently, each one limited to the
+// configured timeout, and is healthy only when all of them pass.
+func (s *healthService) Readiness(ctx context.Context) *entities.HealthReport {
+	log := s.logger.With(slog.String("func", "Readiness"))
+
+	report := &entities.HealthReport{
+		Status: entities.HealthStatusOK,
+		Checks: make([]*entities.HealthCheck, len(s.checkers)),
+	}
+
+	var wg sync.WaitGroup
+	for i, checker := range s.checkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, config.Env.Health.CheckTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := checker.Check(checkCtx)
+			check := &entities.HealthCheck{
+				Name:    checker.Name(),
+				Status:  entities.HealthStatusOK,
+				Latency: time.Since(start),
+			}
+
+			if err != nil {
+				log.Warn("Health check failed", slog.String("check", checker.Name()), slog.String("error", err.Error()))
+				check.Status = entities.HealthStatusError
+			}
+
+			report.Checks[i] = check
+		}()
+	}
+
+	wg.Wait()
+
+	for _, check := range report.Checks {
+		if check.Status != entities.HealthStatusOK {
+			report.Status = entities.HealthStatusError
+		}
+	}
+
+	return report
+}
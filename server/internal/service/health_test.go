@@ -0,0 +1,79 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Bromolima/my-game-list/config"
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/health"
+	"github.com/Bromolima/my-game-list/internal/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthService_Liveness(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	failing := health.NewChecker("database", func(context.Context) error {
+		return errors.New("connection refused")
+	})
+
+	healthService := service.NewHealthService([]health.Checker{failing}, logger)
+
+	t.Run("should report healthy without running the checkers", func(t *testing.T) {
+		report := healthService.Liveness(context.Background())
+
+		assert.True(t, report.Healthy())
+		assert.Empty(t, report.Checks)
+	})
+}
+
+func TestHealthService_Readiness(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	config.Env.Health.CheckTimeout = 50 * time.Millisecond
+
+	passing := health.NewChecker("database", func(context.Context) error {
+		return nil
+	})
+	failing := health.NewChecker("mailer", func(context.Context) error {
+		return errors.New("connection refused")
+	})
+	hanging := health.NewChecker("migrations", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	t.Run("should report healthy when every check passes", func(t *testing.T) {
+		healthService := service.NewHealthService([]health.Checker{passing}, logger)
+
+		report := healthService.Readiness(context.Background())
+
+		assert.True(t, report.Healthy())
+		assert.Len(t, report.Checks, 1)
+		assert.Equal(t, "database", report.Checks[0].Name)
+		assert.Equal(t, entities.HealthStatusOK, report.Checks[0].Status)
+	})
+
+	t.Run("should report unhealthy when a check fails", func(t *testing.T) {
+		healthService := service.NewHealthService([]health.Checker{passing, failing}, logger)
+
+		report := healthService.Readiness(context.Background())
+
+		assert.False(t, report.Healthy())
+		assert.Equal(t, entities.HealthStatusOK, report.Checks[0].Status)
+		assert.Equal(t, entities.HealthStatusError, report.Checks[1].Status)
+	})
+
+	t.Run("should fail a check that exceeds the timeout", func(t *testing.T) {
+		healthService := service.NewHealthService([]health.Checker{hanging}, logger)
+
+		report := healthService.Readiness(context.Background())
+
+		assert.False(t, report.Healthy())
+		assert.Equal(t, entities.HealthStatusError, report.Checks[0].Status)
+		assert.GreaterOrEqual(t, report.Checks[0].Latency, 50*time.Millisecond)
+	})
+}
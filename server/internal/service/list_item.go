@@ -5,8 +5,11 @@ import (
 	"errors"
 	"log/slog"
 
+	"github.com/Bromolima/my-game-list/internal/entities"
 	"github.com/Bromolima/my-game-list/internal/factory"
 	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/metrics"
+	"github.com/Bromolima/my-game-list/internal/policy"
 	"github.com/Bromolima/my-game-list/internal/repository"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -22,20 +25,25 @@ type listItemService struct {
 	listItemRepo repository.ListItemRepository
 	gameRepo     repository.GameRepository
 	gameListRepo repository.GameListRepository
+	policyEngine policy.Engine
+	metrics      *metrics.Metrics
 	logger       *slog.Logger
 }
 
-func NewListItemService(listItemRepo repository.ListItemRepository, gameRepo repository.GameRepository, gameListRepo repository.GameListRepository, logger *slog.Logger) ListItemService {
+func NewListItemService(listItemRepo repository.ListItemRepository, gameRepo repository.GameRepository, gameListRepo repository.GameListRepository, policyEngine policy.Engine, metrics *metrics.Metrics, logger *slog.Logger) ListItemService {
 	return &listItemService{
 		listItemRepo: listItemRepo,
 		gameRepo:     gameRepo,
 		gameListRepo: gameListRepo,
+		policyEngine: policyEngine,
+		metrics:      metrics,
 		logger:       logger.With(slog.String("service", "listItem")),
 	}
 }
 
-func (s *listItemService) AddGameToList(ctx context.Context, userID, gameID, gameListID uuid.UUID, status string, rating float32) *resterr.RestErr {
-	log := s.logger.With(slog.String("func", "AddGameToList"))
+func (s *listItemService) AddGameToList(ctx context.Context, userID, gameID, gameListID uuid.UUID, status string, rating float32) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "ListItemService", "AddGameToList")
+	defer endSpan(span, &restErr)
 
 	_, err := s.gameRepo.Find(ctx, gameID)
 	if err != nil {
@@ -59,9 +67,8 @@ func (s *listItemService) AddGameToList(ctx context.Context, userID, gameID, gam
 		return resterr.NewInternalServerErr("An unexpected error occurred while retrieving the game list")
 	}
 
-	if gameList.UserID != userID {
-		log.Warn("Unauthorized attempt to modify game list")
-		return resterr.NewForbiddenError("You do not have permission to modify this list")
+	if restErr := authorize(ctx, log, s.policyEngine, userID, entities.PermissionEdit, policy.NewGameListResource(gameList), "You do not have permission to modify this list"); restErr != nil {
+		return restErr
 	}
 
 	listItem := factory.NewListItem(gameListID, gameID, status, rating)
@@ -70,11 +77,13 @@ func (s *listItemService) AddGameToList(ctx context.Context, userID, gameID, gam
 		return resterr.NewInternalServerErr("Could not add the game to the list")
 	}
 
+	s.metrics.RecordListItemAdded()
 	return nil
 }
 
-func (s *listItemService) UpdateGameFromList(ctx context.Context, gameID, gameListID, userID uuid.UUID, rating float32, status string) *resterr.RestErr {
-	log := s.logger.With(slog.String("func", "UpdateGameFromList"))
+func (s *listItemService) UpdateGameFromList(ctx context.Context, gameID, gameListID, userID uuid.UUID, rating float32, status string) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "ListItemService", "UpdateGameFromList")
+	defer endSpan(span, &restErr)
 
 	_, err := s.gameRepo.Find(ctx, gameID)
 	if err != nil {
@@ -98,9 +107,8 @@ func (s *listItemService) UpdateGameFromList(ctx context.Context, gameID, gameLi
 		return resterr.NewInternalServerErr("An unexpected error occurred while retrieving the game list")
 	}
 
-	if gameList.UserID != userID {
-		log.Warn("Unauthorized attempt to modify game list")
-		return resterr.NewForbiddenError("You do not have permission to modify this list")
+	if restErr := authorize(ctx, log, s.policyEngine, userID, entities.PermissionEdit, policy.NewGameListResource(gameList), "You do not have permission to modify this list"); restErr != nil {
+		return restErr
 	}
 
 	if err := s.listItemRepo.Update(ctx, gameID, gameListID, rating, status); err != nil {
@@ -111,8 +119,9 @@ func (s *listItemService) UpdateGameFromList(ctx context.Context, gameID, gameLi
 	return nil
 }
 
-func (s *listItemService) DeleteGameFromList(ctx context.Context, gameID, gameListID, userID uuid.UUID) *resterr.RestErr {
-	log := s.logger.With(slog.String("func", "DeleteGameFromList"))
+func (s *listItemService) DeleteGameFromList(ctx context.Context, gameID, gameListID, userID uuid.UUID) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "ListItemService", "DeleteGameFromList")
+	defer endSpan(span, &restErr)
 
 	_, err := s.gameRepo.Find(ctx, gameID)
 	if err != nil {
@@ -136,9 +145,8 @@ func (s *listItemService) DeleteGameFromList(ctx context.Context, gameID, gameLi
 		return resterr.NewInternalServerErr("An unexpected error occurred while retrieving the game list")
 	}
 
-	if gameList.UserID != userID {
-		log.Warn("Unauthorized attempt to modify game list")
-		return resterr.NewForbiddenError("You do not have permission to modify this list")
+	if restErr := authorize(ctx, log, s.policyEngine, userID, entities.PermissionEdit, policy.NewGameListResource(gameList), "You do not have permission to modify this list"); restErr != nil {
+		return restErr
 	}
 
 	if err := s.listItemRepo.Delete(ctx, gameID, gameListID); err != nil {
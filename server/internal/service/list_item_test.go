@@ -8,6 +8,9 @@ import (
 	"testing"
 
 	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/factory"
+	"github.com/Bromolima/my-game-list/internal/metrics"
+	"github.com/Bromolima/my-game-list/internal/policy"
 	"github.com/Bromolima/my-game-list/internal/service"
 	"github.com/Bromolima/my-game-list/mocks"
 	"github.com/google/uuid"
@@ -23,9 +26,12 @@ func TestListItemService_AddGameToList(t *testing.T) {
 	listItemRepository := mocks.NewMockListItemRepository(mockCtrl)
 	gameRepository := mocks.NewMockGameRepository(mockCtrl)
 	gameListRepository := mocks.NewMockGameListRepository(mockCtrl)
+	accessCache := mocks.NewMockAccessCache(mockCtrl)
+	permissionGrantRepository := mocks.NewMockPermissionGrantRepository(mockCtrl)
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 
-	listItemService := service.NewListItemService(listItemRepository, gameRepository, gameListRepository, logger)
+	policyEngine := policy.NewEngine(accessCache, permissionGrantRepository, logger)
+	listItemService := service.NewListItemService(listItemRepository, gameRepository, gameListRepository, policyEngine, metrics.NewMetrics(), logger)
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -44,6 +50,32 @@ func TestListItemService_AddGameToList(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
+	t.Run("should add game to list when user collaborates on the list", func(t *testing.T) {
+		gameRepository.EXPECT().Find(ctx, gameID).Return(&entities.Game{}, nil)
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, UserID: uuid.New()}, nil)
+		permissionGrantRepository.EXPECT().
+			FindForSubject(ctx, userID, entities.ResourceGameList, []entities.PermissionAction{entities.PermissionEdit}).
+			Return([]*entities.PermissionGrant{factory.NewCollaboratorGrant(userID, gameListID)}, nil)
+		listItemRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		err := listItemService.AddGameToList(ctx, userID, gameID, gameListID, status, rating)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return error when user collaborates on another list", func(t *testing.T) {
+		gameRepository.EXPECT().Find(ctx, gameID).Return(&entities.Game{}, nil)
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, UserID: uuid.New()}, nil)
+		permissionGrantRepository.EXPECT().
+			FindForSubject(ctx, userID, entities.ResourceGameList, []entities.PermissionAction{entities.PermissionEdit}).
+			Return([]*entities.PermissionGrant{factory.NewCollaboratorGrant(userID, uuid.New())}, nil)
+
+		err := listItemService.AddGameToList(ctx, userID, gameID, gameListID, status, rating)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "You do not have permission to modify this list", err.Message)
+	})
+
 	t.Run("should return error when game is not found", func(t *testing.T) {
 		gameRepository.EXPECT().Find(ctx, gameID).Return(nil, gorm.ErrRecordNotFound)
 
@@ -65,7 +97,10 @@ func TestListItemService_AddGameToList(t *testing.T) {
 
 	t.Run("should return error when user is not authorized", func(t *testing.T) {
 		gameRepository.EXPECT().Find(ctx, gameID).Return(&entities.Game{}, nil)
-		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{UserID: uuid.New()}, nil)
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, UserID: uuid.New()}, nil)
+		permissionGrantRepository.EXPECT().
+			FindForSubject(ctx, userID, entities.ResourceGameList, []entities.PermissionAction{entities.PermissionEdit}).
+			Return(nil, nil)
 
 		err := listItemService.AddGameToList(ctx, userID, gameID, gameListID, status, rating)
 
@@ -92,9 +127,12 @@ func TestListItemService_DeleteGameFromList(t *testing.T) {
 	listItemRepository := mocks.NewMockListItemRepository(mockCtrl)
 	gameRepository := mocks.NewMockGameRepository(mockCtrl)
 	gameListRepository := mocks.NewMockGameListRepository(mockCtrl)
+	accessCache := mocks.NewMockAccessCache(mockCtrl)
+	permissionGrantRepository := mocks.NewMockPermissionGrantRepository(mockCtrl)
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 
-	listItemService := service.NewListItemService(listItemRepository, gameRepository, gameListRepository, logger)
+	policyEngine := policy.NewEngine(accessCache, permissionGrantRepository, logger)
+	listItemService := service.NewListItemService(listItemRepository, gameRepository, gameListRepository, policyEngine, metrics.NewMetrics(), logger)
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -132,7 +170,10 @@ func TestListItemService_DeleteGameFromList(t *testing.T) {
 
 	t.Run("should return error when user is not authorized", func(t *testing.T) {
 		gameRepository.EXPECT().Find(ctx, gameID).Return(&entities.Game{}, nil)
-		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{UserID: uuid.New()}, nil)
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, UserID: uuid.New()}, nil)
+		permissionGrantRepository.EXPECT().
+			FindForSubject(ctx, userID, entities.ResourceGameList, []entities.PermissionAction{entities.PermissionEdit}).
+			Return(nil, nil)
 
 		err := listItemService.DeleteGameFromList(ctx, gameID, gameListID, userID)
 
@@ -159,9 +200,12 @@ func TestListItemService_UpdateGameFromList(t *testing.T) {
 	listItemRepository := mocks.NewMockListItemRepository(mockCtrl)
 	gameRepository := mocks.NewMockGameRepository(mockCtrl)
 	gameListRepository := mocks.NewMockGameListRepository(mockCtrl)
+	accessCache := mocks.NewMockAccessCache(mockCtrl)
+	permissionGrantRepository := mocks.NewMockPermissionGrantRepository(mockCtrl)
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 
-	listItemService := service.NewListItemService(listItemRepository, gameRepository, gameListRepository, logger)
+	policyEngine := policy.NewEngine(accessCache, permissionGrantRepository, logger)
+	listItemService := service.NewListItemService(listItemRepository, gameRepository, gameListRepository, policyEngine, metrics.NewMetrics(), logger)
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -201,7 +245,10 @@ func TestListItemService_UpdateGameFromList(t *testing.T) {
 
 	t.Run("should return error when user is not authorized", func(t *testing.T) {
 		gameRepository.EXPECT().Find(ctx, gameID).Return(&entities.Game{}, nil)
-		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{UserID: uuid.New()}, nil)
+		gameListRepository.EXPECT().Find(ctx, gameListID).Return(&entities.GameList{ID: gameListID, UserID: uuid.New()}, nil)
+		permissionGrantRepository.EXPECT().
+			FindForSubject(ctx, userID, entities.ResourceGameList, []entities.PermissionAction{entities.PermissionEdit}).
+			Return(nil, nil)
 
 		err := listItemService.UpdateGameFromList(ctx, gameID, gameListID, userID, rating, status)
 
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Bromolima/my-game-list/config"
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/repository"
+)
+
+func accountThrottleKey(email string) string {
+	return entities.LoginThrottleAccountPrefix + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipThrottleKey(ipAddress string) string {
+	return entities.LoginThrottleIPPrefix + ipAddress
+}
+
+// isLoginLocked reports whether the account or the IP address is currently
+// locked out after too many failed attempts.
+func isLoginLocked(ctx context.Context, loginThrottleRepository repository.LoginThrottleRepository, email, ipAddress string, now time.Time) (bool, error) {
+	for _, key := range []string{accountThrottleKey(email), ipThrottleKey(ipAddress)} {
+		loginThrottle, err := loginThrottleRepository.Find(ctx, key)
+		if err != nil {
+			return false, err
+		}
+
+		if loginThrottle != nil && loginThrottle.LockedUntil != nil && now.Before(*loginThrottle.LockedUntil) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// recordLoginFailure counts a failed attempt for the account and the IP
+// address. Once a counter reaches its limit every further failure locks it
+// out, doubling the lockout each time up to LOGIN_MAX_LOCKOUT.
+func recordLoginFailure(ctx context.Context, loginThrottleRepository repository.LoginThrottleRepository, email, ipAddress string, now time.Time) error {
+	protection := config.Env.LoginProtection
+	limits := map[string]int{
+		accountThrottleKey(email): protection.MaxAccountFailures,
+		ipThrottleKey(ipAddress):  protection.MaxIPFailures,
+	}
+
+	for key, limit := range limits {
+		loginThrottle, err := loginThrottleRepository.RecordFailure(ctx, key, now, protection.FailureWindow)
+		if err != nil {
+			return err
+		}
+
+		if limit <= 0 || loginThrottle.Failures < limit {
+			continue
+		}
+
+		if err := loginThrottleRepository.Lock(ctx, key, now.Add(lockoutDuration(loginThrottle.Failures-limit))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func lockoutDuration(exceeded int) time.Duration {
+	protection := config.Env.LoginProtection
+
+	lockout := protection.BaseLockout
+	for range exceeded {
+		if lockout >= protection.MaxLockout {
+			break
+		}
+		lockout *= 2
+	}
+
+	return min(lockout, protection.MaxLockout)
+}
+
+func resetAccountThrottle(ctx context.Context, loginThrottleRepository repository.LoginThrottleRepository, email string) error {
+	return loginThrottleRepository.Reset(ctx, accountThrottleKey(email))
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Bromolima/my-game-list/config"
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/factory"
+	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/metrics"
+	"github.com/Bromolima/my-game-list/internal/oidc"
+	"github.com/Bromolima/my-game-list/internal/repository"
+	"github.com/Bromolima/my-game-list/internal/security"
+	"github.com/Bromolima/my-game-list/internal/token"
+	"github.com/Bromolima/my-game-list/logger"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+)
+
+const (
+	oidcStateSize        = 32
+	oidcPasswordSize     = 32
+	maxUsernameLength    = 100
+	oidcFallbackUsername = "player"
+)
+
+type OIDCService interface {
+	BeginLogin(ctx context.Context, providerName string) (*entities.OIDCAuthorization, *resterr.RestErr)
+	CompleteLogin(ctx context.Context, providerName, code, state, boundState, ipAddress, userAgent string) (*entities.LoginResult, *resterr.RestErr)
+}
+
+type oidcService struct {
+	client                 oidc.Client
+	loginStateRepository   repository.OIDCLoginStateRepository
+	userIdentityRepository repository.UserIdentityRepository
+	userRepository         repository.UserRepository
+	gameListRepository     repository.GameListRepository
+	twoFactorRepository    repository.TwoFactorRepository
+	sessionRepository      repository.SessionRepository
+	tokenService           token.JwtService
+	auditService           AuditService
+	metrics                *metrics.Metrics
+	logger                 *slog.Logger
+}
+
+func NewOIDCService(
+	client oidc.Client,
+	loginStateRepository repository.OIDCLoginStateRepository,
+	userIdentityRepository repository.UserIdentityRepository,
+	userRepository repository.UserRepository,
+	gameListRepository repository.GameListRepository,
+	twoFactorRepository repository.TwoFactorRepository,
+	sessionRepository repository.SessionRepository,
+	tokenService token.JwtService,
+	auditService AuditService,
+	metrics *metrics.Metrics,
+	logger *slog.Logger,
+) OIDCService {
+	return &oidcService{
+		client:                 client,
+		loginStateRepository:   loginStateRepository,
+		userIdentityRepository: userIdentityRepository,
+		userRepository:         userRepository,
+		gameListRepository:     gameListRepository,
+		twoFactorRepository:    twoFactorRepository,
+		sessionRepository:      sessionRepository,
+		tokenService:           tokenService,
+		auditService:           auditService,
+		metrics:                metrics,
+		logger:                 logger.With(slog.String("service", "oidc")),
+	}
+}
+
+// BeginLogin stores a one-time state with the nonce and PKCE verifier of the
+// login and returns the provider URL the user must be redirected to. The
+// plain state is also returned so it can be bound to the browser in a cookie.
+func (s *oidcService) BeginLogin(ctx context.Context, providerName string) (_ *entities.OIDCAuthorization, restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "OIDCService", "BeginLogin")
+	defer endSpan(span, &restErr)
+
+	now := time.Now()
+	if err := s.loginStateRepository.DeleteExpired(ctx, now); err != nil {
+		log.Error("Failed to delete expired login states", slog.String("error", err.Error()))
+	}
+
+	state, err := security.GenerateRandomToken(oidcStateSize)
+	if err != nil {
+		log.Error("Failed to generate login state", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while starting the login")
+	}
+
+	nonce, err := security.GenerateRandomToken(oidcStateSize)
+	if err != nil {
+		log.Error("Failed to generate login nonce", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while starting the login")
+	}
+
+	codeVerifier := oauth2.GenerateVerifier()
+
+	authCodeURL, err := s.client.AuthCodeURL(ctx, providerName, state, nonce, codeVerifier)
+	if err != nil {
+		if errors.Is(err, oidc.ErrUnknownProvider) {
+			log.Warn("The requested identity provider is not configured", slog.String("provider", providerName))
+			return nil, resterr.NewNotFoundError("The requested identity provider is not configured")
+		}
+
+		log.Error("Failed to build authorization URL", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while starting the login")
+	}
+
+	expiresAt := now.Add(config.Env.OIDC.StateDuration)
+	loginState := factory.NewOIDCLoginState(security.HashToken(state), providerName, nonce, codeVerifier, expiresAt)
+	if err := s.loginStateRepository.Create(ctx, loginState); err != nil {
+		log.Error("Failed to create login state in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while starting the login")
+	}
+
+	return factory.NewOIDCAuthorization(authCodeURL, state, expiresAt), nil
+}
+
+// CompleteLogin exchanges the authorization code, resolves the local user of
+// the identity and logs them in like a password login would.
+func (s *oidcService) CompleteLogin(ctx context.Context, providerName, code, state, boundState, ipAddress, userAgent string) (_ *entities.LoginResult, restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "OIDCService", "CompleteLogin")
+	defer endSpan(span, &restErr)
+
+	if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(boundState)) != 1 {
+		log.Warn("The login state does not match the one bound to the browser")
+		return nil, resterr.NewUnauthorizedError("The login state is invalid or has expired")
+	}
+
+	loginState, err := s.loginStateRepository.Consume(ctx, security.HashToken(state))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("The login state was not found")
+			return nil, resterr.NewUnauthorizedError("The login state is invalid or has expired")
+		}
+
+		log.Error("Failed to consume login state in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while completing the login")
+	}
+
+	if loginState.Provider != providerName || time.Now().After(loginState.ExpiresAt) {
+		log.Warn("The login state expired or belongs to another provider")
+		return nil, resterr.NewUnauthorizedError("The login state is invalid or has expired")
+	}
+
+	identity, err := s.client.Exchange(ctx, providerName, code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		log.Warn("The identity provider login could not be completed", slog.String("error", err.Error()))
+		return nil, resterr.NewUnauthorizedError("The identity provider login could not be completed")
+	}
+
+	user, restErr := s.resolveUser(ctx, providerName, identity)
+	if restErr != nil {
+		return nil, restErr
+	}
+
+	result, restErr := completeLogin(ctx, log, s.twoFactorRepository, s.sessionRepository, s.tokenService, user, ipAddress, userAgent)
+	if restErr != nil {
+		return nil, restErr
+	}
+
+	recordLogin(ctx, s.auditService, s.metrics, user, result)
+	return result, nil
+}
+
+// resolveUser returns the user already linked to the identity. Otherwise the
+// identity is linked to the user with the same email, which is only trusted
+// when both the provider and the account verified it, or to a new user
+// created for it.
+func (s *oidcService) resolveUser(ctx context.Context, providerName string, identity *oidc.Identity) (*entities.User, *resterr.RestErr) {
+	log := logger.Scoped(ctx, s.logger).With(slog.String("func", "resolveUser"))
+
+	userIdentity, err := s.userIdentityRepository.FindByProviderSubject(ctx, providerName, identity.Subject)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error("Failed to find user identity in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while finding the user")
+	}
+
+	if userIdentity != nil {
+		user, err := s.userRepository.Find(ctx, userIdentity.UserID)
+		if err != nil {
+			log.Error("Failed to find linked user in database", slog.String("error", err.Error()))
+			return nil, resterr.NewInternalServerErr("An error occurred while finding the user")
+		}
+
+		return user, nil
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		log.Warn("The identity provider did not share a verified email")
+		return nil, resterr.NewForbiddenError("The identity provider did not share a verified email address")
+	}
+
+	user, err := s.userRepository.FindByEmail(ctx, identity.Email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error("Failed to find user by email", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while finding the user")
+	}
+
+	if user == nil {
+		var restErr *resterr.RestErr
+		if user, restErr = s.createUser(ctx, identity); restErr != nil {
+			return nil, restErr
+		}
+	} else if !user.EmailVerified {
+		// Whoever registered the account may not own the address, and linking
+		// it would hand them an account the provider vouched for.
+		log.Warn("The account with the provider email is not verified")
+		return nil, resterr.NewConflictErr("An account with this email already exists, log in with your password and verify your email before signing in with the identity provider")
+	}
+
+	if restErr := s.linkIdentity(ctx, user, providerName, identity); restErr != nil {
+		return nil, restErr
+	}
+
+	return user, nil
+}
+
+func (s *oidcService) createUser(ctx context.Context, identity *oidc.Identity) (*entities.User, *resterr.RestErr) {
+	log := logger.Scoped(ctx, s.logger).With(slog.String("func", "createUser"))
+
+	// The account has no usable password until the user sets one through the
+	// password reset flow.
+	password, err := security.GenerateRandomToken(oidcPasswordSize)
+	if err != nil {
+		log.Error("Failed to generate password", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while creating the user")
+	}
+
+	hashedPassword, err := security.HashPassword(password)
+	if err != nil {
+		log.Error("Failed to hash password", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while hashing the password")
+	}
+
+	now := time.Now()
+	user := factory.NewUser(identity.Email, hashedPassword, oidcUsername(identity), identity.Picture, entities.RoleUserID)
+	user.EmailVerified = true
+	user.EmailVerifiedAt = &now
+	if err := s.userRepository.Create(ctx, user); err != nil {
+		log.Error("Failed to create user in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while creating the user")
+	}
+
+	if err := s.gameListRepository.Create(ctx, factory.NewDefaultGameList(user.ID)); err != nil {
+		log.Error("Failed to create default game list in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while creating the user")
+	}
+
+	s.metrics.RecordRegistration()
+	return user, nil
+}
+
+func (s *oidcService) linkIdentity(ctx context.Context, user *entities.User, providerName string, identity *oidc.Identity) *resterr.RestErr {
+	log := logger.Scoped(ctx, s.logger).With(slog.String("func", "linkIdentity"))
+
+	userIdentity := factory.NewUserIdentity(user.ID, providerName, identity.Subject, identity.Email)
+	if err := s.userIdentityRepository.Create(ctx, userIdentity); err != nil {
+		log.Error("Failed to create user identity in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while linking the identity")
+	}
+
+	return nil
+}
+
+func oidcUsername(identity *oidc.Identity) string {
+	username := identity.PreferredUsername
+	if username == "" {
+		username = identity.Name
+	}
+
+	if username == "" {
+		username, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	if username == "" {
+		username = oidcFallbackUsername
+	}
+
+	if runes := []rune(username); len(runes) > maxUsernameLength {
+		username = string(runes[:maxUsernameLength])
+	}
+
+	return username
+}
@@ -0,0 +1,320 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Bromolima/my-game-list/config"
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/metrics"
+	"github.com/Bromolima/my-game-list/internal/oidc"
+	"github.com/Bromolima/my-game-list/internal/oidc/oidctest"
+	"github.com/Bromolima/my-game-list/internal/service"
+	"github.com/Bromolima/my-game-list/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+const oidcProviderName = "fake"
+
+func setupOIDCIssuer(t *testing.T) *oidctest.Issuer {
+	issuer, err := oidctest.NewIssuer()
+	require.NoError(t, err)
+	t.Cleanup(issuer.Close)
+
+	config.Env.OIDC = config.OIDC{
+		StateDuration: 10 * time.Minute,
+		Providers: []config.OIDCProvider{{
+			Name:        oidcProviderName,
+			IssuerURL:   issuer.URL(),
+			ClientID:    "my-game-list",
+			RedirectURL: "http://localhost:8080/auth/oidc/fake/callback",
+			Scopes:      []string{"openid", "email", "profile"},
+		}},
+	}
+	t.Cleanup(func() { config.Env.OIDC = config.OIDC{} })
+
+	return issuer
+}
+
+func TestOIDCService_BeginLogin(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	setupOIDCIssuer(t)
+
+	loginStateRepository := mocks.NewMockOIDCLoginStateRepository(mockCtrl)
+	userIdentityRepository := mocks.NewMockUserIdentityRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	gameListRepository := mocks.NewMockGameListRepository(mockCtrl)
+	twoFactorRepository := mocks.NewMockTwoFactorRepository(mockCtrl)
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	tokenService := mocks.NewMockJwtService(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	oidcService := service.NewOIDCService(oidc.NewClient(logger), loginStateRepository, userIdentityRepository, userRepository, gameListRepository, twoFactorRepository, sessionRepository, tokenService, auditService, metrics.NewMetrics(), logger)
+
+	ctx := context.Background()
+
+	t.Run("should begin login successfully", func(t *testing.T) {
+		var loginState *entities.OIDCLoginState
+		loginStateRepository.EXPECT().DeleteExpired(ctx, gomock.Any()).Return(nil)
+		loginStateRepository.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, state *entities.OIDCLoginState) error {
+			loginState = state
+			return nil
+		})
+
+		authorization, err := oidcService.BeginLogin(ctx, oidcProviderName)
+
+		assert.Nil(t, err)
+		assert.Contains(t, authorization.URL, "code_challenge_method=S256")
+		assert.Contains(t, authorization.URL, "nonce="+loginState.Nonce)
+		assert.NotEqual(t, authorization.State, loginState.State)
+		assert.Equal(t, oidcProviderName, loginState.Provider)
+	})
+
+	t.Run("should return error when provider is not configured", func(t *testing.T) {
+		loginStateRepository.EXPECT().DeleteExpired(ctx, gomock.Any()).Return(nil)
+
+		authorization, err := oidcService.BeginLogin(ctx, "unknown")
+
+		assert.NotNil(t, err)
+		assert.Nil(t, authorization)
+		assert.Equal(t, "The requested identity provider is not configured", err.Message)
+	})
+
+	t.Run("should return error when Create fails", func(t *testing.T) {
+		loginStateRepository.EXPECT().DeleteExpired(ctx, gomock.Any()).Return(nil)
+		loginStateRepository.EXPECT().Create(ctx, gomock.Any()).Return(errors.New("database error"))
+
+		authorization, err := oidcService.BeginLogin(ctx, oidcProviderName)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, authorization)
+		assert.Equal(t, "An error occurred while starting the login", err.Message)
+	})
+}
+
+func TestOIDCService_CompleteLogin(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	issuer := setupOIDCIssuer(t)
+
+	loginStateRepository := mocks.NewMockOIDCLoginStateRepository(mockCtrl)
+	userIdentityRepository := mocks.NewMockUserIdentityRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	gameListRepository := mocks.NewMockGameListRepository(mockCtrl)
+	twoFactorRepository := mocks.NewMockTwoFactorRepository(mockCtrl)
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	tokenService := mocks.NewMockJwtService(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	oidcService := service.NewOIDCService(oidc.NewClient(logger), loginStateRepository, userIdentityRepository, userRepository, gameListRepository, twoFactorRepository, sessionRepository, tokenService, auditService, metrics.NewMetrics(), logger)
+
+	ctx := context.Background()
+	ipAddress := "127.0.0.1"
+	userAgent := "test-agent"
+	identity := oidc.Identity{
+		Subject:           "subject-1",
+		Email:             "player@example.com",
+		EmailVerified:     true,
+		PreferredUsername: "player_one",
+	}
+
+	// beginLogin starts a login against the fake issuer, approves it for the
+	// identity and returns the callback parameters with the stored state.
+	beginLogin := func(t *testing.T, identity oidc.Identity) (string, string, *entities.OIDCLoginState) {
+		var loginState *entities.OIDCLoginState
+		loginStateRepository.EXPECT().DeleteExpired(ctx, gomock.Any()).Return(nil)
+		loginStateRepository.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, state *entities.OIDCLoginState) error {
+			loginState = state
+			return nil
+		})
+
+		authorization, restErr := oidcService.BeginLogin(ctx, oidcProviderName)
+		require.Nil(t, restErr)
+
+		code, state, err := issuer.Authorize(authorization.URL, identity)
+		require.NoError(t, err)
+		require.Equal(t, authorization.State, state)
+
+		return code, state, loginState
+	}
+
+	expectSession := func(user *entities.User) {
+		twoFactorRepository.EXPECT().Find(ctx, user.ID).Return(nil, gorm.ErrRecordNotFound)
+		tokenService.EXPECT().GenerateToken(user, gomock.Any()).Return("access-token", nil)
+		sessionRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+	}
+
+	t.Run("should log in a user already linked to the identity", func(t *testing.T) {
+		user := &entities.User{ID: uuid.New(), Email: identity.Email, EmailVerified: true}
+		code, state, loginState := beginLogin(t, identity)
+		loginStateRepository.EXPECT().Consume(ctx, loginState.State).Return(loginState, nil)
+		userIdentityRepository.EXPECT().FindByProviderSubject(ctx, oidcProviderName, identity.Subject).Return(&entities.UserIdentity{UserID: user.ID}, nil)
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		expectSession(user)
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		loginResult, err := oidcService.CompleteLogin(ctx, oidcProviderName, code, state, state, ipAddress, userAgent)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "access-token", loginResult.AuthTokens.AccessToken)
+	})
+
+	t.Run("should link the identity to the user with the same verified email", func(t *testing.T) {
+		user := &entities.User{ID: uuid.New(), Email: identity.Email, EmailVerified: true}
+		code, state, loginState := beginLogin(t, identity)
+		loginStateRepository.EXPECT().Consume(ctx, loginState.State).Return(loginState, nil)
+		userIdentityRepository.EXPECT().FindByProviderSubject(ctx, oidcProviderName, identity.Subject).Return(nil, gorm.ErrRecordNotFound)
+		userRepository.EXPECT().FindByEmail(ctx, identity.Email).Return(user, nil)
+		userIdentityRepository.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, userIdentity *entities.UserIdentity) error {
+			assert.Equal(t, user.ID, userIdentity.UserID)
+			assert.Equal(t, identity.Subject, userIdentity.Subject)
+			return nil
+		})
+		expectSession(user)
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		loginResult, err := oidcService.CompleteLogin(ctx, oidcProviderName, code, state, state, ipAddress, userAgent)
+
+		assert.Nil(t, err)
+		assert.NotNil(t, loginResult.AuthTokens)
+	})
+
+	t.Run("should return error when the account with the same email is not verified", func(t *testing.T) {
+		user := &entities.User{ID: uuid.New(), Email: identity.Email}
+		code, state, loginState := beginLogin(t, identity)
+		loginStateRepository.EXPECT().Consume(ctx, loginState.State).Return(loginState, nil)
+		userIdentityRepository.EXPECT().FindByProviderSubject(ctx, oidcProviderName, identity.Subject).Return(nil, gorm.ErrRecordNotFound)
+		userRepository.EXPECT().FindByEmail(ctx, identity.Email).Return(user, nil)
+
+		loginResult, err := oidcService.CompleteLogin(ctx, oidcProviderName, code, state, state, ipAddress, userAgent)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, loginResult)
+		assert.Equal(t, "An account with this email already exists, log in with your password and verify your email before signing in with the identity provider", err.Message)
+		assert.False(t, user.EmailVerified)
+	})
+
+	t.Run("should create a user with a default list when no account matches", func(t *testing.T) {
+		var createdUser *entities.User
+		code, state, loginState := beginLogin(t, identity)
+		loginStateRepository.EXPECT().Consume(ctx, loginState.State).Return(loginState, nil)
+		userIdentityRepository.EXPECT().FindByProviderSubject(ctx, oidcProviderName, identity.Subject).Return(nil, gorm.ErrRecordNotFound)
+		userRepository.EXPECT().FindByEmail(ctx, identity.Email).Return(nil, gorm.ErrRecordNotFound)
+		userRepository.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, user *entities.User) error {
+			createdUser = user
+			return nil
+		})
+		gameListRepository.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, gameList *entities.GameList) error {
+			assert.Equal(t, createdUser.ID, gameList.UserID)
+			assert.True(t, gameList.IsDefault)
+			return nil
+		})
+		userIdentityRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		twoFactorRepository.EXPECT().Find(ctx, gomock.Any()).Return(nil, gorm.ErrRecordNotFound)
+		tokenService.EXPECT().GenerateToken(gomock.Any(), gomock.Any()).Return("access-token", nil)
+		sessionRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		loginResult, err := oidcService.CompleteLogin(ctx, oidcProviderName, code, state, state, ipAddress, userAgent)
+
+		assert.Nil(t, err)
+		assert.NotNil(t, loginResult.AuthTokens)
+		assert.EqualValues(t, entities.RoleUserID, createdUser.RoleID)
+		assert.Equal(t, identity.PreferredUsername, createdUser.Username)
+		assert.True(t, createdUser.EmailVerified)
+	})
+
+	t.Run("should return a challenge when two-factor is enabled", func(t *testing.T) {
+		confirmedAt := time.Now()
+		user := &entities.User{ID: uuid.New(), Email: identity.Email, EmailVerified: true}
+		code, state, loginState := beginLogin(t, identity)
+		loginStateRepository.EXPECT().Consume(ctx, loginState.State).Return(loginState, nil)
+		userIdentityRepository.EXPECT().FindByProviderSubject(ctx, oidcProviderName, identity.Subject).Return(&entities.UserIdentity{UserID: user.ID}, nil)
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		twoFactorRepository.EXPECT().Find(ctx, user.ID).Return(&entities.TwoFactor{UserID: user.ID, ConfirmedAt: &confirmedAt}, nil)
+		tokenService.EXPECT().GenerateChallengeToken(user.ID).Return("challenge-token", nil)
+
+		loginResult, err := oidcService.CompleteLogin(ctx, oidcProviderName, code, state, state, ipAddress, userAgent)
+
+		assert.Nil(t, err)
+		assert.Nil(t, loginResult.AuthTokens)
+		assert.Equal(t, "challenge-token", loginResult.Challenge.Token)
+	})
+
+	t.Run("should return error when email is not verified by the provider", func(t *testing.T) {
+		unverifiedIdentity := identity
+		unverifiedIdentity.EmailVerified = false
+		code, state, loginState := beginLogin(t, unverifiedIdentity)
+		loginStateRepository.EXPECT().Consume(ctx, loginState.State).Return(loginState, nil)
+		userIdentityRepository.EXPECT().FindByProviderSubject(ctx, oidcProviderName, identity.Subject).Return(nil, gorm.ErrRecordNotFound)
+
+		loginResult, err := oidcService.CompleteLogin(ctx, oidcProviderName, code, state, state, ipAddress, userAgent)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, loginResult)
+		assert.Equal(t, "The identity provider did not share a verified email address", err.Message)
+	})
+
+	t.Run("should return error when state does not match the browser", func(t *testing.T) {
+		code, state, _ := beginLogin(t, identity)
+
+		loginResult, err := oidcService.CompleteLogin(ctx, oidcProviderName, code, state, "another-state", ipAddress, userAgent)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, loginResult)
+		assert.Equal(t, "The login state is invalid or has expired", err.Message)
+	})
+
+	t.Run("should return error when state was already used", func(t *testing.T) {
+		code, state, loginState := beginLogin(t, identity)
+		loginStateRepository.EXPECT().Consume(ctx, loginState.State).Return(nil, gorm.ErrRecordNotFound)
+
+		loginResult, err := oidcService.CompleteLogin(ctx, oidcProviderName, code, state, state, ipAddress, userAgent)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, loginResult)
+		assert.Equal(t, "The login state is invalid or has expired", err.Message)
+	})
+
+	t.Run("should return error when code verifier does not match", func(t *testing.T) {
+		code, state, loginState := beginLogin(t, identity)
+		tamperedState := *loginState
+		tamperedState.CodeVerifier = strings.Repeat("a", 43)
+		loginStateRepository.EXPECT().Consume(ctx, loginState.State).Return(&tamperedState, nil)
+
+		loginResult, err := oidcService.CompleteLogin(ctx, oidcProviderName, code, state, state, ipAddress, userAgent)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, loginResult)
+		assert.Equal(t, "The identity provider login could not be completed", err.Message)
+	})
+
+	t.Run("should return error when nonce does not match", func(t *testing.T) {
+		code, state, loginState := beginLogin(t, identity)
+		tamperedState := *loginState
+		tamperedState.Nonce = "another-nonce"
+		loginStateRepository.EXPECT().Consume(ctx, loginState.State).Return(&tamperedState, nil)
+
+		loginResult, err := oidcService.CompleteLogin(ctx, oidcProviderName, code, state, state, ipAddress, userAgent)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, loginResult)
+		assert.Equal(t, "The identity provider login could not be completed", err.Message)
+	})
+}
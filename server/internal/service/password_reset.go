@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/Bromolima/my-game-list/config"
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/factory"
+	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/lifecycle"
+	"github.com/Bromolima/my-game-list/internal/mailer"
+	"github.com/Bromolima/my-game-list/internal/repository"
+	"github.com/Bromolima/my-game-list/internal/security"
+	"github.com/Bromolima/my-game-list/logger"
+	"gorm.io/gorm"
+)
+
+const (
+	PasswordResetTokenDuration = time.Hour
+	passwordResetTokenSize     = 32
+	passwordResetQueueSize     = 100
+)
+
+type PasswordResetService interface {
+	RequestReset(ctx context.Context, email string) *resterr.RestErr
+	ResetPassword(ctx context.Context, resetToken, password string) *resterr.RestErr
+}
+
+type passwordResetService struct {
+	userRepository                repository.UserRepository
+	userTokenRepository           repository.UserTokenRepository
+	sessionRepository             repository.SessionRepository
+	personalAccessTokenRepository repository.PersonalAccessTokenRepository
+	mailer                        mailer.Mailer
+	auditService                  AuditService
+	requests                      chan passwordResetRequest
+	logger                        *slog.Logger
+}
+
+type passwordResetRequest struct {
+	ctx   context.Context
+	email string
+}
+
+// NewPasswordResetService registers the worker sending the requested resets,
+// running from the application start to its shutdown.
+func NewPasswordResetService(
+	userRepository repository.UserRepository,
+	userTokenRepository repository.UserTokenRepository,
+	sessionRepository repository.SessionRepository,
+	personalAccessTokenRepository repository.PersonalAccessTokenRepository,
+	mailer mailer.Mailer,
+	auditService AuditService,
+	lc *lifecycle.Lifecycle,
+	logger *slog.Logger,
+) PasswordResetService {
+	s := &passwordResetService{
+		userRepository:                userRepository,
+		userTokenRepository:           userTokenRepository,
+		sessionRepository:             sessionRepository,
+		personalAccessTokenRepository: personalAccessTokenRepository,
+		mailer:                        mailer,
+		auditService:                  auditService,
+		requests:                      make(chan passwordResetRequest, passwordResetQueueSize),
+		logger:                        logger.With(slog.String("service", "passwordReset")),
+	}
+
+	lc.Append(lifecycle.Worker("password reset mailer", s.run))
+	return s
+}
+
+// RequestReset queues the reset and returns at once, whether or not the email
+// belongs to an account and whether or not the email can be sent, so neither
+// the response nor its timing tells which addresses are registered.
+func (s *passwordResetService) RequestReset(ctx context.Context, email string) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "PasswordResetService", "RequestReset")
+	defer endSpan(span, &restErr)
+
+	// The request context is cancelled once the response is sent, its values
+	// are kept to correlate the logs and traces of the reset.
+	select {
+	case s.requests <- passwordResetRequest{ctx: context.WithoutCancel(ctx), email: email}:
+	default:
+		log.Error("The password reset queue is full, dropping the request")
+	}
+
+	return nil
+}
+
+// run sends the queued resets until the context is cancelled, then sends the
+// ones still queued before returning.
+func (s *passwordResetService) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			for {
+				select {
+				case request := <-s.requests:
+					s.sendReset(request.ctx, request.email)
+				default:
+					return
+				}
+			}
+		case request := <-s.requests:
+			s.sendReset(request.ctx, request.email)
+		}
+	}
+}
+
+// sendReset sends a reset link when the email belongs to an account.
+func (s *passwordResetService) sendReset(ctx context.Context, email string) {
+	log := logger.Scoped(ctx, s.logger).With(slog.String("func", "sendReset"))
+
+	user, err := s.userRepository.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("Password reset requested for an unknown email")
+			return
+		}
+
+		log.Error("Failed to find user by email", slog.String("error", err.Error()))
+		return
+	}
+
+	if err := s.userTokenRepository.InvalidateByUserID(ctx, user.ID, entities.UserTokenPurposePasswordReset); err != nil {
+		log.Error("Failed to invalidate previous reset tokens in database", slog.String("error", err.Error()))
+		return
+	}
+
+	resetToken, err := security.GenerateRandomToken(passwordResetTokenSize)
+	if err != nil {
+		log.Error("Failed to generate reset token", slog.String("error", err.Error()))
+		return
+	}
+
+	userToken := factory.NewUserToken(
+		user.ID,
+		user.Email,
+		entities.UserTokenPurposePasswordReset,
+		security.HashToken(resetToken),
+		time.Now().Add(PasswordResetTokenDuration),
+	)
+	if err := s.userTokenRepository.Create(ctx, userToken); err != nil {
+		log.Error("Failed to create reset token in database", slog.String("error", err.Error()))
+		return
+	}
+
+	resetURL := config.Env.AppURL + "/reset-password?token=" + url.QueryEscape(resetToken)
+	if err := s.mailer.Send(ctx, mailer.NewPasswordResetMessage(user.Email, resetURL, PasswordResetTokenDuration)); err != nil {
+		log.Error("Failed to send password reset email", slog.String("error", err.Error()))
+	}
+}
+
+func (s *passwordResetService) ResetPassword(ctx context.Context, resetToken, password string) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "PasswordResetService", "ResetPassword")
+	defer endSpan(span, &restErr)
+
+	userToken, err := s.userTokenRepository.FindByToken(ctx, security.HashToken(resetToken), entities.UserTokenPurposePasswordReset)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("Unknown reset token provided")
+			return resterr.NewBadRequestError("The reset token is invalid or has expired")
+		}
+
+		log.Error("Failed to find reset token in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while resetting the password")
+	}
+
+	if userToken.UsedAt != nil || time.Now().After(userToken.ExpiresAt) {
+		log.Warn("Used or expired reset token provided")
+		return resterr.NewBadRequestError("The reset token is invalid or has expired")
+	}
+
+	user, err := s.userRepository.Find(ctx, userToken.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("The owner of the reset token was not found")
+			return resterr.NewBadRequestError("The reset token is invalid or has expired")
+		}
+
+		log.Error("Failed to find user in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while resetting the password")
+	}
+
+	used, err := s.userTokenRepository.MarkUsed(ctx, userToken.ID)
+	if err != nil {
+		log.Error("Failed to mark reset token as used in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while resetting the password")
+	}
+
+	if !used {
+		log.Warn("Reset token was used concurrently")
+		return resterr.NewBadRequestError("The reset token is invalid or has expired")
+	}
+
+	hashedPassword, err := security.HashPassword(password)
+	if err != nil {
+		log.Error("Failed to hash password", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while hashing the password")
+	}
+
+	user.Password = hashedPassword
+	if err := s.userRepository.Update(ctx, user); err != nil {
+		log.Error("Failed to update user password in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while resetting the password")
+	}
+
+	if err := s.sessionRepository.RevokeAllByUserID(ctx, user.ID); err != nil {
+		log.Error("Failed to revoke user sessions in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while revoking the sessions")
+	}
+
+	// The reset is how an account is recovered, the API keys an attacker may
+	// have created must stop working too.
+	if err := s.personalAccessTokenRepository.RevokeAllByUserID(ctx, user.ID); err != nil {
+		log.Error("Failed to revoke user personal access tokens in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while revoking the personal access tokens")
+	}
+
+	s.auditService.Record(ctx, entities.AuditPasswordReset, entities.AuditTargetUser, user.ID.String(), nil, nil)
+	return nil
+}
@@ -0,0 +1,228 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/lifecycle"
+	"github.com/Bromolima/my-game-list/internal/mailer"
+	"github.com/Bromolima/my-game-list/internal/security"
+	"github.com/Bromolima/my-game-list/internal/service"
+	"github.com/Bromolima/my-game-list/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestPasswordResetService_RequestReset(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	userTokenRepository := mocks.NewMockUserTokenRepository(mockCtrl)
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	personalAccessTokenRepository := mocks.NewMockPersonalAccessTokenRepository(mockCtrl)
+	mailSender := mocks.NewMockMailer(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	lc := lifecycle.NewLifecycle(logger)
+
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	passwordResetService := service.NewPasswordResetService(userRepository, userTokenRepository, sessionRepository, personalAccessTokenRepository, mailSender, auditService, lc, logger)
+
+	ctx := context.Background()
+	user := &entities.User{ID: uuid.New(), Email: "player@example.com"}
+
+	// The resets are sent by the worker, stopping the lifecycle waits for the
+	// queued ones to be sent.
+	t.Run("should send reset email successfully", func(t *testing.T) {
+		userRepository.EXPECT().FindByEmail(gomock.Any(), user.Email).Return(user, nil)
+		userTokenRepository.EXPECT().InvalidateByUserID(gomock.Any(), user.ID, entities.UserTokenPurposePasswordReset).Return(nil)
+		userTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, userToken *entities.UserToken) error {
+			assert.Equal(t, user.ID, userToken.UserID)
+			assert.Equal(t, entities.UserTokenPurposePasswordReset, userToken.Purpose)
+			assert.Len(t, userToken.Token, 64)
+			return nil
+		})
+		mailSender.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, message *mailer.Message) error {
+			assert.Equal(t, user.Email, message.To)
+			return nil
+		})
+		assert.NoError(t, lc.Start(ctx))
+
+		err := passwordResetService.RequestReset(ctx, user.Email)
+
+		assert.Nil(t, err)
+		assert.NoError(t, lc.Stop(ctx))
+	})
+
+	t.Run("should succeed without sending email when email is unknown", func(t *testing.T) {
+		userRepository.EXPECT().FindByEmail(gomock.Any(), user.Email).Return(nil, gorm.ErrRecordNotFound)
+		assert.NoError(t, lc.Start(ctx))
+
+		err := passwordResetService.RequestReset(ctx, user.Email)
+
+		assert.Nil(t, err)
+		assert.NoError(t, lc.Stop(ctx))
+	})
+
+	t.Run("should succeed before the reset is processed", func(t *testing.T) {
+		err := passwordResetService.RequestReset(ctx, user.Email)
+
+		assert.Nil(t, err)
+
+		userRepository.EXPECT().FindByEmail(gomock.Any(), user.Email).Return(nil, gorm.ErrRecordNotFound)
+		assert.NoError(t, lc.Start(ctx))
+		assert.NoError(t, lc.Stop(ctx))
+	})
+
+	t.Run("should not send email when Create fails", func(t *testing.T) {
+		userRepository.EXPECT().FindByEmail(gomock.Any(), user.Email).Return(user, nil)
+		userTokenRepository.EXPECT().InvalidateByUserID(gomock.Any(), user.ID, entities.UserTokenPurposePasswordReset).Return(nil)
+		userTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("database error"))
+		assert.NoError(t, lc.Start(ctx))
+
+		err := passwordResetService.RequestReset(ctx, user.Email)
+
+		assert.Nil(t, err)
+		assert.NoError(t, lc.Stop(ctx))
+	})
+
+	t.Run("should not return error when Send fails", func(t *testing.T) {
+		userRepository.EXPECT().FindByEmail(gomock.Any(), user.Email).Return(user, nil)
+		userTokenRepository.EXPECT().InvalidateByUserID(gomock.Any(), user.ID, entities.UserTokenPurposePasswordReset).Return(nil)
+		userTokenRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+		mailSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(errors.New("smtp error"))
+		assert.NoError(t, lc.Start(ctx))
+
+		err := passwordResetService.RequestReset(ctx, user.Email)
+
+		assert.Nil(t, err)
+		assert.NoError(t, lc.Stop(ctx))
+	})
+}
+
+func TestPasswordResetService_ResetPassword(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	userTokenRepository := mocks.NewMockUserTokenRepository(mockCtrl)
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	personalAccessTokenRepository := mocks.NewMockPersonalAccessTokenRepository(mockCtrl)
+	mailSender := mocks.NewMockMailer(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	passwordResetService := service.NewPasswordResetService(userRepository, userTokenRepository, sessionRepository, personalAccessTokenRepository, mailSender, auditService, lifecycle.NewLifecycle(logger), logger)
+
+	ctx := context.Background()
+	resetToken := "reset-token"
+	hashedToken := security.HashToken(resetToken)
+	password := "new-password@"
+	user := &entities.User{ID: uuid.New(), Email: "player@example.com"}
+	userToken := &entities.UserToken{
+		ID:        uuid.New(),
+		Purpose:   entities.UserTokenPurposePasswordReset,
+		Token:     hashedToken,
+		ExpiresAt: time.Now().Add(time.Hour),
+		UserID:    user.ID,
+	}
+
+	t.Run("should reset password successfully", func(t *testing.T) {
+		userTokenRepository.EXPECT().FindByToken(ctx, hashedToken, entities.UserTokenPurposePasswordReset).Return(userToken, nil)
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		userTokenRepository.EXPECT().MarkUsed(ctx, userToken.ID).Return(true, nil)
+		userRepository.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, updated *entities.User) error {
+			assert.True(t, security.CheckPassword(updated.Password, password))
+			return nil
+		})
+		sessionRepository.EXPECT().RevokeAllByUserID(ctx, user.ID).Return(nil)
+		personalAccessTokenRepository.EXPECT().RevokeAllByUserID(ctx, user.ID).Return(nil)
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		err := passwordResetService.ResetPassword(ctx, resetToken, password)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return error when token is unknown", func(t *testing.T) {
+		userTokenRepository.EXPECT().FindByToken(ctx, hashedToken, entities.UserTokenPurposePasswordReset).Return(nil, gorm.ErrRecordNotFound)
+
+		err := passwordResetService.ResetPassword(ctx, resetToken, password)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The reset token is invalid or has expired", err.Message)
+	})
+
+	t.Run("should return error when token has expired", func(t *testing.T) {
+		userTokenRepository.EXPECT().FindByToken(ctx, hashedToken, entities.UserTokenPurposePasswordReset).Return(&entities.UserToken{
+			ID:        userToken.ID,
+			ExpiresAt: time.Now().Add(-time.Minute),
+			UserID:    user.ID,
+		}, nil)
+
+		err := passwordResetService.ResetPassword(ctx, resetToken, password)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The reset token is invalid or has expired", err.Message)
+	})
+
+	t.Run("should return error when token was already used", func(t *testing.T) {
+		usedAt := time.Now()
+		userTokenRepository.EXPECT().FindByToken(ctx, hashedToken, entities.UserTokenPurposePasswordReset).Return(&entities.UserToken{
+			ID:        userToken.ID,
+			ExpiresAt: time.Now().Add(time.Hour),
+			UsedAt:    &usedAt,
+			UserID:    user.ID,
+		}, nil)
+
+		err := passwordResetService.ResetPassword(ctx, resetToken, password)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The reset token is invalid or has expired", err.Message)
+	})
+
+	t.Run("should return error when token is consumed concurrently", func(t *testing.T) {
+		userTokenRepository.EXPECT().FindByToken(ctx, hashedToken, entities.UserTokenPurposePasswordReset).Return(userToken, nil)
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		userTokenRepository.EXPECT().MarkUsed(ctx, userToken.ID).Return(false, nil)
+
+		err := passwordResetService.ResetPassword(ctx, resetToken, password)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The reset token is invalid or has expired", err.Message)
+	})
+
+	t.Run("should return error when Update fails", func(t *testing.T) {
+		userTokenRepository.EXPECT().FindByToken(ctx, hashedToken, entities.UserTokenPurposePasswordReset).Return(userToken, nil)
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		userTokenRepository.EXPECT().MarkUsed(ctx, userToken.ID).Return(true, nil)
+		userRepository.EXPECT().Update(ctx, gomock.Any()).Return(errors.New("database error"))
+
+		err := passwordResetService.ResetPassword(ctx, resetToken, password)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "An error occurred while resetting the password", err.Message)
+	})
+	t.Run("should return error when revoking the personal access tokens fails", func(t *testing.T) {
+		userTokenRepository.EXPECT().FindByToken(ctx, hashedToken, entities.UserTokenPurposePasswordReset).Return(userToken, nil)
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		userTokenRepository.EXPECT().MarkUsed(ctx, userToken.ID).Return(true, nil)
+		userRepository.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+		sessionRepository.EXPECT().RevokeAllByUserID(ctx, user.ID).Return(nil)
+		personalAccessTokenRepository.EXPECT().RevokeAllByUserID(ctx, user.ID).Return(errors.New("database error"))
+
+		err := passwordResetService.ResetPassword(ctx, resetToken, password)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "An error occurred while revoking the personal access tokens", err.Message)
+	})
+}
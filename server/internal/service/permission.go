@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/factory"
+	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PermissionService interface {
+	ListGrants(ctx context.Context) ([]*entities.PermissionGrant, *resterr.RestErr)
+	CreateGrant(
+		ctx context.Context,
+		userID *uuid.UUID,
+		roleID *uint,
+		action entities.PermissionAction,
+		resourceType entities.ResourceType,
+		resourceID *uuid.UUID,
+		attribute, value string,
+	) (*entities.PermissionGrant, *resterr.RestErr)
+	DeleteGrant(ctx context.Context, id uuid.UUID) *resterr.RestErr
+}
+
+type permissionService struct {
+	repository     repository.PermissionGrantRepository
+	userRepository repository.UserRepository
+	roleRepository repository.RoleRepository
+	logger         *slog.Logger
+}
+
+func NewPermissionService(
+	repository repository.PermissionGrantRepository,
+	userRepository repository.UserRepository,
+	roleRepository repository.RoleRepository,
+	logger *slog.Logger,
+) PermissionService {
+	return &permissionService{
+		repository:     repository,
+		userRepository: userRepository,
+		roleRepository: roleRepository,
+		logger:         logger.With(slog.String("service", "permission")),
+	}
+}
+
+func (s *permissionService) ListGrants(ctx context.Context) (_ []*entities.PermissionGrant, restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "PermissionService", "ListGrants")
+	defer endSpan(span, &restErr)
+
+	grants, err := s.repository.FindAll(ctx)
+	if err != nil {
+		log.Error("Failed to find permission grants in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while listing the permission grants")
+	}
+
+	return grants, nil
+}
+
+func (s *permissionService) CreateGrant(
+	ctx context.Context,
+	userID *uuid.UUID,
+	roleID *uint,
+	action entities.PermissionAction,
+	resourceType entities.ResourceType,
+	resourceID *uuid.UUID,
+	attribute, value string,
+) (_ *entities.PermissionGrant, restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "PermissionService", "CreateGrant")
+	defer endSpan(span, &restErr)
+
+	if (userID == nil) == (roleID == nil) {
+		log.Warn("Permission grant without exactly one subject")
+		return nil, resterr.NewBadRequestError("A permission grant must target either a user or a role")
+	}
+
+	if !action.IsValid() {
+		log.Warn("Unknown permission action provided", slog.String("action", string(action)))
+		return nil, resterr.NewBadRequestError("The provided action is not valid")
+	}
+
+	if !resourceType.IsValid() {
+		log.Warn("Unknown resource type provided", slog.String("resourceType", string(resourceType)))
+		return nil, resterr.NewBadRequestError("The provided resource type is not valid")
+	}
+
+	if attribute != "" && (resourceType != entities.ResourceGame || attribute != entities.ResourceAttributeGenre) {
+		log.Warn("Unsupported resource attribute provided", slog.String("attribute", attribute))
+		return nil, resterr.NewBadRequestError("The provided attribute is not supported for this resource type")
+	}
+
+	if (attribute == "") != (value == "") {
+		log.Warn("Permission grant attribute without value")
+		return nil, resterr.NewBadRequestError("An attribute and its value must be provided together")
+	}
+
+	if userID != nil {
+		if _, err := s.userRepository.Find(ctx, *userID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				log.Warn("The requested user was not found")
+				return nil, resterr.NewNotFoundError("The requested user was not found")
+			}
+
+			log.Error("Failed to find user in database", slog.String("error", err.Error()))
+			return nil, resterr.NewInternalServerErr("An error occurred while finding the user")
+		}
+	}
+
+	if roleID != nil {
+		if _, err := s.roleRepository.Find(ctx, *roleID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				log.Warn("The requested role was not found")
+				return nil, resterr.NewNotFoundError("The requested role was not found")
+			}
+
+			log.Error("Failed to find role in database", slog.String("error", err.Error()))
+			return nil, resterr.NewInternalServerErr("An error occurred while finding the role")
+		}
+	}
+
+	grant := factory.NewPermissionGrant(userID, roleID, action, resourceType, resourceID, attribute, value)
+	if err := s.repository.Create(ctx, grant); err != nil {
+		log.Error("Failed to create permission grant in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while creating the permission grant")
+	}
+
+	return grant, nil
+}
+
+func (s *permissionService) DeleteGrant(ctx context.Context, id uuid.UUID) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "PermissionService", "DeleteGrant")
+	defer endSpan(span, &restErr)
+
+	if _, err := s.repository.Find(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("The requested permission grant was not found")
+			return resterr.NewNotFoundError("The requested permission grant was not found")
+		}
+
+		log.Error("Failed to find permission grant in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while finding the permission grant")
+	}
+
+	if err := s.repository.Delete(ctx, id); err != nil {
+		log.Error("Failed to delete permission grant from database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while deleting the permission grant")
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/service"
+	"github.com/Bromolima/my-game-list/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestPermissionService_CreateGrant(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	permissionGrantRepository := mocks.NewMockPermissionGrantRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	roleRepository := mocks.NewMockRoleRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	permissionService := service.NewPermissionService(permissionGrantRepository, userRepository, roleRepository, logger)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	roleID := uint(3)
+
+	t.Run("should create curator grant for a role successfully", func(t *testing.T) {
+		roleRepository.EXPECT().Find(ctx, roleID).Return(&entities.Role{ID: roleID}, nil)
+		permissionGrantRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		grant, err := permissionService.CreateGrant(ctx, nil, &roleID, entities.PermissionEdit, entities.ResourceGame, nil, entities.ResourceAttributeGenre, "RPG")
+
+		assert.Nil(t, err)
+		assert.Equal(t, roleID, *grant.RoleID)
+		assert.Equal(t, "RPG", grant.Value)
+	})
+
+	t.Run("should return error when grant has no subject", func(t *testing.T) {
+		grant, err := permissionService.CreateGrant(ctx, nil, nil, entities.PermissionEdit, entities.ResourceGame, nil, "", "")
+
+		assert.NotNil(t, err)
+		assert.Nil(t, grant)
+		assert.Equal(t, "A permission grant must target either a user or a role", err.Message)
+	})
+
+	t.Run("should return error when grant has both subjects", func(t *testing.T) {
+		grant, err := permissionService.CreateGrant(ctx, &userID, &roleID, entities.PermissionEdit, entities.ResourceGame, nil, "", "")
+
+		assert.NotNil(t, err)
+		assert.Nil(t, grant)
+		assert.Equal(t, "A permission grant must target either a user or a role", err.Message)
+	})
+
+	t.Run("should return error when action is not valid", func(t *testing.T) {
+		grant, err := permissionService.CreateGrant(ctx, &userID, nil, entities.PermissionAction("publish"), entities.ResourceGame, nil, "", "")
+
+		assert.NotNil(t, err)
+		assert.Nil(t, grant)
+		assert.Equal(t, "The provided action is not valid", err.Message)
+	})
+
+	t.Run("should return error when attribute is not supported", func(t *testing.T) {
+		grant, err := permissionService.CreateGrant(ctx, &userID, nil, entities.PermissionEdit, entities.ResourceGameList, nil, entities.ResourceAttributeGenre, "RPG")
+
+		assert.NotNil(t, err)
+		assert.Nil(t, grant)
+		assert.Equal(t, "The provided attribute is not supported for this resource type", err.Message)
+	})
+
+	t.Run("should return error when user is not found", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, userID).Return(nil, gorm.ErrRecordNotFound)
+
+		grant, err := permissionService.CreateGrant(ctx, &userID, nil, entities.PermissionEdit, entities.ResourceGame, nil, "", "")
+
+		assert.NotNil(t, err)
+		assert.Nil(t, grant)
+		assert.Equal(t, "The requested user was not found", err.Message)
+	})
+
+	t.Run("should return error when Create fails", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, userID).Return(&entities.User{ID: userID}, nil)
+		permissionGrantRepository.EXPECT().Create(ctx, gomock.Any()).Return(errors.New("database error"))
+
+		grant, err := permissionService.CreateGrant(ctx, &userID, nil, entities.PermissionEdit, entities.ResourceGame, nil, "", "")
+
+		assert.NotNil(t, err)
+		assert.Nil(t, grant)
+		assert.Equal(t, "An error occurred while creating the permission grant", err.Message)
+	})
+}
+
+func TestPermissionService_DeleteGrant(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	permissionGrantRepository := mocks.NewMockPermissionGrantRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	roleRepository := mocks.NewMockRoleRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	permissionService := service.NewPermissionService(permissionGrantRepository, userRepository, roleRepository, logger)
+
+	ctx := context.Background()
+	grantID := uuid.New()
+
+	t.Run("should delete grant successfully", func(t *testing.T) {
+		permissionGrantRepository.EXPECT().Find(ctx, grantID).Return(&entities.PermissionGrant{ID: grantID}, nil)
+		permissionGrantRepository.EXPECT().Delete(ctx, grantID).Return(nil)
+
+		err := permissionService.DeleteGrant(ctx, grantID)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return error when grant is not found", func(t *testing.T) {
+		permissionGrantRepository.EXPECT().Find(ctx, grantID).Return(nil, gorm.ErrRecordNotFound)
+
+		err := permissionService.DeleteGrant(ctx, grantID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The requested permission grant was not found", err.Message)
+	})
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/factory"
+	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/repository"
+	"github.com/Bromolima/my-game-list/internal/security"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	personalAccessTokenSize = 32
+)
+
+type PersonalAccessTokenService interface {
+	CreateToken(ctx context.Context, userID uuid.UUID, name string, scopes []entities.AccessType, expiresAt *time.Time) (*entities.PersonalAccessToken, string, *resterr.RestErr)
+	ListTokens(ctx context.Context, userID uuid.UUID) ([]*entities.PersonalAccessToken, *resterr.RestErr)
+	RevokeToken(ctx context.Context, tokenID, userID uuid.UUID) *resterr.RestErr
+	Authenticate(ctx context.Context, plainToken string) (*entities.UserClaims, *resterr.RestErr)
+}
+
+type personalAccessTokenService struct {
+	repository     repository.PersonalAccessTokenRepository
+	userRepository repository.UserRepository
+	logger         *slog.Logger
+}
+
+func NewPersonalAccessTokenService(repository repository.PersonalAccessTokenRepository, userRepository repository.UserRepository, logger *slog.Logger) PersonalAccessTokenService {
+	return &personalAccessTokenService{
+		repository:     repository,
+		userRepository: userRepository,
+		logger:         logger.With(slog.String("service", "personalAccessToken")),
+	}
+}
+
+func (s *personalAccessTokenService) CreateToken(ctx context.Context, userID uuid.UUID, name string, scopes []entities.AccessType, expiresAt *time.Time) (_ *entities.PersonalAccessToken, _ string, restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "PersonalAccessTokenService", "CreateToken")
+	defer endSpan(span, &restErr)
+
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		log.Warn("Personal access token expiration is in the past")
+		return nil, "", resterr.NewBadRequestError("The expiration date must be in the future")
+	}
+
+	secret, err := security.GenerateRandomToken(personalAccessTokenSize)
+	if err != nil {
+		log.Error("Failed to generate personal access token", slog.String("error", err.Error()))
+		return nil, "", resterr.NewInternalServerErr("An error occurred while generating the token")
+	}
+
+	plainToken := entities.PersonalAccessTokenPrefix + secret
+	personalAccessToken := factory.NewPersonalAccessToken(userID, name, security.HashToken(plainToken), scopes, expiresAt)
+	if err := s.repository.Create(ctx, personalAccessToken); err != nil {
+		log.Error("Failed to create personal access token in database", slog.String("error", err.Error()))
+		return nil, "", resterr.NewInternalServerErr("An error occurred while creating the token")
+	}
+
+	return personalAccessToken, plainToken, nil
+}
+
+func (s *personalAccessTokenService) ListTokens(ctx context.Context, userID uuid.UUID) (_ []*entities.PersonalAccessToken, restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "PersonalAccessTokenService", "ListTokens")
+	defer endSpan(span, &restErr)
+
+	personalAccessTokens, err := s.repository.FindActiveByUserID(ctx, userID)
+	if err != nil {
+		log.Error("Failed to find personal access tokens in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while listing the tokens")
+	}
+
+	return personalAccessTokens, nil
+}
+
+func (s *personalAccessTokenService) RevokeToken(ctx context.Context, tokenID, userID uuid.UUID) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "PersonalAccessTokenService", "RevokeToken")
+	defer endSpan(span, &restErr)
+
+	personalAccessToken, err := s.repository.Find(ctx, tokenID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("The requested personal access token was not found")
+			return resterr.NewNotFoundError("The requested token was not found")
+		}
+
+		log.Error("Failed to find personal access token in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while finding the token")
+	}
+
+	if personalAccessToken.UserID != userID {
+		log.Warn("Attempt to revoke a personal access token owned by another user")
+		return resterr.NewNotFoundError("The requested token was not found")
+	}
+
+	if err := s.repository.Revoke(ctx, tokenID); err != nil {
+		log.Error("Failed to revoke personal access token in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while revoking the token")
+	}
+
+	return nil
+}
+
+func (s *personalAccessTokenService) Authenticate(ctx context.Context, plainToken string) (_ *entities.UserClaims, restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "PersonalAccessTokenService", "Authenticate")
+	defer endSpan(span, &restErr)
+
+	personalAccessToken, err := s.repository.FindByToken(ctx, security.HashToken(plainToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("Unknown personal access token provided")
+			return nil, resterr.NewUnauthorizedError("The provided token is invalid")
+		}
+
+		log.Error("Failed to find personal access token in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while validating the token")
+	}
+
+	now := time.Now()
+	if personalAccessToken.RevokedAt != nil {
+		log.Warn("Revoked personal access token provided")
+		return nil, resterr.NewUnauthorizedError("The provided token is invalid")
+	}
+
+	if personalAccessToken.ExpiresAt != nil && now.After(*personalAccessToken.ExpiresAt) {
+		log.Warn("Expired personal access token provided")
+		return nil, resterr.NewUnauthorizedError("The provided token is invalid")
+	}
+
+	user, err := s.userRepository.Find(ctx, personalAccessToken.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("The owner of the personal access token was not found")
+			return nil, resterr.NewUnauthorizedError("The provided token is invalid")
+		}
+
+		log.Error("Failed to find user in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while validating the token")
+	}
+
+	if err := s.repository.UpdateLastUsed(ctx, personalAccessToken.ID, now); err != nil {
+		log.Warn("Failed to record personal access token usage", slog.String("error", err.Error()))
+	}
+
+	return factory.NewPersonalAccessTokenClaims(user.ID, user.RoleID, personalAccessToken.ID, personalAccessToken.Scopes), nil
+}
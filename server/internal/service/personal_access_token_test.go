@@ -0,0 +1,241 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/security"
+	"github.com/Bromolima/my-game-list/internal/service"
+	"github.com/Bromolima/my-game-list/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestPersonalAccessTokenService_CreateToken(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	personalAccessTokenRepository := mocks.NewMockPersonalAccessTokenRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	personalAccessTokenService := service.NewPersonalAccessTokenService(personalAccessTokenRepository, userRepository, logger)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	name := "sync script"
+	scopes := []entities.AccessType{entities.ReadAccess}
+
+	t.Run("should create token successfully", func(t *testing.T) {
+		personalAccessTokenRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		personalAccessToken, plainToken, err := personalAccessTokenService.CreateToken(ctx, userID, name, scopes, nil)
+
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(plainToken, entities.PersonalAccessTokenPrefix))
+		assert.Equal(t, security.HashToken(plainToken), personalAccessToken.Token)
+		assert.Equal(t, scopes, personalAccessToken.Scopes)
+	})
+
+	t.Run("should return error when expiration is in the past", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Hour)
+
+		personalAccessToken, plainToken, err := personalAccessTokenService.CreateToken(ctx, userID, name, scopes, &expiresAt)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, personalAccessToken)
+		assert.Empty(t, plainToken)
+		assert.Equal(t, "The expiration date must be in the future", err.Message)
+	})
+
+	t.Run("should return error when Create fails", func(t *testing.T) {
+		personalAccessTokenRepository.EXPECT().Create(ctx, gomock.Any()).Return(errors.New("database error"))
+
+		personalAccessToken, plainToken, err := personalAccessTokenService.CreateToken(ctx, userID, name, scopes, nil)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, personalAccessToken)
+		assert.Empty(t, plainToken)
+		assert.Equal(t, "An error occurred while creating the token", err.Message)
+	})
+}
+
+func TestPersonalAccessTokenService_ListTokens(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	personalAccessTokenRepository := mocks.NewMockPersonalAccessTokenRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	personalAccessTokenService := service.NewPersonalAccessTokenService(personalAccessTokenRepository, userRepository, logger)
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("should list tokens successfully", func(t *testing.T) {
+		personalAccessTokens := []*entities.PersonalAccessToken{{ID: uuid.New(), UserID: userID}}
+		personalAccessTokenRepository.EXPECT().FindActiveByUserID(ctx, userID).Return(personalAccessTokens, nil)
+
+		result, err := personalAccessTokenService.ListTokens(ctx, userID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, personalAccessTokens, result)
+	})
+
+	t.Run("should return error when FindActiveByUserID fails", func(t *testing.T) {
+		personalAccessTokenRepository.EXPECT().FindActiveByUserID(ctx, userID).Return(nil, errors.New("database error"))
+
+		result, err := personalAccessTokenService.ListTokens(ctx, userID)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "An error occurred while listing the tokens", err.Message)
+	})
+}
+
+func TestPersonalAccessTokenService_RevokeToken(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	personalAccessTokenRepository := mocks.NewMockPersonalAccessTokenRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	personalAccessTokenService := service.NewPersonalAccessTokenService(personalAccessTokenRepository, userRepository, logger)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	tokenID := uuid.New()
+
+	t.Run("should revoke token successfully", func(t *testing.T) {
+		personalAccessTokenRepository.EXPECT().Find(ctx, tokenID).Return(&entities.PersonalAccessToken{ID: tokenID, UserID: userID}, nil)
+		personalAccessTokenRepository.EXPECT().Revoke(ctx, tokenID).Return(nil)
+
+		err := personalAccessTokenService.RevokeToken(ctx, tokenID, userID)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return error when token is not found", func(t *testing.T) {
+		personalAccessTokenRepository.EXPECT().Find(ctx, tokenID).Return(nil, gorm.ErrRecordNotFound)
+
+		err := personalAccessTokenService.RevokeToken(ctx, tokenID, userID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The requested token was not found", err.Message)
+	})
+
+	t.Run("should return error when token belongs to another user", func(t *testing.T) {
+		personalAccessTokenRepository.EXPECT().Find(ctx, tokenID).Return(&entities.PersonalAccessToken{ID: tokenID, UserID: uuid.New()}, nil)
+
+		err := personalAccessTokenService.RevokeToken(ctx, tokenID, userID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The requested token was not found", err.Message)
+	})
+
+	t.Run("should return error when Revoke fails", func(t *testing.T) {
+		personalAccessTokenRepository.EXPECT().Find(ctx, tokenID).Return(&entities.PersonalAccessToken{ID: tokenID, UserID: userID}, nil)
+		personalAccessTokenRepository.EXPECT().Revoke(ctx, tokenID).Return(errors.New("database error"))
+
+		err := personalAccessTokenService.RevokeToken(ctx, tokenID, userID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "An error occurred while revoking the token", err.Message)
+	})
+}
+
+func TestPersonalAccessTokenService_Authenticate(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	personalAccessTokenRepository := mocks.NewMockPersonalAccessTokenRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	personalAccessTokenService := service.NewPersonalAccessTokenService(personalAccessTokenRepository, userRepository, logger)
+
+	ctx := context.Background()
+	plainToken := entities.PersonalAccessTokenPrefix + "secret"
+	hashedToken := security.HashToken(plainToken)
+	user := &entities.User{ID: uuid.New(), RoleID: entities.RoleUserID}
+	tokenID := uuid.New()
+	scopes := []entities.AccessType{entities.ReadAccess}
+
+	t.Run("should authenticate token successfully", func(t *testing.T) {
+		personalAccessTokenRepository.EXPECT().FindByToken(ctx, hashedToken).Return(&entities.PersonalAccessToken{
+			ID:     tokenID,
+			UserID: user.ID,
+			Scopes: scopes,
+		}, nil)
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		personalAccessTokenRepository.EXPECT().UpdateLastUsed(ctx, tokenID, gomock.Any()).Return(nil)
+
+		claims, err := personalAccessTokenService.Authenticate(ctx, plainToken)
+
+		assert.Nil(t, err)
+		assert.Equal(t, user.ID, claims.ID)
+		assert.Equal(t, user.RoleID, claims.RoleID)
+		assert.Equal(t, entities.TokenTypePersonalAccessToken, claims.TokenType)
+		assert.Equal(t, scopes, claims.Scopes)
+	})
+
+	t.Run("should return error when token is unknown", func(t *testing.T) {
+		personalAccessTokenRepository.EXPECT().FindByToken(ctx, hashedToken).Return(nil, gorm.ErrRecordNotFound)
+
+		claims, err := personalAccessTokenService.Authenticate(ctx, plainToken)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, claims)
+		assert.Equal(t, "The provided token is invalid", err.Message)
+	})
+
+	t.Run("should return error when token was revoked", func(t *testing.T) {
+		revokedAt := time.Now()
+		personalAccessTokenRepository.EXPECT().FindByToken(ctx, hashedToken).Return(&entities.PersonalAccessToken{
+			ID:        tokenID,
+			UserID:    user.ID,
+			RevokedAt: &revokedAt,
+		}, nil)
+
+		claims, err := personalAccessTokenService.Authenticate(ctx, plainToken)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, claims)
+		assert.Equal(t, "The provided token is invalid", err.Message)
+	})
+
+	t.Run("should return error when token has expired", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Hour)
+		personalAccessTokenRepository.EXPECT().FindByToken(ctx, hashedToken).Return(&entities.PersonalAccessToken{
+			ID:        tokenID,
+			UserID:    user.ID,
+			ExpiresAt: &expiresAt,
+		}, nil)
+
+		claims, err := personalAccessTokenService.Authenticate(ctx, plainToken)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, claims)
+		assert.Equal(t, "The provided token is invalid", err.Message)
+	})
+
+	t.Run("should return error when FindByToken fails", func(t *testing.T) {
+		personalAccessTokenRepository.EXPECT().FindByToken(ctx, hashedToken).Return(nil, errors.New("database error"))
+
+		claims, err := personalAccessTokenService.Authenticate(ctx, plainToken)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, claims)
+		assert.Equal(t, "An error occurred while validating the token", err.Message)
+	})
+}
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/factory"
+	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/policy"
+	"github.com/Bromolima/my-game-list/internal/repository"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var roleNamePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+type RoleService interface {
+	ListRoles(ctx context.Context) ([]*entities.Role, *resterr.RestErr)
+	CreateRole(ctx context.Context, name string, accessTypes []entities.AccessType) (*entities.Role, *resterr.RestErr)
+	GrantAccess(ctx context.Context, roleID uint, accessType entities.AccessType) *resterr.RestErr
+	RevokeAccess(ctx context.Context, roleID uint, accessType entities.AccessType) *resterr.RestErr
+	AssignRole(ctx context.Context, userID uuid.UUID, roleID uint) *resterr.RestErr
+	ListRoleUsers(ctx context.Context, page *entities.Page[entities.User], roleID uint) (*entities.Page[entities.User], *resterr.RestErr)
+}
+
+type roleService struct {
+	repository        repository.RoleRepository
+	userRepository    repository.UserRepository
+	sessionRepository repository.SessionRepository
+	accessCache       policy.AccessCache
+	auditService      AuditService
+	logger            *slog.Logger
+}
+
+func NewRoleService(
+	repository repository.RoleRepository,
+	userRepository repository.UserRepository,
+	sessionRepository repository.SessionRepository,
+	accessCache policy.AccessCache,
+	auditService AuditService,
+	logger *slog.Logger,
+) RoleService {
+	return &roleService{
+		repository:        repository,
+		userRepository:    userRepository,
+		sessionRepository: sessionRepository,
+		accessCache:       accessCache,
+		auditService:      auditService,
+		logger:            logger.With(slog.String("service", "role")),
+	}
+}
+
+func (s *roleService) ListRoles(ctx context.Context) (_ []*entities.Role, restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "RoleService", "ListRoles")
+	defer endSpan(span, &restErr)
+
+	roles, err := s.repository.FindAll(ctx)
+	if err != nil {
+		log.Error("Failed to find roles in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while listing the roles")
+	}
+
+	return roles, nil
+}
+
+func (s *roleService) CreateRole(ctx context.Context, name string, accessTypes []entities.AccessType) (_ *entities.Role, restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "RoleService", "CreateRole")
+	defer endSpan(span, &restErr)
+
+	for _, accessType := range accessTypes {
+		if !accessType.IsValid() {
+			log.Warn("Unknown access type provided", slog.String("access", string(accessType)))
+			return nil, resterr.NewBadRequestError("The provided access type is not valid")
+		}
+	}
+
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if !roleNamePattern.MatchString(name) {
+		log.Warn("Invalid role name provided")
+		return nil, resterr.NewBadRequestError("The role name may only contain letters, digits and underscores")
+	}
+
+	roleExists, err := s.repository.FindByName(ctx, name)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error("Failed to find role by name in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while finding the role")
+	}
+
+	if roleExists != nil {
+		log.Warn("The provided role name is already in use")
+		return nil, resterr.NewConflictErr("The provided role name is already in use")
+	}
+
+	role := factory.NewRole(name)
+	if err := s.repository.Create(ctx, role); err != nil {
+		log.Error("Failed to create role in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while creating the role")
+	}
+
+	for _, accessType := range accessTypes {
+		if err := s.repository.GrantAccess(ctx, role.ID, accessType); err != nil {
+			log.Error("Failed to grant access to role in database", slog.String("error", err.Error()))
+			return nil, resterr.NewInternalServerErr("An error occurred while granting the access")
+		}
+
+		role.Access = append(role.Access, entities.Access{AccessType: accessType})
+	}
+
+	s.accessCache.Invalidate()
+	s.auditService.Record(ctx, entities.AuditRoleCreated, entities.AuditTargetRole, roleTargetID(role.ID), nil, map[string]any{
+		"name":   role.Name,
+		"access": accessTypes,
+	})
+	return role, nil
+}
+
+func (s *roleService) GrantAccess(ctx context.Context, roleID uint, accessType entities.AccessType) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "RoleService", "GrantAccess")
+	defer endSpan(span, &restErr)
+
+	if !accessType.IsValid() {
+		log.Warn("Unknown access type provided", slog.String("access", string(accessType)))
+		return resterr.NewBadRequestError("The provided access type is not valid")
+	}
+
+	if restErr := s.findRole(ctx, roleID); restErr != nil {
+		return restErr
+	}
+
+	if err := s.repository.GrantAccess(ctx, roleID, accessType); err != nil {
+		log.Error("Failed to grant access to role in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while granting the access")
+	}
+
+	s.accessCache.Invalidate()
+	s.auditService.Record(ctx, entities.AuditRoleAccessGranted, entities.AuditTargetRole, roleTargetID(roleID), nil, map[string]any{
+		"access": accessType,
+	})
+	return nil
+}
+
+func (s *roleService) RevokeAccess(ctx context.Context, roleID uint, accessType entities.AccessType) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "RoleService", "RevokeAccess")
+	defer endSpan(span, &restErr)
+
+	if !accessType.IsValid() {
+		log.Warn("Unknown access type provided", slog.String("access", string(accessType)))
+		return resterr.NewBadRequestError("The provided access type is not valid")
+	}
+
+	// Without this guard an administrator could lock everyone out of the
+	// role management endpoints.
+	if roleID == entities.RoleAdminID && accessType == entities.ManageRolesAccess {
+		log.Warn("Attempt to revoke role management from the admin role")
+		return resterr.NewForbiddenError("The admin role cannot lose access to role management")
+	}
+
+	if restErr := s.findRole(ctx, roleID); restErr != nil {
+		return restErr
+	}
+
+	if err := s.repository.RevokeAccess(ctx, roleID, accessType); err != nil {
+		log.Error("Failed to revoke access from role in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while revoking the access")
+	}
+
+	s.accessCache.Invalidate()
+	s.auditService.Record(ctx, entities.AuditRoleAccessRevoked, entities.AuditTargetRole, roleTargetID(roleID), map[string]any{
+		"access": accessType,
+	}, nil)
+	return nil
+}
+
+func (s *roleService) AssignRole(ctx context.Context, userID uuid.UUID, roleID uint) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "RoleService", "AssignRole")
+	defer endSpan(span, &restErr)
+
+	user, err := s.userRepository.Find(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("The requested user was not found")
+			return resterr.NewNotFoundError("The requested user was not found")
+		}
+
+		log.Error("Failed to find user in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while finding the user")
+	}
+
+	if restErr := s.findRole(ctx, roleID); restErr != nil {
+		return restErr
+	}
+
+	if user.RoleID == entities.RoleAdminID && roleID != entities.RoleAdminID {
+		admins, err := s.repository.CountUsers(ctx, entities.RoleAdminID)
+		if err != nil {
+			log.Error("Failed to count administrators in database", slog.String("error", err.Error()))
+			return resterr.NewInternalServerErr("An error occurred while assigning the role")
+		}
+
+		if admins <= 1 {
+			log.Warn("Attempt to demote the last administrator")
+			return resterr.NewConflictErr("The last administrator cannot be assigned another role")
+		}
+	}
+
+	if err := s.repository.AssignRole(ctx, userID, roleID); err != nil {
+		log.Error("Failed to assign role to user in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while assigning the role")
+	}
+
+	// Access tokens carry the role, so the sessions of the user are revoked to
+	// keep a previous role from being used until the tokens expire.
+	if user.RoleID != roleID {
+		if err := s.sessionRepository.RevokeAllByUserID(ctx, userID); err != nil {
+			log.Error("Failed to revoke user sessions in database", slog.String("error", err.Error()))
+			return resterr.NewInternalServerErr("An error occurred while assigning the role")
+		}
+
+		s.auditService.Record(
+			ctx,
+			entities.AuditUserRoleChanged,
+			entities.AuditTargetUser,
+			userID.String(),
+			map[string]any{"role_id": user.RoleID},
+			map[string]any{"role_id": roleID},
+		)
+	}
+
+	return nil
+}
+
+func (s *roleService) ListRoleUsers(ctx context.Context, page *entities.Page[entities.User], roleID uint) (_ *entities.Page[entities.User], restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "RoleService", "ListRoleUsers")
+	defer endSpan(span, &restErr)
+
+	if restErr := s.findRole(ctx, roleID); restErr != nil {
+		return nil, restErr
+	}
+
+	page, err := s.repository.FindUsers(ctx, page, roleID)
+	if err != nil {
+		log.Error("Failed to find role users in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while listing the role users")
+	}
+
+	return page, nil
+}
+
+func (s *roleService) findRole(ctx context.Context, roleID uint) *resterr.RestErr {
+	log := logger.Scoped(ctx, s.logger).With(slog.String("func", "findRole"))
+
+	if _, err := s.repository.Find(ctx, roleID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("The requested role was not found")
+			return resterr.NewNotFoundError("The requested role was not found")
+		}
+
+		log.Error("Failed to find role in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while finding the role")
+	}
+
+	return nil
+}
+
+func roleTargetID(roleID uint) string {
+	return strconv.FormatUint(uint64(roleID), 10)
+}
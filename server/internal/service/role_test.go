@@ -0,0 +1,367 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/factory"
+	"github.com/Bromolima/my-game-list/internal/service"
+	"github.com/Bromolima/my-game-list/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestRoleService_ListRoles(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	roleRepository := mocks.NewMockRoleRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	accessCache := mocks.NewMockAccessCache(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	roleService := service.NewRoleService(roleRepository, userRepository, sessionRepository, accessCache, auditService, logger)
+
+	ctx := context.Background()
+
+	t.Run("should list roles successfully", func(t *testing.T) {
+		roles := []*entities.Role{{ID: entities.RoleUserID, Name: entities.RoleUserName}}
+		roleRepository.EXPECT().FindAll(ctx).Return(roles, nil)
+
+		result, err := roleService.ListRoles(ctx)
+
+		assert.Nil(t, err)
+		assert.Equal(t, roles, result)
+	})
+
+	t.Run("should return error when FindAll fails", func(t *testing.T) {
+		roleRepository.EXPECT().FindAll(ctx).Return(nil, errors.New("database error"))
+
+		result, err := roleService.ListRoles(ctx)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "An error occurred while listing the roles", err.Message)
+	})
+}
+
+func TestRoleService_CreateRole(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	roleRepository := mocks.NewMockRoleRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	accessCache := mocks.NewMockAccessCache(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	roleService := service.NewRoleService(roleRepository, userRepository, sessionRepository, accessCache, auditService, logger)
+
+	ctx := context.Background()
+	accessTypes := []entities.AccessType{entities.ReadAccess, entities.UpdateAccess}
+
+	t.Run("should create role successfully", func(t *testing.T) {
+		roleRepository.EXPECT().FindByName(ctx, "MODERATOR").Return(nil, gorm.ErrRecordNotFound)
+		roleRepository.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, role *entities.Role) error {
+			role.ID = 3
+			return nil
+		})
+		roleRepository.EXPECT().GrantAccess(ctx, uint(3), entities.ReadAccess).Return(nil)
+		roleRepository.EXPECT().GrantAccess(ctx, uint(3), entities.UpdateAccess).Return(nil)
+		accessCache.EXPECT().Invalidate()
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		role, err := roleService.CreateRole(ctx, " moderator ", accessTypes)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "MODERATOR", role.Name)
+		assert.Equal(t, []string{"read", "update"}, factory.NewResponseFromRole(role).Access)
+	})
+
+	t.Run("should return error when access type is unknown", func(t *testing.T) {
+		role, err := roleService.CreateRole(ctx, "CURATOR", []entities.AccessType{"fly"})
+
+		assert.NotNil(t, err)
+		assert.Nil(t, role)
+		assert.Equal(t, "The provided access type is not valid", err.Message)
+	})
+
+	t.Run("should return error when name is invalid", func(t *testing.T) {
+		role, err := roleService.CreateRole(ctx, "super admin", accessTypes)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, role)
+		assert.Equal(t, "The role name may only contain letters, digits and underscores", err.Message)
+	})
+
+	t.Run("should return error when name is already in use", func(t *testing.T) {
+		roleRepository.EXPECT().FindByName(ctx, "CURATOR").Return(&entities.Role{ID: 3, Name: "CURATOR"}, nil)
+
+		role, err := roleService.CreateRole(ctx, "curator", accessTypes)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, role)
+		assert.Equal(t, "The provided role name is already in use", err.Message)
+	})
+
+	t.Run("should return error when Create fails", func(t *testing.T) {
+		roleRepository.EXPECT().FindByName(ctx, "CURATOR").Return(nil, gorm.ErrRecordNotFound)
+		roleRepository.EXPECT().Create(ctx, gomock.Any()).Return(errors.New("database error"))
+
+		role, err := roleService.CreateRole(ctx, "curator", accessTypes)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, role)
+		assert.Equal(t, "An error occurred while creating the role", err.Message)
+	})
+}
+
+func TestRoleService_GrantAccess(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	roleRepository := mocks.NewMockRoleRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	accessCache := mocks.NewMockAccessCache(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	roleService := service.NewRoleService(roleRepository, userRepository, sessionRepository, accessCache, auditService, logger)
+
+	ctx := context.Background()
+	roleID := uint(3)
+
+	t.Run("should grant access successfully", func(t *testing.T) {
+		roleRepository.EXPECT().Find(ctx, roleID).Return(&entities.Role{ID: roleID}, nil)
+		roleRepository.EXPECT().GrantAccess(ctx, roleID, entities.DeleteAcess).Return(nil)
+		accessCache.EXPECT().Invalidate()
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		err := roleService.GrantAccess(ctx, roleID, entities.DeleteAcess)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return error when access type is unknown", func(t *testing.T) {
+		err := roleService.GrantAccess(ctx, roleID, "fly")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The provided access type is not valid", err.Message)
+	})
+
+	t.Run("should return error when role is not found", func(t *testing.T) {
+		roleRepository.EXPECT().Find(ctx, roleID).Return(nil, gorm.ErrRecordNotFound)
+
+		err := roleService.GrantAccess(ctx, roleID, entities.DeleteAcess)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The requested role was not found", err.Message)
+	})
+
+	t.Run("should return error when GrantAccess fails", func(t *testing.T) {
+		roleRepository.EXPECT().Find(ctx, roleID).Return(&entities.Role{ID: roleID}, nil)
+		roleRepository.EXPECT().GrantAccess(ctx, roleID, entities.DeleteAcess).Return(errors.New("database error"))
+
+		err := roleService.GrantAccess(ctx, roleID, entities.DeleteAcess)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "An error occurred while granting the access", err.Message)
+	})
+}
+
+func TestRoleService_RevokeAccess(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	roleRepository := mocks.NewMockRoleRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	accessCache := mocks.NewMockAccessCache(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	roleService := service.NewRoleService(roleRepository, userRepository, sessionRepository, accessCache, auditService, logger)
+
+	ctx := context.Background()
+	roleID := uint(3)
+
+	t.Run("should revoke access successfully", func(t *testing.T) {
+		roleRepository.EXPECT().Find(ctx, roleID).Return(&entities.Role{ID: roleID}, nil)
+		roleRepository.EXPECT().RevokeAccess(ctx, roleID, entities.DeleteAcess).Return(nil)
+		accessCache.EXPECT().Invalidate()
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		err := roleService.RevokeAccess(ctx, roleID, entities.DeleteAcess)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return error when revoking role management from admins", func(t *testing.T) {
+		err := roleService.RevokeAccess(ctx, entities.RoleAdminID, entities.ManageRolesAccess)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The admin role cannot lose access to role management", err.Message)
+	})
+
+	t.Run("should return error when RevokeAccess fails", func(t *testing.T) {
+		roleRepository.EXPECT().Find(ctx, roleID).Return(&entities.Role{ID: roleID}, nil)
+		roleRepository.EXPECT().RevokeAccess(ctx, roleID, entities.DeleteAcess).Return(errors.New("database error"))
+
+		err := roleService.RevokeAccess(ctx, roleID, entities.DeleteAcess)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "An error occurred while revoking the access", err.Message)
+	})
+}
+
+func TestRoleService_AssignRole(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	roleRepository := mocks.NewMockRoleRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	accessCache := mocks.NewMockAccessCache(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	roleService := service.NewRoleService(roleRepository, userRepository, sessionRepository, accessCache, auditService, logger)
+
+	ctx := context.Background()
+	roleID := uint(3)
+	user := &entities.User{ID: uuid.New(), RoleID: entities.RoleUserID}
+	admin := &entities.User{ID: uuid.New(), RoleID: entities.RoleAdminID}
+
+	t.Run("should assign role successfully", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		roleRepository.EXPECT().Find(ctx, roleID).Return(&entities.Role{ID: roleID}, nil)
+		roleRepository.EXPECT().AssignRole(ctx, user.ID, roleID).Return(nil)
+		sessionRepository.EXPECT().RevokeAllByUserID(ctx, user.ID).Return(nil)
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		err := roleService.AssignRole(ctx, user.ID, roleID)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should keep sessions when role does not change", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		roleRepository.EXPECT().Find(ctx, uint(entities.RoleUserID)).Return(&entities.Role{ID: entities.RoleUserID}, nil)
+		roleRepository.EXPECT().AssignRole(ctx, user.ID, uint(entities.RoleUserID)).Return(nil)
+
+		err := roleService.AssignRole(ctx, user.ID, entities.RoleUserID)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return error when RevokeAllByUserID fails", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		roleRepository.EXPECT().Find(ctx, roleID).Return(&entities.Role{ID: roleID}, nil)
+		roleRepository.EXPECT().AssignRole(ctx, user.ID, roleID).Return(nil)
+		sessionRepository.EXPECT().RevokeAllByUserID(ctx, user.ID).Return(errors.New("database error"))
+
+		err := roleService.AssignRole(ctx, user.ID, roleID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "An error occurred while assigning the role", err.Message)
+	})
+
+	t.Run("should demote an admin when another admin remains", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, admin.ID).Return(admin, nil)
+		roleRepository.EXPECT().Find(ctx, roleID).Return(&entities.Role{ID: roleID}, nil)
+		roleRepository.EXPECT().CountUsers(ctx, uint(entities.RoleAdminID)).Return(int64(2), nil)
+		roleRepository.EXPECT().AssignRole(ctx, admin.ID, roleID).Return(nil)
+		sessionRepository.EXPECT().RevokeAllByUserID(ctx, admin.ID).Return(nil)
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		err := roleService.AssignRole(ctx, admin.ID, roleID)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return error when demoting the last admin", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, admin.ID).Return(admin, nil)
+		roleRepository.EXPECT().Find(ctx, roleID).Return(&entities.Role{ID: roleID}, nil)
+		roleRepository.EXPECT().CountUsers(ctx, uint(entities.RoleAdminID)).Return(int64(1), nil)
+
+		err := roleService.AssignRole(ctx, admin.ID, roleID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The last administrator cannot be assigned another role", err.Message)
+	})
+
+	t.Run("should return error when user is not found", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, user.ID).Return(nil, gorm.ErrRecordNotFound)
+
+		err := roleService.AssignRole(ctx, user.ID, roleID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The requested user was not found", err.Message)
+	})
+
+	t.Run("should return error when role is not found", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		roleRepository.EXPECT().Find(ctx, roleID).Return(nil, gorm.ErrRecordNotFound)
+
+		err := roleService.AssignRole(ctx, user.ID, roleID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The requested role was not found", err.Message)
+	})
+}
+
+func TestRoleService_ListRoleUsers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	roleRepository := mocks.NewMockRoleRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	accessCache := mocks.NewMockAccessCache(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	roleService := service.NewRoleService(roleRepository, userRepository, sessionRepository, accessCache, auditService, logger)
+
+	ctx := context.Background()
+	roleID := uint(3)
+	page := factory.NewPage[entities.User](1, 10)
+
+	t.Run("should list role users successfully", func(t *testing.T) {
+		result := &entities.Page[entities.User]{Data: []entities.User{{ID: uuid.New()}}, TotalItems: 1, TotalPages: 1}
+		roleRepository.EXPECT().Find(ctx, roleID).Return(&entities.Role{ID: roleID}, nil)
+		roleRepository.EXPECT().FindUsers(ctx, page, roleID).Return(result, nil)
+
+		users, err := roleService.ListRoleUsers(ctx, page, roleID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, result, users)
+	})
+
+	t.Run("should return error when FindUsers fails", func(t *testing.T) {
+		roleRepository.EXPECT().Find(ctx, roleID).Return(&entities.Role{ID: roleID}, nil)
+		roleRepository.EXPECT().FindUsers(ctx, page, roleID).Return(nil, errors.New("database error"))
+
+		users, err := roleService.ListRoleUsers(ctx, page, roleID)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, users)
+		assert.Equal(t, "An error occurred while listing the role users", err.Message)
+	})
+}
@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/factory"
+	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/repository"
+	"github.com/Bromolima/my-game-list/internal/security"
+	"github.com/Bromolima/my-game-list/internal/token"
+	"github.com/Bromolima/my-game-list/logger"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SessionService interface {
+	ValidateSession(ctx context.Context, sessionID, userID uuid.UUID) *resterr.RestErr
+	RefreshSession(ctx context.Context, refreshToken, ipAddress, userAgent string) (*entities.AuthTokens, *resterr.RestErr)
+	Logout(ctx context.Context, refreshToken string) *resterr.RestErr
+	ListActiveSessions(ctx context.Context, userID uuid.UUID) ([]*entities.Session, *resterr.RestErr)
+	RevokeSession(ctx context.Context, sessionID, userID uuid.UUID) *resterr.RestErr
+	RevokeAllSessions(ctx context.Context, userID uuid.UUID) *resterr.RestErr
+}
+
+type sessionService struct {
+	repository     repository.SessionRepository
+	userRepository repository.UserRepository
+	tokenService   token.JwtService
+	logger         *slog.Logger
+}
+
+func NewSessionService(repository repository.SessionRepository, userRepository repository.UserRepository, tokenService token.JwtService, logger *slog.Logger) SessionService {
+	return &sessionService{
+		repository:     repository,
+		userRepository: userRepository,
+		tokenService:   tokenService,
+		logger:         logger.With(slog.String("service", "session")),
+	}
+}
+
+func (s *sessionService) ValidateSession(ctx context.Context, sessionID, userID uuid.UUID) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "SessionService", "ValidateSession")
+	defer endSpan(span, &restErr)
+
+	session, err := s.repository.Find(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("The session was not found")
+			return resterr.NewUnauthorizedError("The session is no longer valid")
+		}
+
+		log.Error("Failed to find session in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while validating the session")
+	}
+
+	if session.UserID != userID {
+		log.Warn("The session does not belong to the token owner")
+		return resterr.NewUnauthorizedError("The session is no longer valid")
+	}
+
+	if session.RevokedAt != nil {
+		log.Warn("The session was revoked")
+		return resterr.NewUnauthorizedError("The session is no longer valid")
+	}
+
+	if time.Now().After(session.ExpiresAt) {
+		log.Warn("The session has expired")
+		return resterr.NewUnauthorizedError("The session is no longer valid")
+	}
+
+	return nil
+}
+
+func (s *sessionService) RefreshSession(ctx context.Context, refreshToken, ipAddress, userAgent string) (_ *entities.AuthTokens, restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "SessionService", "RefreshSession")
+	defer endSpan(span, &restErr)
+
+	sessionID, err := token.ParseRefreshToken(refreshToken)
+	if err != nil {
+		log.Warn("Malformed refresh token provided")
+		return nil, resterr.NewUnauthorizedError("The refresh token is invalid")
+	}
+
+	session, err := s.repository.Find(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("The session of the refresh token was not found")
+			return nil, resterr.NewUnauthorizedError("The refresh token is invalid")
+		}
+
+		log.Error("Failed to find session in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while refreshing the session")
+	}
+
+	if session.RevokedAt != nil || time.Now().After(session.ExpiresAt) {
+		log.Warn("Refresh attempted on an inactive session")
+		return nil, resterr.NewUnauthorizedError("The refresh token is invalid")
+	}
+
+	if !security.CheckToken(session.Token, refreshToken) {
+		log.Warn("Refresh token reuse detected, revoking the session")
+		return nil, s.revokeReusedSession(ctx, sessionID)
+	}
+
+	user, err := s.userRepository.Find(ctx, session.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("The session owner was not found")
+			return nil, resterr.NewUnauthorizedError("The refresh token is invalid")
+		}
+
+		log.Error("Failed to find user in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while refreshing the session")
+	}
+
+	tokens, err := generateAuthTokens(s.tokenService, user, session.ID)
+	if err != nil {
+		log.Error("Failed to generate session tokens", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while generating the token")
+	}
+
+	previousToken := session.Token
+	session.Token = security.HashToken(tokens.RefreshToken)
+	session.IPAdress = ipAddress
+	session.UserAgent = userAgent
+	session.ExpiresAt = tokens.RefreshTokenExpiresAt
+
+	rotated, err := s.repository.Rotate(ctx, session, previousToken)
+	if err != nil {
+		log.Error("Failed to rotate session token in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while refreshing the session")
+	}
+
+	if !rotated {
+		log.Warn("Refresh token was rotated concurrently, revoking the session")
+		return nil, s.revokeReusedSession(ctx, sessionID)
+	}
+
+	return tokens, nil
+}
+
+// Logout revokes the session of the refresh token. The session ID is not a
+// secret, it is part of the access token claims, so the secret of the refresh
+// token is checked before revoking it.
+func (s *sessionService) Logout(ctx context.Context, refreshToken string) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "SessionService", "Logout")
+	defer endSpan(span, &restErr)
+
+	sessionID, err := token.ParseRefreshToken(refreshToken)
+	if err != nil {
+		log.Warn("Malformed refresh token provided")
+		return resterr.NewUnauthorizedError("The refresh token is invalid")
+	}
+
+	session, err := s.repository.Find(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("The session of the refresh token was not found")
+			return resterr.NewUnauthorizedError("The refresh token is invalid")
+		}
+
+		log.Error("Failed to find session in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while revoking the session")
+	}
+
+	if !security.CheckToken(session.Token, refreshToken) {
+		log.Warn("Logout attempted with a refresh token that does not match the session")
+		return resterr.NewUnauthorizedError("The refresh token is invalid")
+	}
+
+	if err := s.repository.Revoke(ctx, sessionID); err != nil {
+		log.Error("Failed to revoke session in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while revoking the session")
+	}
+
+	return nil
+}
+
+func (s *sessionService) revokeReusedSession(ctx context.Context, sessionID uuid.UUID) *resterr.RestErr {
+	log := logger.Scoped(ctx, s.logger).With(slog.String("func", "revokeReusedSession"))
+
+	if err := s.repository.Revoke(ctx, sessionID); err != nil {
+		log.Error("Failed to revoke session in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while revoking the session")
+	}
+
+	return resterr.NewUnauthorizedError("The refresh token was already used, the session has been revoked")
+}
+
+func (s *sessionService) ListActiveSessions(ctx context.Context, userID uuid.UUID) (_ []*entities.Session, restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "SessionService", "ListActiveSessions")
+	defer endSpan(span, &restErr)
+
+	sessions, err := s.repository.FindActiveByUserID(ctx, userID)
+	if err != nil {
+		log.Error("Failed to find active sessions in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while listing the sessions")
+	}
+
+	return sessions, nil
+}
+
+func (s *sessionService) RevokeSession(ctx context.Context, sessionID, userID uuid.UUID) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "SessionService", "RevokeSession")
+	defer endSpan(span, &restErr)
+
+	session, err := s.repository.Find(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("The requested session was not found")
+			return resterr.NewNotFoundError("The requested session was not found")
+		}
+
+		log.Error("Failed to find session in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while finding the session")
+	}
+
+	if session.UserID != userID {
+		log.Warn("Attempt to revoke a session owned by another user")
+		return resterr.NewNotFoundError("The requested session was not found")
+	}
+
+	if err := s.repository.Revoke(ctx, sessionID); err != nil {
+		log.Error("Failed to revoke session in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while revoking the session")
+	}
+
+	return nil
+}
+
+func (s *sessionService) RevokeAllSessions(ctx context.Context, userID uuid.UUID) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "SessionService", "RevokeAllSessions")
+	defer endSpan(span, &restErr)
+
+	if err := s.repository.RevokeAllByUserID(ctx, userID); err != nil {
+		log.Error("Failed to revoke user sessions in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while revoking the sessions")
+	}
+
+	return nil
+}
+
+func createSession(
+	ctx context.Context,
+	log *slog.Logger,
+	sessionRepository repository.SessionRepository,
+	tokenService token.JwtService,
+	user *entities.User,
+	ipAddress, userAgent string,
+) (*entities.AuthTokens, *resterr.RestErr) {
+	sessionID := uuid.New()
+	tokens, err := generateAuthTokens(tokenService, user, sessionID)
+	if err != nil {
+		log.Error("Failed to generate token", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while generating the token")
+	}
+
+	session := factory.NewSession(
+		sessionID,
+		user.ID,
+		security.HashToken(tokens.RefreshToken),
+		ipAddress,
+		userAgent,
+		tokens.RefreshTokenExpiresAt,
+	)
+	if err := sessionRepository.Create(ctx, session); err != nil {
+		log.Error("Failed to create session in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while creating the session")
+	}
+
+	return tokens, nil
+}
+
+func generateAuthTokens(tokenService token.JwtService, user *entities.User, sessionID uuid.UUID) (*entities.AuthTokens, error) {
+	accessToken, err := tokenService.GenerateToken(user, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := token.NewRefreshToken(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return factory.NewAuthTokens(
+		accessToken,
+		now.Add(token.AccessTokenDuration),
+		refreshToken,
+		now.Add(token.RefreshTokenDuration),
+	), nil
+}
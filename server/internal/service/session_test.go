@@ -0,0 +1,403 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/security"
+	"github.com/Bromolima/my-game-list/internal/service"
+	"github.com/Bromolima/my-game-list/internal/token"
+	"github.com/Bromolima/my-game-list/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestSessionService_ValidateSession(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	tokenService := mocks.NewMockJwtService(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	sessionService := service.NewSessionService(sessionRepository, userRepository, tokenService, logger)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	sessionID := uuid.New()
+
+	t.Run("should validate session successfully", func(t *testing.T) {
+		sessionRepository.EXPECT().Find(ctx, sessionID).Return(&entities.Session{
+			ID:        sessionID,
+			UserID:    userID,
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+
+		err := sessionService.ValidateSession(ctx, sessionID, userID)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return error when session is not found", func(t *testing.T) {
+		sessionRepository.EXPECT().Find(ctx, sessionID).Return(nil, gorm.ErrRecordNotFound)
+
+		err := sessionService.ValidateSession(ctx, sessionID, userID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The session is no longer valid", err.Message)
+	})
+
+	t.Run("should return error when session was revoked", func(t *testing.T) {
+		revokedAt := time.Now()
+		sessionRepository.EXPECT().Find(ctx, sessionID).Return(&entities.Session{
+			ID:        sessionID,
+			UserID:    userID,
+			ExpiresAt: time.Now().Add(time.Hour),
+			RevokedAt: &revokedAt,
+		}, nil)
+
+		err := sessionService.ValidateSession(ctx, sessionID, userID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The session is no longer valid", err.Message)
+	})
+
+	t.Run("should return error when session has expired", func(t *testing.T) {
+		sessionRepository.EXPECT().Find(ctx, sessionID).Return(&entities.Session{
+			ID:        sessionID,
+			UserID:    userID,
+			ExpiresAt: time.Now().Add(-time.Hour),
+		}, nil)
+
+		err := sessionService.ValidateSession(ctx, sessionID, userID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The session is no longer valid", err.Message)
+	})
+
+	t.Run("should return error when session belongs to another user", func(t *testing.T) {
+		sessionRepository.EXPECT().Find(ctx, sessionID).Return(&entities.Session{
+			ID:        sessionID,
+			UserID:    uuid.New(),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+
+		err := sessionService.ValidateSession(ctx, sessionID, userID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The session is no longer valid", err.Message)
+	})
+
+	t.Run("should return error when Find fails", func(t *testing.T) {
+		sessionRepository.EXPECT().Find(ctx, sessionID).Return(nil, errors.New("database error"))
+
+		err := sessionService.ValidateSession(ctx, sessionID, userID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "An error occurred while validating the session", err.Message)
+	})
+}
+
+func TestSessionService_RefreshSession(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	tokenService := mocks.NewMockJwtService(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	sessionService := service.NewSessionService(sessionRepository, userRepository, tokenService, logger)
+
+	ctx := context.Background()
+	ipAddress := "127.0.0.1"
+	userAgent := "test-agent"
+	user := &entities.User{ID: uuid.New(), RoleID: entities.RoleUserID}
+	sessionID := uuid.New()
+	refreshToken, _ := token.NewRefreshToken(sessionID)
+
+	newSession := func() *entities.Session {
+		return &entities.Session{
+			ID:        sessionID,
+			UserID:    user.ID,
+			Token:     security.HashToken(refreshToken),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
+
+	t.Run("should rotate refresh token successfully", func(t *testing.T) {
+		sessionRepository.EXPECT().Find(ctx, sessionID).Return(newSession(), nil)
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		tokenService.EXPECT().GenerateToken(user, sessionID).Return("access", nil)
+		sessionRepository.EXPECT().Rotate(ctx, gomock.Any(), security.HashToken(refreshToken)).Return(true, nil)
+
+		tokens, err := sessionService.RefreshSession(ctx, refreshToken, ipAddress, userAgent)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "access", tokens.AccessToken)
+		assert.NotEqual(t, refreshToken, tokens.RefreshToken)
+	})
+
+	t.Run("should return error when refresh token is malformed", func(t *testing.T) {
+		tokens, err := sessionService.RefreshSession(ctx, "malformed", ipAddress, userAgent)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, tokens)
+		assert.Equal(t, "The refresh token is invalid", err.Message)
+	})
+
+	t.Run("should return error when session is not found", func(t *testing.T) {
+		sessionRepository.EXPECT().Find(ctx, sessionID).Return(nil, gorm.ErrRecordNotFound)
+
+		tokens, err := sessionService.RefreshSession(ctx, refreshToken, ipAddress, userAgent)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, tokens)
+		assert.Equal(t, "The refresh token is invalid", err.Message)
+	})
+
+	t.Run("should return error when session was revoked", func(t *testing.T) {
+		session := newSession()
+		revokedAt := time.Now()
+		session.RevokedAt = &revokedAt
+		sessionRepository.EXPECT().Find(ctx, sessionID).Return(session, nil)
+
+		tokens, err := sessionService.RefreshSession(ctx, refreshToken, ipAddress, userAgent)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, tokens)
+		assert.Equal(t, "The refresh token is invalid", err.Message)
+	})
+
+	t.Run("should revoke session when refresh token is reused", func(t *testing.T) {
+		reusedToken, _ := token.NewRefreshToken(sessionID)
+		sessionRepository.EXPECT().Find(ctx, sessionID).Return(newSession(), nil)
+		sessionRepository.EXPECT().Revoke(ctx, sessionID).Return(nil)
+
+		tokens, err := sessionService.RefreshSession(ctx, reusedToken, ipAddress, userAgent)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, tokens)
+		assert.Equal(t, "The refresh token was already used, the session has been revoked", err.Message)
+	})
+
+	t.Run("should revoke session when token was rotated concurrently", func(t *testing.T) {
+		sessionRepository.EXPECT().Find(ctx, sessionID).Return(newSession(), nil)
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		tokenService.EXPECT().GenerateToken(user, sessionID).Return("access", nil)
+		sessionRepository.EXPECT().Rotate(ctx, gomock.Any(), security.HashToken(refreshToken)).Return(false, nil)
+		sessionRepository.EXPECT().Revoke(ctx, sessionID).Return(nil)
+
+		tokens, err := sessionService.RefreshSession(ctx, refreshToken, ipAddress, userAgent)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, tokens)
+		assert.Equal(t, "The refresh token was already used, the session has been revoked", err.Message)
+	})
+
+	t.Run("should return error when Rotate fails", func(t *testing.T) {
+		sessionRepository.EXPECT().Find(ctx, sessionID).Return(newSession(), nil)
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		tokenService.EXPECT().GenerateToken(user, sessionID).Return("access", nil)
+		sessionRepository.EXPECT().Rotate(ctx, gomock.Any(), security.HashToken(refreshToken)).Return(false, errors.New("database error"))
+
+		tokens, err := sessionService.RefreshSession(ctx, refreshToken, ipAddress, userAgent)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, tokens)
+		assert.Equal(t, "An error occurred while refreshing the session", err.Message)
+	})
+}
+
+func TestSessionService_Logout(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	tokenService := mocks.NewMockJwtService(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	sessionService := service.NewSessionService(sessionRepository, userRepository, tokenService, logger)
+
+	ctx := context.Background()
+	sessionID := uuid.New()
+	refreshToken, _ := token.NewRefreshToken(sessionID)
+	session := &entities.Session{
+		ID:        sessionID,
+		Token:     security.HashToken(refreshToken),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	t.Run("should revoke session successfully", func(t *testing.T) {
+		sessionRepository.EXPECT().Find(ctx, sessionID).Return(session, nil)
+		sessionRepository.EXPECT().Revoke(ctx, sessionID).Return(nil)
+
+		err := sessionService.Logout(ctx, refreshToken)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return error when refresh token is malformed", func(t *testing.T) {
+		err := sessionService.Logout(ctx, "malformed")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The refresh token is invalid", err.Message)
+	})
+
+	t.Run("should not revoke session when the secret is forged", func(t *testing.T) {
+		sessionRepository.EXPECT().Find(ctx, sessionID).Return(session, nil)
+
+		err := sessionService.Logout(ctx, sessionID.String()+".x")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The refresh token is invalid", err.Message)
+	})
+
+	t.Run("should return error when session is not found", func(t *testing.T) {
+		sessionRepository.EXPECT().Find(ctx, sessionID).Return(nil, gorm.ErrRecordNotFound)
+
+		err := sessionService.Logout(ctx, refreshToken)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The refresh token is invalid", err.Message)
+	})
+
+	t.Run("should return error when Revoke fails", func(t *testing.T) {
+		sessionRepository.EXPECT().Find(ctx, sessionID).Return(session, nil)
+		sessionRepository.EXPECT().Revoke(ctx, sessionID).Return(errors.New("database error"))
+
+		err := sessionService.Logout(ctx, refreshToken)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "An error occurred while revoking the session", err.Message)
+	})
+}
+
+func TestSessionService_ListActiveSessions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	tokenService := mocks.NewMockJwtService(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	sessionService := service.NewSessionService(sessionRepository, userRepository, tokenService, logger)
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("should list active sessions successfully", func(t *testing.T) {
+		sessions := []*entities.Session{{ID: uuid.New(), UserID: userID}}
+		sessionRepository.EXPECT().FindActiveByUserID(ctx, userID).Return(sessions, nil)
+
+		result, err := sessionService.ListActiveSessions(ctx, userID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, sessions, result)
+	})
+
+	t.Run("should return error when FindActiveByUserID fails", func(t *testing.T) {
+		sessionRepository.EXPECT().FindActiveByUserID(ctx, userID).Return(nil, errors.New("database error"))
+
+		result, err := sessionService.ListActiveSessions(ctx, userID)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "An error occurred while listing the sessions", err.Message)
+	})
+}
+
+func TestSessionService_RevokeSession(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	tokenService := mocks.NewMockJwtService(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	sessionService := service.NewSessionService(sessionRepository, userRepository, tokenService, logger)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	sessionID := uuid.New()
+
+	t.Run("should revoke session successfully", func(t *testing.T) {
+		sessionRepository.EXPECT().Find(ctx, sessionID).Return(&entities.Session{ID: sessionID, UserID: userID}, nil)
+		sessionRepository.EXPECT().Revoke(ctx, sessionID).Return(nil)
+
+		err := sessionService.RevokeSession(ctx, sessionID, userID)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return error when session is not found", func(t *testing.T) {
+		sessionRepository.EXPECT().Find(ctx, sessionID).Return(nil, gorm.ErrRecordNotFound)
+
+		err := sessionService.RevokeSession(ctx, sessionID, userID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The requested session was not found", err.Message)
+	})
+
+	t.Run("should return error when session belongs to another user", func(t *testing.T) {
+		sessionRepository.EXPECT().Find(ctx, sessionID).Return(&entities.Session{ID: sessionID, UserID: uuid.New()}, nil)
+
+		err := sessionService.RevokeSession(ctx, sessionID, userID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The requested session was not found", err.Message)
+	})
+
+	t.Run("should return error when Revoke fails", func(t *testing.T) {
+		sessionRepository.EXPECT().Find(ctx, sessionID).Return(&entities.Session{ID: sessionID, UserID: userID}, nil)
+		sessionRepository.EXPECT().Revoke(ctx, sessionID).Return(errors.New("database error"))
+
+		err := sessionService.RevokeSession(ctx, sessionID, userID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "An error occurred while revoking the session", err.Message)
+	})
+}
+
+func TestSessionService_RevokeAllSessions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	tokenService := mocks.NewMockJwtService(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	sessionService := service.NewSessionService(sessionRepository, userRepository, tokenService, logger)
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("should revoke all sessions successfully", func(t *testing.T) {
+		sessionRepository.EXPECT().RevokeAllByUserID(ctx, userID).Return(nil)
+
+		err := sessionService.RevokeAllSessions(ctx, userID)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return error when RevokeAllByUserID fails", func(t *testing.T) {
+		sessionRepository.EXPECT().RevokeAllByUserID(ctx, userID).Return(errors.New("database error"))
+
+		err := sessionService.RevokeAllSessions(ctx, userID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "An error occurred while revoking the sessions", err.Message)
+	})
+}
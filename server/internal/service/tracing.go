@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/tracing"
+	"github.com/Bromolima/my-game-list/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer(tracing.InstrumentationName)
+
+// startSpan starts the span of a service method, named after the service and
+// the method, and returns the logger of the method with the attributes of the
+// request and the IDs of the span.
+// The context is returned untouched when the span is not recorded, because
+// tracing is disabled or the trace is sampled out, since the spans started
+// from it would not be recorded either.
+func startSpan(ctx context.Context, componentLogger *slog.Logger, service, fn string) (context.Context, trace.Span, *slog.Logger) {
+	spanCtx, span := tracer.Start(ctx, service+"."+fn)
+	if span.IsRecording() {
+		ctx = spanCtx
+	}
+
+	return ctx, span, logger.Scoped(ctx, componentLogger).With(slog.String("func", fn))
+}
+
+// endSpan ends the span of a service method, marking it as failed when the
+// method returns an error. It is deferred with the address of the named error
+// result, which holds the returned error once the method returns.
+func endSpan(span trace.Span, restErr **resterr.RestErr) {
+	if err := *restErr; err != nil {
+		span.SetStatus(codes.Error, err.Message)
+		span.SetAttributes(
+			semconv.ErrorTypeKey.String(err.Err),
+			semconv.HTTPResponseStatusCode(err.Code),
+		)
+	}
+
+	span.End()
+}
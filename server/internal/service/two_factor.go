@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Bromolima/my-game-list/config"
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/factory"
+	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/repository"
+	"github.com/Bromolima/my-game-list/internal/security"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	recoveryCodeCount = 10
+)
+
+type TwoFactorService interface {
+	BeginEnrollment(ctx context.Context, userID uuid.UUID) (string, string, *resterr.RestErr)
+	ConfirmEnrollment(ctx context.Context, userID uuid.UUID, code string) ([]string, *resterr.RestErr)
+	Disable(ctx context.Context, userID uuid.UUID, code string) *resterr.RestErr
+	RequireEnrollment(ctx context.Context, userClaims *entities.UserClaims) *resterr.RestErr
+}
+
+type twoFactorService struct {
+	repository             repository.TwoFactorRepository
+	recoveryCodeRepository repository.RecoveryCodeRepository
+	userRepository         repository.UserRepository
+	logger                 *slog.Logger
+}
+
+func NewTwoFactorService(
+	repository repository.TwoFactorRepository,
+	recoveryCodeRepository repository.RecoveryCodeRepository,
+	userRepository repository.UserRepository,
+	logger *slog.Logger,
+) TwoFactorService {
+	return &twoFactorService{
+		repository:             repository,
+		recoveryCodeRepository: recoveryCodeRepository,
+		userRepository:         userRepository,
+		logger:                 logger.With(slog.String("service", "twoFactor")),
+	}
+}
+
+// BeginEnrollment stores a new pending secret and returns it together with the
+// otpauth URI. Two-factor stays disabled until the first code is confirmed.
+func (s *twoFactorService) BeginEnrollment(ctx context.Context, userID uuid.UUID) (_ string, _ string, restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "TwoFactorService", "BeginEnrollment")
+	defer endSpan(span, &restErr)
+
+	user, err := s.userRepository.Find(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("The requested user was not found")
+			return "", "", resterr.NewNotFoundError("The requested user was not found")
+		}
+
+		log.Error("Failed to find user in database", slog.String("error", err.Error()))
+		return "", "", resterr.NewInternalServerErr("An error occurred while finding the user")
+	}
+
+	twoFactor, err := findConfirmedTwoFactor(ctx, s.repository, userID)
+	if err != nil {
+		log.Error("Failed to find two-factor settings in database", slog.String("error", err.Error()))
+		return "", "", resterr.NewInternalServerErr("An error occurred while enabling two-factor authentication")
+	}
+
+	if twoFactor != nil {
+		log.Warn("Two-factor enrolment started for an account that already has it enabled")
+		return "", "", resterr.NewConflictErr("Two-factor authentication is already enabled")
+	}
+
+	secret, err := security.GenerateTOTPSecret()
+	if err != nil {
+		log.Error("Failed to generate two-factor secret", slog.String("error", err.Error()))
+		return "", "", resterr.NewInternalServerErr("An error occurred while enabling two-factor authentication")
+	}
+
+	if err := s.repository.Update(ctx, factory.NewTwoFactor(userID, secret)); err != nil {
+		log.Error("Failed to save two-factor settings in database", slog.String("error", err.Error()))
+		return "", "", resterr.NewInternalServerErr("An error occurred while enabling two-factor authentication")
+	}
+
+	return secret, security.TOTPURI(config.Env.TwoFactor.Issuer, user.Email, secret), nil
+}
+
+func (s *twoFactorService) ConfirmEnrollment(ctx context.Context, userID uuid.UUID, code string) (_ []string, restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "TwoFactorService", "ConfirmEnrollment")
+	defer endSpan(span, &restErr)
+
+	twoFactor, err := s.repository.Find(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("Two-factor confirmation without a pending enrolment")
+			return nil, resterr.NewBadRequestError("Two-factor enrolment was not started")
+		}
+
+		log.Error("Failed to find two-factor settings in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while enabling two-factor authentication")
+	}
+
+	if twoFactor.ConfirmedAt != nil {
+		log.Warn("Two-factor enrolment confirmed twice")
+		return nil, resterr.NewConflictErr("Two-factor authentication is already enabled")
+	}
+
+	step, valid := security.ValidateTOTPCode(twoFactor.Secret, code, time.Now())
+	if !valid {
+		log.Warn("Invalid two-factor code provided")
+		return nil, resterr.NewBadRequestError("The two-factor code is invalid")
+	}
+
+	confirmedAt := time.Now()
+	twoFactor.ConfirmedAt = &confirmedAt
+	twoFactor.LastUsedStep = step
+	if err := s.repository.Update(ctx, twoFactor); err != nil {
+		log.Error("Failed to confirm two-factor settings in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while enabling two-factor authentication")
+	}
+
+	plainCodes := make([]string, 0, recoveryCodeCount)
+	recoveryCodes := make([]*entities.RecoveryCode, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		plainCode, err := security.GenerateRecoveryCode()
+		if err != nil {
+			log.Error("Failed to generate recovery code", slog.String("error", err.Error()))
+			return nil, resterr.NewInternalServerErr("An error occurred while generating the recovery codes")
+		}
+
+		plainCodes = append(plainCodes, plainCode)
+		recoveryCodes = append(recoveryCodes, factory.NewRecoveryCode(userID, security.HashToken(plainCode)))
+	}
+
+	if err := s.recoveryCodeRepository.ReplaceForUser(ctx, userID, recoveryCodes); err != nil {
+		log.Error("Failed to save recovery codes in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while generating the recovery codes")
+	}
+
+	return plainCodes, nil
+}
+
+func (s *twoFactorService) Disable(ctx context.Context, userID uuid.UUID, code string) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "TwoFactorService", "Disable")
+	defer endSpan(span, &restErr)
+
+	twoFactor, err := findConfirmedTwoFactor(ctx, s.repository, userID)
+	if err != nil {
+		log.Error("Failed to find two-factor settings in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while disabling two-factor authentication")
+	}
+
+	if twoFactor == nil {
+		log.Warn("Two-factor disabled for an account that does not have it enabled")
+		return resterr.NewBadRequestError("Two-factor authentication is not enabled")
+	}
+
+	user, err := s.userRepository.Find(ctx, userID)
+	if err != nil {
+		log.Error("Failed to find user in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while finding the user")
+	}
+
+	if config.Env.TwoFactor.RequiredForAdmins && user.RoleID == entities.RoleAdminID {
+		log.Warn("Admin attempted to disable mandatory two-factor authentication")
+		return resterr.NewForbiddenError("Two-factor authentication is required for this account")
+	}
+
+	valid, err := verifyTwoFactorCode(ctx, s.repository, s.recoveryCodeRepository, twoFactor, code)
+	if err != nil {
+		log.Error("Failed to verify two-factor code", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while disabling two-factor authentication")
+	}
+
+	if !valid {
+		log.Warn("Invalid two-factor code provided")
+		return resterr.NewBadRequestError("The two-factor code is invalid")
+	}
+
+	if err := s.repository.Delete(ctx, userID); err != nil {
+		log.Error("Failed to delete two-factor settings from database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while disabling two-factor authentication")
+	}
+
+	if err := s.recoveryCodeRepository.DeleteByUserID(ctx, userID); err != nil {
+		log.Error("Failed to delete recovery codes from database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while disabling two-factor authentication")
+	}
+
+	return nil
+}
+
+// RequireEnrollment blocks admins without two-factor authentication when
+// TOTP_REQUIRED_FOR_ADMINS is set, leaving them able to reach the enrolment
+// endpoints only.
+func (s *twoFactorService) RequireEnrollment(ctx context.Context, userClaims *entities.UserClaims) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "TwoFactorService", "RequireEnrollment")
+	defer endSpan(span, &restErr)
+
+	if !config.Env.TwoFactor.RequiredForAdmins || userClaims.RoleID != entities.RoleAdminID {
+		return nil
+	}
+
+	twoFactor, err := findConfirmedTwoFactor(ctx, s.repository, userClaims.ID)
+	if err != nil {
+		log.Error("Failed to find two-factor settings in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while validating two-factor authentication")
+	}
+
+	if twoFactor == nil {
+		log.Warn("Admin without two-factor authentication attempted a protected action")
+		return resterr.NewForbiddenError("Two-factor authentication must be enabled for this account")
+	}
+
+	return nil
+}
+
+// findConfirmedTwoFactor returns the two-factor settings of the user, or nil
+// when the user has not completed the enrolment.
+func findConfirmedTwoFactor(ctx context.Context, twoFactorRepository repository.TwoFactorRepository, userID uuid.UUID) (*entities.TwoFactor, error) {
+	twoFactor, err := twoFactorRepository.Find(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	if twoFactor.ConfirmedAt == nil {
+		return nil, nil
+	}
+
+	return twoFactor, nil
+}
+
+// verifyTwoFactorCode accepts either a TOTP code, which can only be used once
+// per time step, or one of the user's unused recovery codes.
+func verifyTwoFactorCode(
+	ctx context.Context,
+	twoFactorRepository repository.TwoFactorRepository,
+	recoveryCodeRepository repository.RecoveryCodeRepository,
+	twoFactor *entities.TwoFactor,
+	code string,
+) (bool, error) {
+	code = strings.TrimSpace(code)
+	if step, valid := security.ValidateTOTPCode(twoFactor.Secret, code, time.Now()); valid {
+		return twoFactorRepository.UpdateLastUsedStep(ctx, twoFactor.UserID, step)
+	}
+
+	return recoveryCodeRepository.Consume(ctx, twoFactor.UserID, security.HashToken(strings.ToLower(code)))
+}
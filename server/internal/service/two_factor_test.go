@@ -0,0 +1,224 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Bromolima/my-game-list/config"
+	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/security"
+	"github.com/Bromolima/my-game-list/internal/service"
+	"github.com/Bromolima/my-game-list/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestTwoFactorService_BeginEnrollment(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	twoFactorRepository := mocks.NewMockTwoFactorRepository(mockCtrl)
+	recoveryCodeRepository := mocks.NewMockRecoveryCodeRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	twoFactorService := service.NewTwoFactorService(twoFactorRepository, recoveryCodeRepository, userRepository, logger)
+
+	ctx := context.Background()
+	user := &entities.User{ID: uuid.New(), Email: "player@example.com"}
+
+	t.Run("should begin enrolment successfully", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		twoFactorRepository.EXPECT().Find(ctx, user.ID).Return(nil, gorm.ErrRecordNotFound)
+		twoFactorRepository.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+
+		secret, uri, err := twoFactorService.BeginEnrollment(ctx, user.ID)
+
+		assert.Nil(t, err)
+		assert.NotEmpty(t, secret)
+		assert.True(t, strings.HasPrefix(uri, "otpauth://totp/"))
+		assert.Contains(t, uri, secret)
+	})
+
+	t.Run("should return error when two-factor is already enabled", func(t *testing.T) {
+		confirmedAt := time.Now()
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		twoFactorRepository.EXPECT().Find(ctx, user.ID).Return(&entities.TwoFactor{UserID: user.ID, ConfirmedAt: &confirmedAt}, nil)
+
+		secret, uri, err := twoFactorService.BeginEnrollment(ctx, user.ID)
+
+		assert.NotNil(t, err)
+		assert.Empty(t, secret)
+		assert.Empty(t, uri)
+		assert.Equal(t, "Two-factor authentication is already enabled", err.Message)
+	})
+}
+
+func TestTwoFactorService_ConfirmEnrollment(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	twoFactorRepository := mocks.NewMockTwoFactorRepository(mockCtrl)
+	recoveryCodeRepository := mocks.NewMockRecoveryCodeRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	twoFactorService := service.NewTwoFactorService(twoFactorRepository, recoveryCodeRepository, userRepository, logger)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	secret, _ := security.GenerateTOTPSecret()
+
+	t.Run("should confirm enrolment and return recovery codes", func(t *testing.T) {
+		code, _ := security.GenerateTOTPCode(secret, security.TOTPStep(time.Now()))
+		twoFactorRepository.EXPECT().Find(ctx, userID).Return(&entities.TwoFactor{UserID: userID, Secret: secret}, nil)
+		twoFactorRepository.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, twoFactor *entities.TwoFactor) error {
+			assert.NotNil(t, twoFactor.ConfirmedAt)
+			return nil
+		})
+		recoveryCodeRepository.EXPECT().ReplaceForUser(ctx, userID, gomock.Len(10)).Return(nil)
+
+		recoveryCodes, err := twoFactorService.ConfirmEnrollment(ctx, userID, code)
+
+		assert.Nil(t, err)
+		assert.Len(t, recoveryCodes, 10)
+	})
+
+	t.Run("should return error when code is invalid", func(t *testing.T) {
+		twoFactorRepository.EXPECT().Find(ctx, userID).Return(&entities.TwoFactor{UserID: userID, Secret: secret}, nil)
+
+		recoveryCodes, err := twoFactorService.ConfirmEnrollment(ctx, userID, "000000x")
+
+		assert.NotNil(t, err)
+		assert.Nil(t, recoveryCodes)
+		assert.Equal(t, "The two-factor code is invalid", err.Message)
+	})
+
+	t.Run("should return error when enrolment was not started", func(t *testing.T) {
+		twoFactorRepository.EXPECT().Find(ctx, userID).Return(nil, gorm.ErrRecordNotFound)
+
+		recoveryCodes, err := twoFactorService.ConfirmEnrollment(ctx, userID, "123456")
+
+		assert.NotNil(t, err)
+		assert.Nil(t, recoveryCodes)
+		assert.Equal(t, "Two-factor enrolment was not started", err.Message)
+	})
+
+	t.Run("should return error when ReplaceForUser fails", func(t *testing.T) {
+		code, _ := security.GenerateTOTPCode(secret, security.TOTPStep(time.Now()))
+		twoFactorRepository.EXPECT().Find(ctx, userID).Return(&entities.TwoFactor{UserID: userID, Secret: secret}, nil)
+		twoFactorRepository.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+		recoveryCodeRepository.EXPECT().ReplaceForUser(ctx, userID, gomock.Any()).Return(errors.New("database error"))
+
+		recoveryCodes, err := twoFactorService.ConfirmEnrollment(ctx, userID, code)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, recoveryCodes)
+		assert.Equal(t, "An error occurred while generating the recovery codes", err.Message)
+	})
+}
+
+func TestTwoFactorService_Disable(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	twoFactorRepository := mocks.NewMockTwoFactorRepository(mockCtrl)
+	recoveryCodeRepository := mocks.NewMockRecoveryCodeRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	twoFactorService := service.NewTwoFactorService(twoFactorRepository, recoveryCodeRepository, userRepository, logger)
+
+	config.Env.TwoFactor.RequiredForAdmins = true
+	defer func() { config.Env.TwoFactor.RequiredForAdmins = false }()
+
+	ctx := context.Background()
+	user := &entities.User{ID: uuid.New(), RoleID: entities.RoleUserID}
+	secret, _ := security.GenerateTOTPSecret()
+	confirmedAt := time.Now()
+	twoFactor := &entities.TwoFactor{UserID: user.ID, Secret: secret, ConfirmedAt: &confirmedAt}
+
+	t.Run("should disable two-factor successfully", func(t *testing.T) {
+		code, _ := security.GenerateTOTPCode(secret, security.TOTPStep(time.Now()))
+		twoFactorRepository.EXPECT().Find(ctx, user.ID).Return(twoFactor, nil)
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		twoFactorRepository.EXPECT().UpdateLastUsedStep(ctx, user.ID, gomock.Any()).Return(true, nil)
+		twoFactorRepository.EXPECT().Delete(ctx, user.ID).Return(nil)
+		recoveryCodeRepository.EXPECT().DeleteByUserID(ctx, user.ID).Return(nil)
+
+		err := twoFactorService.Disable(ctx, user.ID, code)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return error when admin is required to keep two-factor", func(t *testing.T) {
+		twoFactorRepository.EXPECT().Find(ctx, user.ID).Return(twoFactor, nil)
+		userRepository.EXPECT().Find(ctx, user.ID).Return(&entities.User{ID: user.ID, RoleID: entities.RoleAdminID}, nil)
+
+		err := twoFactorService.Disable(ctx, user.ID, "123456")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Two-factor authentication is required for this account", err.Message)
+	})
+
+	t.Run("should return error when code was already used", func(t *testing.T) {
+		code, _ := security.GenerateTOTPCode(secret, security.TOTPStep(time.Now()))
+		twoFactorRepository.EXPECT().Find(ctx, user.ID).Return(twoFactor, nil)
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		twoFactorRepository.EXPECT().UpdateLastUsedStep(ctx, user.ID, gomock.Any()).Return(false, nil)
+
+		err := twoFactorService.Disable(ctx, user.ID, code)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The two-factor code is invalid", err.Message)
+	})
+}
+
+func TestTwoFactorService_RequireEnrollment(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	twoFactorRepository := mocks.NewMockTwoFactorRepository(mockCtrl)
+	recoveryCodeRepository := mocks.NewMockRecoveryCodeRepository(mockCtrl)
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	twoFactorService := service.NewTwoFactorService(twoFactorRepository, recoveryCodeRepository, userRepository, logger)
+
+	config.Env.TwoFactor.RequiredForAdmins = true
+	defer func() { config.Env.TwoFactor.RequiredForAdmins = false }()
+
+	ctx := context.Background()
+	adminClaims := &entities.UserClaims{ID: uuid.New(), RoleID: entities.RoleAdminID}
+
+	t.Run("should allow regular users without two-factor", func(t *testing.T) {
+		err := twoFactorService.RequireEnrollment(ctx, &entities.UserClaims{ID: uuid.New(), RoleID: entities.RoleUserID})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should allow enrolled admins", func(t *testing.T) {
+		confirmedAt := time.Now()
+		twoFactorRepository.EXPECT().Find(ctx, adminClaims.ID).Return(&entities.TwoFactor{UserID: adminClaims.ID, ConfirmedAt: &confirmedAt}, nil)
+
+		err := twoFactorService.RequireEnrollment(ctx, adminClaims)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return error when admin is not enrolled", func(t *testing.T) {
+		twoFactorRepository.EXPECT().Find(ctx, adminClaims.ID).Return(nil, gorm.ErrRecordNotFound)
+
+		err := twoFactorService.RequireEnrollment(ctx, adminClaims)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "Two-factor authentication must be enabled for this account", err.Message)
+	})
+}
@@ -4,13 +4,17 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"time"
 
 	"github.com/Bromolima/my-game-list/internal/entities"
 	"github.com/Bromolima/my-game-list/internal/factory"
 	resterr "github.com/Bromolima/my-game-list/internal/http/rest_err"
+	"github.com/Bromolima/my-game-list/internal/mailer"
+	"github.com/Bromolima/my-game-list/internal/metrics"
 	"github.com/Bromolima/my-game-list/internal/repository"
 	"github.com/Bromolima/my-game-list/internal/security"
 	"github.com/Bromolima/my-game-list/internal/token"
+	"github.com/Bromolima/my-game-list/logger"
 	"github.com/google/uuid"
 
 	"gorm.io/gorm"
@@ -20,27 +24,58 @@ type UserService interface {
 	RegisterUser(ctx context.Context, email, password, username, avatarURL string) *resterr.RestErr
 	FindUser(ctx context.Context, id string) (*entities.User, *resterr.RestErr)
 	SearchUsers(ctx context.Context, page *entities.Page[entities.User], query string) (*entities.Page[entities.User], *resterr.RestErr)
-	UpdateUser(ctx context.Context, id uuid.UUID, email, password, username, avatarURL string) *resterr.RestErr
+	UpdateUser(ctx context.Context, id, sessionID uuid.UUID, email, password, username, avatarURL string) *resterr.RestErr
 	DeleteUser(ctx context.Context, id string) *resterr.RestErr
-	Login(ctx context.Context, email, password string) (string, *resterr.RestErr)
+	Login(ctx context.Context, email, password, ipAddress, userAgent string) (*entities.LoginResult, *resterr.RestErr)
+	CompleteTwoFactorLogin(ctx context.Context, challengeToken, code, ipAddress, userAgent string) (*entities.AuthTokens, *resterr.RestErr)
+	UnlockAccount(ctx context.Context, id uuid.UUID) *resterr.RestErr
 }
 
 type userService struct {
-	repository   repository.UserRepository
-	tokenService token.JwtService
-	logger       *slog.Logger
+	repository              repository.UserRepository
+	sessionRepository       repository.SessionRepository
+	userTokenRepository     repository.UserTokenRepository
+	twoFactorRepository     repository.TwoFactorRepository
+	recoveryCodeRepository  repository.RecoveryCodeRepository
+	loginThrottleRepository repository.LoginThrottleRepository
+	tokenService            token.JwtService
+	mailer                  mailer.Mailer
+	auditService            AuditService
+	metrics                 *metrics.Metrics
+	logger                  *slog.Logger
 }
 
-func NewUserService(repository repository.UserRepository, tokenService token.JwtService, logger *slog.Logger) UserService {
+func NewUserService(
+	repository repository.UserRepository,
+	sessionRepository repository.SessionRepository,
+	userTokenRepository repository.UserTokenRepository,
+	twoFactorRepository repository.TwoFactorRepository,
+	recoveryCodeRepository repository.RecoveryCodeRepository,
+	loginThrottleRepository repository.LoginThrottleRepository,
+	tokenService token.JwtService,
+	mailer mailer.Mailer,
+	auditService AuditService,
+	metrics *metrics.Metrics,
+	logger *slog.Logger,
+) UserService {
 	return &userService{
-		repository:   repository,
-		tokenService: tokenService,
-		logger:       logger.With(slog.String("service", "user")),
+		repository:              repository,
+		sessionRepository:       sessionRepository,
+		userTokenRepository:     userTokenRepository,
+		twoFactorRepository:     twoFactorRepository,
+		recoveryCodeRepository:  recoveryCodeRepository,
+		loginThrottleRepository: loginThrottleRepository,
+		tokenService:            tokenService,
+		mailer:                  mailer,
+		auditService:            auditService,
+		metrics:                 metrics,
+		logger:                  logger.With(slog.String("service", "user")),
 	}
 }
 
-func (s *userService) RegisterUser(ctx context.Context, email, password, username, avatarURL string) *resterr.RestErr {
-	log := s.logger.With(slog.String("func", "RegisterUser"))
+func (s *userService) RegisterUser(ctx context.Context, email, password, username, avatarURL string) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "UserService", "RegisterUser")
+	defer endSpan(span, &restErr)
 
 	userExists, err := s.repository.FindByEmail(ctx, email)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
@@ -65,39 +100,193 @@ func (s *userService) RegisterUser(ctx context.Context, email, password, usernam
 		return resterr.NewInternalServerErr("An error occurred while creating the user")
 	}
 
+	s.metrics.RecordRegistration()
+
+	// The account is created even if the email cannot be sent, the user can
+	// ask for a new verification email later.
+	if err := sendEmailVerification(ctx, s.userTokenRepository, s.mailer, user); err != nil {
+		log.Error("Failed to send verification email", slog.String("error", err.Error()))
+	}
+
 	return nil
 }
 
-func (s *userService) Login(ctx context.Context, email, password string) (string, *resterr.RestErr) {
-	log := s.logger.With(slog.String("func", "Login"))
+// Login verifies the password and opens a session. Users with two-factor
+// authentication enabled get a challenge token instead, which must be
+// exchanged through CompleteTwoFactorLogin.
+func (s *userService) Login(ctx context.Context, email, password, ipAddress, userAgent string) (_ *entities.LoginResult, restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "UserService", "Login")
+	defer endSpan(span, &restErr)
+
+	now := time.Now()
+	locked, err := isLoginLocked(ctx, s.loginThrottleRepository, email, ipAddress, now)
+	if err != nil {
+		log.Error("Failed to check login throttle", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while finding the user")
+	}
+
+	if locked {
+		log.Warn("Login attempted while locked out")
+		return nil, resterr.NewTooManyRequestsError("Too many failed login attempts, please try again later")
+	}
 
 	userExists, err := s.repository.FindByEmail(ctx, email)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		log.Error("Failed to find user by email", slog.String("error", err.Error()))
-		return "", resterr.NewInternalServerErr("An error occurred while finding the user")
+		return nil, resterr.NewInternalServerErr("An error occurred while finding the user")
 	}
 
+	// Unknown emails and wrong passwords get the same response and take the
+	// same time, so the endpoint does not reveal which accounts exist.
 	if userExists == nil {
-		log.Warn("The requested user was not found")
-		return "", resterr.NewNotFoundError("The requested user was not found")
+		security.CompareDummyPassword(password)
+		log.Warn("Invalid credentials provided")
+		return nil, s.loginFailed(ctx, email, ipAddress, now)
 	}
 
 	if !security.CheckPassword(userExists.Password, password) {
 		log.Warn("Invalid credentials provided")
-		return "", resterr.NewUnauthorizedError("Invalid credentials provided")
+		s.auditService.Record(ctx, entities.AuditLoginFailed, entities.AuditTargetUser, userExists.ID.String(), nil, nil)
+		return nil, s.loginFailed(ctx, email, ipAddress, now)
+	}
+
+	result, restErr := completeLogin(ctx, log, s.twoFactorRepository, s.sessionRepository, s.tokenService, userExists, ipAddress, userAgent)
+	if restErr != nil {
+		return nil, restErr
+	}
+
+	// The failures are only forgiven once a session is issued, otherwise the
+	// password alone would clear the failed attempts at the second factor.
+	if result.AuthTokens != nil {
+		s.resetAccountThrottle(ctx, email)
+	}
+
+	recordLogin(ctx, s.auditService, s.metrics, userExists, result)
+	return result, nil
+}
+
+func (s *userService) CompleteTwoFactorLogin(ctx context.Context, challengeToken, code, ipAddress, userAgent string) (_ *entities.AuthTokens, restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "UserService", "CompleteTwoFactorLogin")
+	defer endSpan(span, &restErr)
+
+	userID, err := s.tokenService.ParseChallengeToken(challengeToken)
+	if err != nil {
+		log.Warn("Invalid challenge token provided")
+		return nil, resterr.NewUnauthorizedError("The challenge token is invalid or has expired")
+	}
+
+	user, err := s.repository.Find(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("The owner of the challenge token was not found")
+			return nil, resterr.NewUnauthorizedError("The challenge token is invalid or has expired")
+		}
+
+		log.Error("Failed to find user in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while finding the user")
+	}
+
+	now := time.Now()
+	locked, err := isLoginLocked(ctx, s.loginThrottleRepository, user.Email, ipAddress, now)
+	if err != nil {
+		log.Error("Failed to check login throttle", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while finding the user")
+	}
+
+	if locked {
+		log.Warn("Two-factor login attempted while locked out")
+		return nil, resterr.NewTooManyRequestsError("Too many failed login attempts, please try again later")
+	}
+
+	twoFactor, err := findConfirmedTwoFactor(ctx, s.twoFactorRepository, userID)
+	if err != nil {
+		log.Error("Failed to find two-factor settings in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while finding the user")
+	}
+
+	if twoFactor == nil {
+		log.Warn("Two-factor login attempted for an account without two-factor authentication")
+		return nil, resterr.NewUnauthorizedError("The challenge token is invalid or has expired")
+	}
+
+	valid, err := verifyTwoFactorCode(ctx, s.twoFactorRepository, s.recoveryCodeRepository, twoFactor, code)
+	if err != nil {
+		log.Error("Failed to verify two-factor code", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while verifying the two-factor code")
+	}
+
+	if !valid {
+		log.Warn("Invalid two-factor code provided")
+		s.auditService.Record(ctx, entities.AuditLoginFailed, entities.AuditTargetUser, user.ID.String(), nil, nil)
+		s.metrics.RecordLogin(metrics.LoginFailed)
+		if err := recordLoginFailure(ctx, s.loginThrottleRepository, user.Email, ipAddress, now); err != nil {
+			log.Error("Failed to record login failure", slog.String("error", err.Error()))
+		}
+
+		return nil, resterr.NewUnauthorizedError("The two-factor code is invalid")
+	}
+
+	tokens, restErr := createSession(ctx, log, s.sessionRepository, s.tokenService, user, ipAddress, userAgent)
+	if restErr != nil {
+		return nil, restErr
 	}
 
-	token, err := s.tokenService.GenerateToken(userExists)
+	s.resetAccountThrottle(ctx, user.Email)
+	s.auditService.Record(ctx, entities.AuditLoginSucceeded, entities.AuditTargetUser, user.ID.String(), nil, nil)
+	s.metrics.RecordLogin(metrics.LoginSucceeded)
+	return tokens, nil
+}
+
+func (s *userService) UnlockAccount(ctx context.Context, id uuid.UUID) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "UserService", "UnlockAccount")
+	defer endSpan(span, &restErr)
+
+	user, err := s.repository.Find(ctx, id)
 	if err != nil {
-		log.Error("Failed to generate token", slog.String("error", err.Error()))
-		return "", resterr.NewInternalServerErr("An error occurred while generating the token")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Warn("The requested user was not found")
+			return resterr.NewNotFoundError("The requested user was not found")
+		}
+
+		log.Error("Failed to find user in database", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while finding the user")
+	}
+
+	if err := resetAccountThrottle(ctx, s.loginThrottleRepository, user.Email); err != nil {
+		log.Error("Failed to reset login throttle", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while unlocking the account")
+	}
+
+	return nil
+}
+
+// resetAccountThrottle forgives the failed attempts of the account once the
+// user is logged in. The session is already issued, so a failure is only
+// logged.
+func (s *userService) resetAccountThrottle(ctx context.Context, email string) {
+	log := logger.Scoped(ctx, s.logger).With(slog.String("func", "resetAccountThrottle"))
+
+	if err := resetAccountThrottle(ctx, s.loginThrottleRepository, email); err != nil {
+		log.Error("Failed to reset login throttle", slog.String("error", err.Error()))
+	}
+}
+
+func (s *userService) loginFailed(ctx context.Context, email, ipAddress string, now time.Time) *resterr.RestErr {
+	log := logger.Scoped(ctx, s.logger).With(slog.String("func", "loginFailed"))
+
+	s.metrics.RecordLogin(metrics.LoginFailed)
+
+	if err := recordLoginFailure(ctx, s.loginThrottleRepository, email, ipAddress, now); err != nil {
+		log.Error("Failed to record login failure", slog.String("error", err.Error()))
+		return resterr.NewInternalServerErr("An error occurred while finding the user")
 	}
 
-	return token, nil
+	return resterr.NewUnauthorizedError("Invalid credentials provided")
 }
 
-func (s *userService) FindUser(ctx context.Context, id string) (*entities.User, *resterr.RestErr) {
-	log := s.logger.With(slog.String("func", "FindUser"))
+func (s *userService) FindUser(ctx context.Context, id string) (_ *entities.User, restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "UserService", "FindUser")
+	defer endSpan(span, &restErr)
 
 	user, err := s.repository.Find(ctx, uuid.MustParse(id))
 	if err != nil {
@@ -113,8 +302,9 @@ func (s *userService) FindUser(ctx context.Context, id string) (*entities.User,
 	return user, nil
 }
 
-func (s *userService) SearchUsers(ctx context.Context, page *entities.Page[entities.User], query string) (*entities.Page[entities.User], *resterr.RestErr) {
-	log := s.logger.With(slog.String("func", "SearchUsers"))
+func (s *userService) SearchUsers(ctx context.Context, page *entities.Page[entities.User], query string) (_ *entities.Page[entities.User], restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "UserService", "SearchUsers")
+	defer endSpan(span, &restErr)
 
 	page, err := s.repository.Search(ctx, page, query)
 	if err != nil {
@@ -130,40 +320,87 @@ func (s *userService) SearchUsers(ctx context.Context, page *entities.Page[entit
 	return page, nil
 }
 
-func (s *userService) UpdateUser(ctx context.Context, id uuid.UUID, email, password, username, avatarURL string) *resterr.RestErr {
-	log := s.logger.With(slog.String("func", "UpdateUser"))
+// UpdateUser changes the profile of the user. A new password logs out every
+// other session, keeping the one the change was made from.
+func (s *userService) UpdateUser(ctx context.Context, id, sessionID uuid.UUID, email, password, username, avatarURL string) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "UserService", "UpdateUser")
+	defer endSpan(span, &restErr)
 
-	_, err := s.repository.FindByEmail(ctx, email)
+	user, err := s.repository.Find(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			log.Warn("The requested user was not found")
 			return resterr.NewNotFoundError("The requested user was not found")
 		}
 
-		log.Error("Failed to find user by email", slog.String("error", err.Error()))
+		log.Error("Failed to find user in database", slog.String("error", err.Error()))
 		return resterr.NewInternalServerErr("An error occurred while finding the user")
 	}
 
-	hashedPassword, err := security.HashPassword(password)
-	if err != nil {
-		log.Error("Failed to hash password", slog.String("error", err.Error()))
-		return resterr.NewInternalServerErr("An error occurred while hashing the password")
+	columns := map[string]any{"username": username, "avatar_url": avatarURL}
+
+	// A new address has not been verified yet.
+	emailChanged := email != user.Email
+	if emailChanged {
+		emailOwner, err := s.repository.FindByEmail(ctx, email)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Error("Failed to find user by email", slog.String("error", err.Error()))
+			return resterr.NewInternalServerErr("An error occurred while finding the user")
+		}
+
+		if emailOwner != nil {
+			log.Warn("The email is already used by another user")
+			return resterr.NewConflictErr("The email is already in use")
+		}
+
+		columns["email"] = email
+		columns["email_verified"] = false
+		columns["email_verified_at"] = nil
 	}
 
-	user := factory.NewUserUpdate(id, email, hashedPassword, username, avatarURL)
-	if err := s.repository.Update(ctx, user); err != nil {
+	passwordChanged := !security.CheckPassword(user.Password, password)
+	if passwordChanged {
+		hashedPassword, err := security.HashPassword(password)
+		if err != nil {
+			log.Error("Failed to hash password", slog.String("error", err.Error()))
+			return resterr.NewInternalServerErr("An error occurred while hashing the password")
+		}
+
+		columns["password"] = hashedPassword
+	}
+
+	if err := s.repository.UpdateColumns(ctx, user, columns); err != nil {
 		log.Error("Failed to update user in database", slog.String("error", err.Error()))
 		return resterr.NewInternalServerErr("An error occurred while updating the user")
 	}
 
+	if emailChanged {
+		// Sending the verification also invalidates the tokens sent to the
+		// previous address.
+		user.Email = email
+		if err := sendEmailVerification(ctx, s.userTokenRepository, s.mailer, user); err != nil {
+			log.Error("Failed to send verification email", slog.String("error", err.Error()))
+		}
+	}
+
+	if passwordChanged {
+		if err := s.sessionRepository.RevokeOthersByUserID(ctx, id, sessionID); err != nil {
+			log.Error("Failed to revoke other user sessions in database", slog.String("error", err.Error()))
+			return resterr.NewInternalServerErr("An error occurred while revoking the sessions")
+		}
+
+		s.auditService.Record(ctx, entities.AuditPasswordChanged, entities.AuditTargetUser, id.String(), nil, nil)
+	}
+
 	return nil
 }
 
-func (s *userService) DeleteUser(ctx context.Context, id string) *resterr.RestErr {
-	log := s.logger.With(slog.String("func", "DeleteUser"))
+func (s *userService) DeleteUser(ctx context.Context, id string) (restErr *resterr.RestErr) {
+	ctx, span, log := startSpan(ctx, s.logger, "UserService", "DeleteUser")
+	defer endSpan(span, &restErr)
 
 	uniqueID := uuid.MustParse(id)
-	_, err := s.repository.Find(ctx, uniqueID)
+	user, err := s.repository.Find(ctx, uniqueID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			log.Warn("The requested user was not found")
@@ -179,5 +416,52 @@ func (s *userService) DeleteUser(ctx context.Context, id string) *resterr.RestEr
 		return resterr.NewInternalServerErr("An error occurred while deleting the user")
 	}
 
+	s.auditService.Record(ctx, entities.AuditUserDeleted, entities.AuditTargetUser, id, factory.NewAuditSnapshotFromUser(user), nil)
 	return nil
 }
+
+// recordLogin audits and counts logins that opened a session. Logins answered
+// with a two-factor challenge are recorded once the challenge is completed.
+func recordLogin(ctx context.Context, auditService AuditService, m *metrics.Metrics, user *entities.User, result *entities.LoginResult) {
+	if result.AuthTokens == nil {
+		return
+	}
+
+	auditService.Record(ctx, entities.AuditLoginSucceeded, entities.AuditTargetUser, user.ID.String(), nil, nil)
+	m.RecordLogin(metrics.LoginSucceeded)
+}
+
+// completeLogin returns a challenge for users with two-factor authentication
+// enabled and opens a session for everyone else.
+func completeLogin(
+	ctx context.Context,
+	log *slog.Logger,
+	twoFactorRepository repository.TwoFactorRepository,
+	sessionRepository repository.SessionRepository,
+	tokenService token.JwtService,
+	user *entities.User,
+	ipAddress, userAgent string,
+) (*entities.LoginResult, *resterr.RestErr) {
+	twoFactor, err := findConfirmedTwoFactor(ctx, twoFactorRepository, user.ID)
+	if err != nil {
+		log.Error("Failed to find two-factor settings in database", slog.String("error", err.Error()))
+		return nil, resterr.NewInternalServerErr("An error occurred while finding the user")
+	}
+
+	if twoFactor != nil {
+		challengeToken, err := tokenService.GenerateChallengeToken(user.ID)
+		if err != nil {
+			log.Error("Failed to generate challenge token", slog.String("error", err.Error()))
+			return nil, resterr.NewInternalServerErr("An error occurred while generating the token")
+		}
+
+		return factory.NewChallengeLoginResult(challengeToken, time.Now().Add(token.ChallengeTokenDuration)), nil
+	}
+
+	tokens, restErr := createSession(ctx, log, sessionRepository, tokenService, user, ipAddress, userAgent)
+	if restErr != nil {
+		return nil, restErr
+	}
+
+	return factory.NewLoginResult(tokens), nil
+}
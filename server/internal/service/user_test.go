@@ -6,8 +6,12 @@ import (
 	"log/slog"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/Bromolima/my-game-list/config"
 	"github.com/Bromolima/my-game-list/internal/entities"
+	"github.com/Bromolima/my-game-list/internal/mailer"
+	"github.com/Bromolima/my-game-list/internal/metrics"
 	"github.com/Bromolima/my-game-list/internal/security"
 	"github.com/Bromolima/my-game-list/internal/service"
 	"github.com/Bromolima/my-game-list/mocks"
@@ -22,10 +26,18 @@ func TestUserService_RegisterUser(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	userTokenRepository := mocks.NewMockUserTokenRepository(mockCtrl)
+	twoFactorRepository := mocks.NewMockTwoFactorRepository(mockCtrl)
+	recoveryCodeRepository := mocks.NewMockRecoveryCodeRepository(mockCtrl)
+	loginThrottleRepository := mocks.NewMockLoginThrottleRepository(mockCtrl)
 	tokenService := mocks.NewMockJwtService(mockCtrl)
+	mailSender := mocks.NewMockMailer(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 
-	userService := service.NewUserService(userRepository, tokenService, logger)
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	userService := service.NewUserService(userRepository, sessionRepository, userTokenRepository, twoFactorRepository, recoveryCodeRepository, loginThrottleRepository, tokenService, mailSender, auditService, metrics.NewMetrics(), logger)
 
 	ctx := context.Background()
 	email := "test@example.com"
@@ -36,6 +48,24 @@ func TestUserService_RegisterUser(t *testing.T) {
 	t.Run("should register user successfully", func(t *testing.T) {
 		userRepository.EXPECT().FindByEmail(ctx, email).Return(nil, gorm.ErrRecordNotFound)
 		userRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		userTokenRepository.EXPECT().InvalidateByUserID(ctx, gomock.Any(), entities.UserTokenPurposeEmailVerification).Return(nil)
+		userTokenRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		mailSender.EXPECT().Send(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, message *mailer.Message) error {
+			assert.Equal(t, email, message.To)
+			return nil
+		})
+
+		err := userService.RegisterUser(ctx, email, password, username, avatarURL)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should register user when verification email fails", func(t *testing.T) {
+		userRepository.EXPECT().FindByEmail(ctx, email).Return(nil, gorm.ErrRecordNotFound)
+		userRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		userTokenRepository.EXPECT().InvalidateByUserID(ctx, gomock.Any(), entities.UserTokenPurposeEmailVerification).Return(nil)
+		userTokenRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		mailSender.EXPECT().Send(ctx, gomock.Any()).Return(errors.New("smtp error"))
 
 		err := userService.RegisterUser(ctx, email, password, username, avatarURL)
 
@@ -76,54 +106,127 @@ func TestUserService_Login(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	userTokenRepository := mocks.NewMockUserTokenRepository(mockCtrl)
+	twoFactorRepository := mocks.NewMockTwoFactorRepository(mockCtrl)
+	recoveryCodeRepository := mocks.NewMockRecoveryCodeRepository(mockCtrl)
+	loginThrottleRepository := mocks.NewMockLoginThrottleRepository(mockCtrl)
 	tokenService := mocks.NewMockJwtService(mockCtrl)
+	mailSender := mocks.NewMockMailer(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 
-	userService := service.NewUserService(userRepository, tokenService, logger)
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	userService := service.NewUserService(userRepository, sessionRepository, userTokenRepository, twoFactorRepository, recoveryCodeRepository, loginThrottleRepository, tokenService, mailSender, auditService, metrics.NewMetrics(), logger)
 
 	ctx := context.Background()
 	email := "test@example.com"
 	password := "password123"
+	ipAddress := "127.0.0.1"
+	userAgent := "test-agent"
 	hashedPassword, _ := security.HashPassword(password)
 	user := &entities.User{
+		ID:       uuid.New(),
 		Email:    email,
 		Password: hashedPassword,
 	}
 
 	t.Run("should login successfully", func(t *testing.T) {
+		loginThrottleRepository.EXPECT().Find(ctx, gomock.Any()).Return(nil, nil).Times(2)
 		userRepository.EXPECT().FindByEmail(ctx, email).Return(user, nil)
-		tokenService.EXPECT().GenerateToken(user).Return("token", nil)
+		twoFactorRepository.EXPECT().Find(ctx, user.ID).Return(nil, gorm.ErrRecordNotFound)
+		tokenService.EXPECT().GenerateToken(user, gomock.Any()).Return("token", nil)
+		sessionRepository.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, session *entities.Session) error {
+			assert.Equal(t, user.ID, session.UserID)
+			assert.Equal(t, ipAddress, session.IPAdress)
+			assert.Equal(t, userAgent, session.UserAgent)
+			assert.NotEmpty(t, session.Token)
+			return nil
+		})
+		loginThrottleRepository.EXPECT().Reset(ctx, "account:"+email).Return(nil)
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		loginResult, err := userService.Login(ctx, email, password, ipAddress, userAgent)
 
-		token, err := userService.Login(ctx, email, password)
+		assert.Nil(t, err)
+		assert.Nil(t, loginResult.Challenge)
+		assert.Equal(t, "token", loginResult.AuthTokens.AccessToken)
+		assert.NotEmpty(t, loginResult.AuthTokens.RefreshToken)
+	})
+
+	t.Run("should return challenge when two-factor is enabled", func(t *testing.T) {
+		loginThrottleRepository.EXPECT().Find(ctx, gomock.Any()).Return(nil, nil).Times(2)
+		confirmedAt := time.Now()
+		userRepository.EXPECT().FindByEmail(ctx, email).Return(user, nil)
+		twoFactorRepository.EXPECT().Find(ctx, user.ID).Return(&entities.TwoFactor{UserID: user.ID, ConfirmedAt: &confirmedAt}, nil)
+		tokenService.EXPECT().GenerateChallengeToken(user.ID).Return("challenge", nil)
+
+		loginResult, err := userService.Login(ctx, email, password, ipAddress, userAgent)
 
 		assert.Nil(t, err)
-		assert.Equal(t, "token", token)
+		assert.Nil(t, loginResult.AuthTokens)
+		assert.Equal(t, "challenge", loginResult.Challenge.Token)
 	})
 
 	t.Run("should return error when user is not found", func(t *testing.T) {
+		loginThrottleRepository.EXPECT().Find(ctx, gomock.Any()).Return(nil, nil).Times(2)
 		userRepository.EXPECT().FindByEmail(ctx, email).Return(nil, gorm.ErrRecordNotFound)
+		loginThrottleRepository.EXPECT().RecordFailure(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(&entities.LoginThrottle{Failures: 1}, nil).Times(2)
 
-		token, err := userService.Login(ctx, email, password)
+		token, err := userService.Login(ctx, email, password, ipAddress, userAgent)
 
 		assert.NotNil(t, err)
 		assert.Empty(t, token)
-		assert.Equal(t, "The requested user was not found", err.Message)
+		assert.Equal(t, "Invalid credentials provided", err.Message)
 	})
 
 	t.Run("should return error when password is wrong", func(t *testing.T) {
+		loginThrottleRepository.EXPECT().Find(ctx, gomock.Any()).Return(nil, nil).Times(2)
 		userRepository.EXPECT().FindByEmail(ctx, email).Return(user, nil)
+		loginThrottleRepository.EXPECT().RecordFailure(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(&entities.LoginThrottle{Failures: 1}, nil).Times(2)
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
 
-		token, err := userService.Login(ctx, email, "wrongpassword")
+		token, err := userService.Login(ctx, email, "wrongpassword", ipAddress, userAgent)
 
 		assert.NotNil(t, err)
 		assert.Empty(t, token)
 		assert.Equal(t, "Invalid credentials provided", err.Message)
 	})
 
+	t.Run("should lock the account when failures reach the limit", func(t *testing.T) {
+		config.Env.LoginProtection = config.LoginProtection{MaxAccountFailures: 5, MaxIPFailures: 20, BaseLockout: time.Minute, MaxLockout: time.Hour}
+		defer func() { config.Env.LoginProtection = config.LoginProtection{} }()
+
+		loginThrottleRepository.EXPECT().Find(ctx, gomock.Any()).Return(nil, nil).Times(2)
+		userRepository.EXPECT().FindByEmail(ctx, email).Return(user, nil)
+		loginThrottleRepository.EXPECT().RecordFailure(ctx, "account:"+email, gomock.Any(), gomock.Any()).Return(&entities.LoginThrottle{Failures: 5}, nil)
+		loginThrottleRepository.EXPECT().RecordFailure(ctx, "ip:"+ipAddress, gomock.Any(), gomock.Any()).Return(&entities.LoginThrottle{Failures: 5}, nil)
+		loginThrottleRepository.EXPECT().Lock(ctx, "account:"+email, gomock.Any()).Return(nil)
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		token, err := userService.Login(ctx, email, "wrongpassword", ipAddress, userAgent)
+
+		assert.NotNil(t, err)
+		assert.Empty(t, token)
+		assert.Equal(t, "Invalid credentials provided", err.Message)
+	})
+
+	t.Run("should return error when account is locked", func(t *testing.T) {
+		lockedUntil := time.Now().Add(time.Minute)
+		loginThrottleRepository.EXPECT().Find(ctx, "account:"+email).Return(&entities.LoginThrottle{Failures: 5, LockedUntil: &lockedUntil}, nil)
+
+		token, err := userService.Login(ctx, email, password, ipAddress, userAgent)
+
+		assert.NotNil(t, err)
+		assert.Empty(t, token)
+		assert.Equal(t, "Too many failed login attempts, please try again later", err.Message)
+	})
+
 	t.Run("should return error when FindByEmail fails", func(t *testing.T) {
+		loginThrottleRepository.EXPECT().Find(ctx, gomock.Any()).Return(nil, nil).Times(2)
 		userRepository.EXPECT().FindByEmail(ctx, email).Return(nil, errors.New("database error"))
 
-		token, err := userService.Login(ctx, email, password)
+		token, err := userService.Login(ctx, email, password, ipAddress, userAgent)
 
 		assert.NotNil(t, err)
 		assert.Empty(t, token)
@@ -131,15 +234,119 @@ func TestUserService_Login(t *testing.T) {
 	})
 
 	t.Run("should return error when GenerateToken fails", func(t *testing.T) {
+		loginThrottleRepository.EXPECT().Find(ctx, gomock.Any()).Return(nil, nil).Times(2)
 		userRepository.EXPECT().FindByEmail(ctx, email).Return(user, nil)
-		tokenService.EXPECT().GenerateToken(user).Return("", errors.New("token error"))
+		twoFactorRepository.EXPECT().Find(ctx, user.ID).Return(nil, gorm.ErrRecordNotFound)
+		tokenService.EXPECT().GenerateToken(user, gomock.Any()).Return("", errors.New("token error"))
 
-		token, err := userService.Login(ctx, email, password)
+		token, err := userService.Login(ctx, email, password, ipAddress, userAgent)
 
 		assert.NotNil(t, err)
 		assert.Empty(t, token)
 		assert.Equal(t, "An error occurred while generating the token", err.Message)
 	})
+
+	t.Run("should return error when session creation fails", func(t *testing.T) {
+		loginThrottleRepository.EXPECT().Find(ctx, gomock.Any()).Return(nil, nil).Times(2)
+		userRepository.EXPECT().FindByEmail(ctx, email).Return(user, nil)
+		twoFactorRepository.EXPECT().Find(ctx, user.ID).Return(nil, gorm.ErrRecordNotFound)
+		tokenService.EXPECT().GenerateToken(user, gomock.Any()).Return("token", nil)
+		sessionRepository.EXPECT().Create(ctx, gomock.Any()).Return(errors.New("database error"))
+
+		token, err := userService.Login(ctx, email, password, ipAddress, userAgent)
+
+		assert.NotNil(t, err)
+		assert.Empty(t, token)
+		assert.Equal(t, "An error occurred while creating the session", err.Message)
+	})
+}
+
+func TestUserService_CompleteTwoFactorLogin(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	userTokenRepository := mocks.NewMockUserTokenRepository(mockCtrl)
+	twoFactorRepository := mocks.NewMockTwoFactorRepository(mockCtrl)
+	recoveryCodeRepository := mocks.NewMockRecoveryCodeRepository(mockCtrl)
+	loginThrottleRepository := mocks.NewMockLoginThrottleRepository(mockCtrl)
+	tokenService := mocks.NewMockJwtService(mockCtrl)
+	mailSender := mocks.NewMockMailer(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	userService := service.NewUserService(userRepository, sessionRepository, userTokenRepository, twoFactorRepository, recoveryCodeRepository, loginThrottleRepository, tokenService, mailSender, auditService, metrics.NewMetrics(), logger)
+
+	ctx := context.Background()
+	ipAddress := "127.0.0.1"
+	userAgent := "test-agent"
+	user := &entities.User{ID: uuid.New(), Email: "test@example.com"}
+	secret, _ := security.GenerateTOTPSecret()
+	confirmedAt := time.Now()
+	twoFactor := &entities.TwoFactor{UserID: user.ID, Secret: secret, ConfirmedAt: &confirmedAt}
+
+	t.Run("should complete login with a TOTP code", func(t *testing.T) {
+		code, _ := security.GenerateTOTPCode(secret, security.TOTPStep(time.Now()))
+		tokenService.EXPECT().ParseChallengeToken("challenge").Return(user.ID, nil)
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		loginThrottleRepository.EXPECT().Find(ctx, gomock.Any()).Return(nil, nil).Times(2)
+		twoFactorRepository.EXPECT().Find(ctx, user.ID).Return(twoFactor, nil)
+		twoFactorRepository.EXPECT().UpdateLastUsedStep(ctx, user.ID, gomock.Any()).Return(true, nil)
+		tokenService.EXPECT().GenerateToken(user, gomock.Any()).Return("token", nil)
+		sessionRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		loginThrottleRepository.EXPECT().Reset(ctx, "account:"+user.Email).Return(nil)
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		tokens, err := userService.CompleteTwoFactorLogin(ctx, "challenge", code, ipAddress, userAgent)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "token", tokens.AccessToken)
+	})
+
+	t.Run("should complete login with a recovery code", func(t *testing.T) {
+		tokenService.EXPECT().ParseChallengeToken("challenge").Return(user.ID, nil)
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		loginThrottleRepository.EXPECT().Find(ctx, gomock.Any()).Return(nil, nil).Times(2)
+		twoFactorRepository.EXPECT().Find(ctx, user.ID).Return(twoFactor, nil)
+		recoveryCodeRepository.EXPECT().Consume(ctx, user.ID, security.HashToken("abcdefgh-ijklmnop")).Return(true, nil)
+		tokenService.EXPECT().GenerateToken(user, gomock.Any()).Return("token", nil)
+		sessionRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		loginThrottleRepository.EXPECT().Reset(ctx, "account:"+user.Email).Return(nil)
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		tokens, err := userService.CompleteTwoFactorLogin(ctx, "challenge", "ABCDEFGH-IJKLMNOP", ipAddress, userAgent)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "token", tokens.AccessToken)
+	})
+
+	t.Run("should return error when challenge token is invalid", func(t *testing.T) {
+		tokenService.EXPECT().ParseChallengeToken("challenge").Return(uuid.Nil, errors.New("invalid token"))
+
+		tokens, err := userService.CompleteTwoFactorLogin(ctx, "challenge", "123456", ipAddress, userAgent)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, tokens)
+		assert.Equal(t, "The challenge token is invalid or has expired", err.Message)
+	})
+
+	t.Run("should return error when code is invalid", func(t *testing.T) {
+		tokenService.EXPECT().ParseChallengeToken("challenge").Return(user.ID, nil)
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		loginThrottleRepository.EXPECT().Find(ctx, gomock.Any()).Return(nil, nil).Times(2)
+		twoFactorRepository.EXPECT().Find(ctx, user.ID).Return(twoFactor, nil)
+		recoveryCodeRepository.EXPECT().Consume(ctx, user.ID, gomock.Any()).Return(false, nil)
+		loginThrottleRepository.EXPECT().RecordFailure(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(&entities.LoginThrottle{Failures: 1}, nil).Times(2)
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		tokens, err := userService.CompleteTwoFactorLogin(ctx, "challenge", "not-a-code", ipAddress, userAgent)
+
+		assert.NotNil(t, err)
+		assert.Nil(t, tokens)
+		assert.Equal(t, "The two-factor code is invalid", err.Message)
+	})
 }
 
 func TestUserService_FindUser(t *testing.T) {
@@ -147,10 +354,18 @@ func TestUserService_FindUser(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	userTokenRepository := mocks.NewMockUserTokenRepository(mockCtrl)
+	twoFactorRepository := mocks.NewMockTwoFactorRepository(mockCtrl)
+	recoveryCodeRepository := mocks.NewMockRecoveryCodeRepository(mockCtrl)
+	loginThrottleRepository := mocks.NewMockLoginThrottleRepository(mockCtrl)
 	tokenService := mocks.NewMockJwtService(mockCtrl)
+	mailSender := mocks.NewMockMailer(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 
-	userService := service.NewUserService(userRepository, tokenService, logger)
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	userService := service.NewUserService(userRepository, sessionRepository, userTokenRepository, twoFactorRepository, recoveryCodeRepository, loginThrottleRepository, tokenService, mailSender, auditService, metrics.NewMetrics(), logger)
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -191,10 +406,18 @@ func TestUserService_SearchUsers(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	userTokenRepository := mocks.NewMockUserTokenRepository(mockCtrl)
+	twoFactorRepository := mocks.NewMockTwoFactorRepository(mockCtrl)
+	recoveryCodeRepository := mocks.NewMockRecoveryCodeRepository(mockCtrl)
+	loginThrottleRepository := mocks.NewMockLoginThrottleRepository(mockCtrl)
 	tokenService := mocks.NewMockJwtService(mockCtrl)
+	mailSender := mocks.NewMockMailer(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 
-	userService := service.NewUserService(userRepository, tokenService, logger)
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	userService := service.NewUserService(userRepository, sessionRepository, userTokenRepository, twoFactorRepository, recoveryCodeRepository, loginThrottleRepository, tokenService, mailSender, auditService, metrics.NewMetrics(), logger)
 
 	ctx := context.Background()
 	page := &entities.Page[entities.User]{}
@@ -235,50 +458,129 @@ func TestUserService_UpdateUser(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	userTokenRepository := mocks.NewMockUserTokenRepository(mockCtrl)
+	twoFactorRepository := mocks.NewMockTwoFactorRepository(mockCtrl)
+	recoveryCodeRepository := mocks.NewMockRecoveryCodeRepository(mockCtrl)
+	loginThrottleRepository := mocks.NewMockLoginThrottleRepository(mockCtrl)
 	tokenService := mocks.NewMockJwtService(mockCtrl)
+	mailSender := mocks.NewMockMailer(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 
-	userService := service.NewUserService(userRepository, tokenService, logger)
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	userService := service.NewUserService(userRepository, sessionRepository, userTokenRepository, twoFactorRepository, recoveryCodeRepository, loginThrottleRepository, tokenService, mailSender, auditService, metrics.NewMetrics(), logger)
 
 	ctx := context.Background()
 	userID := uuid.New()
+	sessionID := uuid.New()
 	email := "test@example.com"
 	password := "password123"
 	username := "testuser"
 	avatarURL := "http://example.com/avatar.png"
 
+	hashedPassword, _ := security.HashPassword(password)
+	verifiedAt := time.Now()
+	user := &entities.User{
+		ID:              userID,
+		Email:           email,
+		Password:        hashedPassword,
+		RoleID:          entities.RoleAdminID,
+		EmailVerified:   true,
+		EmailVerifiedAt: &verifiedAt,
+	}
+
 	t.Run("should update user successfully", func(t *testing.T) {
-		userRepository.EXPECT().FindByEmail(ctx, email).Return(&entities.User{}, nil)
-		userRepository.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+		userRepository.EXPECT().Find(ctx, userID).Return(user, nil)
+		userRepository.EXPECT().UpdateColumns(ctx, user, map[string]any{"username": username, "avatar_url": avatarURL}).Return(nil)
+
+		err := userService.UpdateUser(ctx, userID, sessionID, email, password, username, avatarURL)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should reset the verification when the email changes", func(t *testing.T) {
+		newEmail := "new@example.com"
+		changedUser := *user
+		userRepository.EXPECT().Find(ctx, userID).Return(&changedUser, nil)
+		userRepository.EXPECT().FindByEmail(ctx, newEmail).Return(nil, gorm.ErrRecordNotFound)
+		userRepository.EXPECT().UpdateColumns(ctx, &changedUser, gomock.Any()).DoAndReturn(func(_ context.Context, _ *entities.User, columns map[string]any) error {
+			assert.Equal(t, newEmail, columns["email"])
+			assert.Equal(t, false, columns["email_verified"])
+			assert.Contains(t, columns, "email_verified_at")
+			assert.NotContains(t, columns, "role_id")
+			return nil
+		})
+		userTokenRepository.EXPECT().InvalidateByUserID(ctx, userID, entities.UserTokenPurposeEmailVerification).Return(nil)
+		userTokenRepository.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, userToken *entities.UserToken) error {
+			assert.Equal(t, newEmail, userToken.Email)
+			return nil
+		})
+		mailSender.EXPECT().Send(ctx, gomock.Any()).Return(nil)
+
+		err := userService.UpdateUser(ctx, userID, sessionID, newEmail, password, username, avatarURL)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should hash the password, revoke the other sessions and record the change", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, userID).Return(user, nil)
+		userRepository.EXPECT().UpdateColumns(ctx, user, gomock.Any()).DoAndReturn(func(_ context.Context, _ *entities.User, columns map[string]any) error {
+			assert.True(t, security.CheckPassword(columns["password"].(string), "newpassword123"))
+			return nil
+		})
+		sessionRepository.EXPECT().RevokeOthersByUserID(ctx, userID, sessionID).Return(nil)
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
 
-		err := userService.UpdateUser(ctx, userID, email, password, username, avatarURL)
+		err := userService.UpdateUser(ctx, userID, sessionID, email, "newpassword123", username, avatarURL)
 
 		assert.Nil(t, err)
 	})
 
+	t.Run("should return error when revoking the other sessions fails", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, userID).Return(user, nil)
+		userRepository.EXPECT().UpdateColumns(ctx, user, gomock.Any()).Return(nil)
+		sessionRepository.EXPECT().RevokeOthersByUserID(ctx, userID, sessionID).Return(errors.New("database error"))
+
+		err := userService.UpdateUser(ctx, userID, sessionID, email, "newpassword123", username, avatarURL)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "An error occurred while revoking the sessions", err.Message)
+	})
+
+	t.Run("should return error when the email is used by another user", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, userID).Return(user, nil)
+		userRepository.EXPECT().FindByEmail(ctx, "taken@example.com").Return(&entities.User{ID: uuid.New()}, nil)
+
+		err := userService.UpdateUser(ctx, userID, sessionID, "taken@example.com", password, username, avatarURL)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The email is already in use", err.Message)
+	})
+
 	t.Run("should return error when user is not found", func(t *testing.T) {
-		userRepository.EXPECT().FindByEmail(ctx, email).Return(nil, gorm.ErrRecordNotFound)
+		userRepository.EXPECT().Find(ctx, userID).Return(nil, gorm.ErrRecordNotFound)
 
-		err := userService.UpdateUser(ctx, userID, email, password, username, avatarURL)
+		err := userService.UpdateUser(ctx, userID, sessionID, email, password, username, avatarURL)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, "The requested user was not found", err.Message)
 	})
 
-	t.Run("should return error when FindByEmail fails", func(t *testing.T) {
-		userRepository.EXPECT().FindByEmail(ctx, email).Return(nil, errors.New("database error"))
+	t.Run("should return error when Find fails", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, userID).Return(nil, errors.New("database error"))
 
-		err := userService.UpdateUser(ctx, userID, email, password, username, avatarURL)
+		err := userService.UpdateUser(ctx, userID, sessionID, email, password, username, avatarURL)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, "An error occurred while finding the user", err.Message)
 	})
 
-	t.Run("should return error when Update fails", func(t *testing.T) {
-		userRepository.EXPECT().FindByEmail(ctx, email).Return(&entities.User{}, nil)
-		userRepository.EXPECT().Update(ctx, gomock.Any()).Return(errors.New("database error"))
+	t.Run("should return error when UpdateColumns fails", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, userID).Return(user, nil)
+		userRepository.EXPECT().UpdateColumns(ctx, user, gomock.Any()).Return(errors.New("database error"))
 
-		err := userService.UpdateUser(ctx, userID, email, password, username, avatarURL)
+		err := userService.UpdateUser(ctx, userID, sessionID, email, password, username, avatarURL)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, "An error occurred while updating the user", err.Message)
@@ -290,10 +592,18 @@ func TestUserService_DeleteUser(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	userTokenRepository := mocks.NewMockUserTokenRepository(mockCtrl)
+	twoFactorRepository := mocks.NewMockTwoFactorRepository(mockCtrl)
+	recoveryCodeRepository := mocks.NewMockRecoveryCodeRepository(mockCtrl)
+	loginThrottleRepository := mocks.NewMockLoginThrottleRepository(mockCtrl)
 	tokenService := mocks.NewMockJwtService(mockCtrl)
+	mailSender := mocks.NewMockMailer(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 
-	userService := service.NewUserService(userRepository, tokenService, logger)
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	userService := service.NewUserService(userRepository, sessionRepository, userTokenRepository, twoFactorRepository, recoveryCodeRepository, loginThrottleRepository, tokenService, mailSender, auditService, metrics.NewMetrics(), logger)
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -301,6 +611,7 @@ func TestUserService_DeleteUser(t *testing.T) {
 	t.Run("should delete user successfully", func(t *testing.T) {
 		userRepository.EXPECT().Find(ctx, userID).Return(&entities.User{}, nil)
 		userRepository.EXPECT().Delete(ctx, userID).Return(nil)
+		auditLogRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
 
 		err := userService.DeleteUser(ctx, userID.String())
 
@@ -335,3 +646,53 @@ func TestUserService_DeleteUser(t *testing.T) {
 		assert.Equal(t, "An error occurred while deleting the user", err.Message)
 	})
 }
+
+func TestUserService_UnlockAccount(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	userRepository := mocks.NewMockUserRepository(mockCtrl)
+	sessionRepository := mocks.NewMockSessionRepository(mockCtrl)
+	userTokenRepository := mocks.NewMockUserTokenRepository(mockCtrl)
+	twoFactorRepository := mocks.NewMockTwoFactorRepository(mockCtrl)
+	recoveryCodeRepository := mocks.NewMockRecoveryCodeRepository(mockCtrl)
+	loginThrottleRepository := mocks.NewMockLoginThrottleRepository(mockCtrl)
+	tokenService := mocks.NewMockJwtService(mockCtrl)
+	mailSender := mocks.NewMockMailer(mockCtrl)
+	auditLogRepository := mocks.NewMockAuditLogRepository(mockCtrl)
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	userService := service.NewUserService(userRepository, sessionRepository, userTokenRepository, twoFactorRepository, recoveryCodeRepository, loginThrottleRepository, tokenService, mailSender, auditService, metrics.NewMetrics(), logger)
+
+	ctx := context.Background()
+	user := &entities.User{ID: uuid.New(), Email: "Test@Example.com"}
+
+	t.Run("should unlock account successfully", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		loginThrottleRepository.EXPECT().Reset(ctx, "account:test@example.com").Return(nil)
+
+		err := userService.UnlockAccount(ctx, user.ID)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return error when user is not found", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, user.ID).Return(nil, gorm.ErrRecordNotFound)
+
+		err := userService.UnlockAccount(ctx, user.ID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "The requested user was not found", err.Message)
+	})
+
+	t.Run("should return error when Reset fails", func(t *testing.T) {
+		userRepository.EXPECT().Find(ctx, user.ID).Return(user, nil)
+		loginThrottleRepository.EXPECT().Reset(ctx, "account:test@example.com").Return(errors.New("database error"))
+
+		err := userService.UnlockAccount(ctx, user.ID)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "An error occurred while unlocking the account", err.Message)
+	})
+}
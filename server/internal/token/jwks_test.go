@@ -0,0 +1,54 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJwks(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 30, 0, 0, time.UTC)
+
+	t.Run("should publish the public half of every key", func(t *testing.T) {
+		store := newTestKeyStore(t.TempDir())
+		assert.NoError(t, store.generateKey(now.Add(-time.Hour)))
+		store.algorithm = jwt.SigningMethodRS256.Alg()
+		assert.NoError(t, store.generateKey(now))
+		assert.NoError(t, store.Reload())
+		keys := store.PublicKeys()
+
+		jwks := NewJwks(keys)
+
+		assert.Len(t, jwks.Keys, 2)
+
+		okp := jwks.Keys[0]
+		assert.Equal(t, "20250310T1130", okp.Kid)
+		assert.Equal(t, "OKP", okp.Kty)
+		assert.Equal(t, "sig", okp.Use)
+		assert.Equal(t, "EdDSA", okp.Alg)
+		assert.Equal(t, "Ed25519", okp.Crv)
+		assert.Equal(t, base64.RawURLEncoding.EncodeToString(keys[0].VerifyKey.(ed25519.PublicKey)), okp.X)
+		assert.Empty(t, okp.N)
+
+		rsaKey := jwks.Keys[1]
+		assert.Equal(t, "20250310T1230", rsaKey.Kid)
+		assert.Equal(t, "RSA", rsaKey.Kty)
+		assert.Equal(t, "RS256", rsaKey.Alg)
+		assert.Equal(t, base64.RawURLEncoding.EncodeToString(keys[1].VerifyKey.(*rsa.PublicKey).N.Bytes()), rsaKey.N)
+		assert.Equal(t, "AQAB", rsaKey.E)
+		assert.Empty(t, rsaKey.X)
+	})
+
+	t.Run("should publish no keys for the shared secret", func(t *testing.T) {
+		store := newHmacKeyStore("secret")
+
+		jwks := NewJwks(store.PublicKeys())
+
+		assert.Empty(t, jwks.Keys)
+	})
+}
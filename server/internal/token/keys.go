@@ -0,0 +1,356 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Bromolima/my-game-list/config"
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	keyFileExtension = ".pem"
+	rsaKeySize       = 2048
+
+	// keyIDLayout is the creation time, in UTC, each key ID encodes. Keys are
+	// ordered by it rather than by file times, which change when the
+	// directory is copied or restored.
+	keyIDLayout = "20060102T1504"
+)
+
+var (
+	ErrUnknownKey          = errors.New("the token was signed with an unknown key")
+	ErrNoSigningKey        = errors.New("no signing key is available")
+	ErrUnsupportedKey      = errors.New("the key type is not supported")
+	ErrUnsupportedSigning  = errors.New("the signing algorithm is not supported")
+	ErrKeyAlreadyGenerated = errors.New("the key was already generated by another process")
+	ErrInvalidKeyID        = errors.New("the key file name is not a key creation time")
+)
+
+type SigningKey struct {
+	ID        string
+	Method    jwt.SigningMethod
+	SignKey   any
+	VerifyKey any
+	CreatedAt time.Time
+}
+
+// KeyStore holds the keys used to sign and verify access tokens. The newest
+// key signs new tokens while older keys are kept for verification until
+// every token they signed has expired.
+type KeyStore interface {
+	SigningKey() (*SigningKey, error)
+	VerificationKey(kid string) (*SigningKey, error)
+	PublicKeys() []*SigningKey
+	Reload() error
+	Rotate(now time.Time) (bool, error)
+}
+
+func NewKeyStore(logger *slog.Logger) (KeyStore, error) {
+	if config.Env.Jwt.KeysDir == "" {
+		return newHmacKeyStore(config.Env.SecretKey), nil
+	}
+
+	store := &fileKeyStore{
+		dir:              config.Env.Jwt.KeysDir,
+		algorithm:        config.Env.Jwt.Algorithm,
+		rotationInterval: config.Env.Jwt.RotationInterval,
+		retention:        config.Env.Jwt.KeyRetention,
+		logger:           logger.With(slog.String("keyStore", "token")),
+	}
+
+	if err := os.MkdirAll(store.dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	if err := store.Reload(); err != nil {
+		return nil, err
+	}
+
+	if len(store.keys) == 0 {
+		if err := store.generateKey(time.Now()); err != nil && !errors.Is(err, ErrKeyAlreadyGenerated) {
+			return nil, err
+		}
+
+		if err := store.Reload(); err != nil {
+			return nil, err
+		}
+	}
+
+	return store, nil
+}
+
+type hmacKeyStore struct {
+	key *SigningKey
+}
+
+func newHmacKeyStore(secret string) KeyStore {
+	return &hmacKeyStore{
+		key: &SigningKey{
+			Method:    jwt.SigningMethodHS256,
+			SignKey:   []byte(secret),
+			VerifyKey: []byte(secret),
+		},
+	}
+}
+
+func (s *hmacKeyStore) SigningKey() (*SigningKey, error) {
+	return s.key, nil
+}
+
+func (s *hmacKeyStore) VerificationKey(kid string) (*SigningKey, error) {
+	if kid != s.key.ID {
+		return nil, ErrUnknownKey
+	}
+
+	return s.key, nil
+}
+
+func (s *hmacKeyStore) PublicKeys() []*SigningKey {
+	return nil
+}
+
+func (s *hmacKeyStore) Reload() error {
+	return nil
+}
+
+func (s *hmacKeyStore) Rotate(time.Time) (bool, error) {
+	return false, nil
+}
+
+type fileKeyStore struct {
+	dir              string
+	algorithm        string
+	rotationInterval time.Duration
+	retention        time.Duration
+	mu               sync.RWMutex
+	keys             []*SigningKey
+	logger           *slog.Logger
+}
+
+func (s *fileKeyStore) SigningKey() (*SigningKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.keys) == 0 {
+		return nil, ErrNoSigningKey
+	}
+
+	return s.keys[len(s.keys)-1], nil
+}
+
+func (s *fileKeyStore) VerificationKey(kid string) (*SigningKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, key := range s.keys {
+		if key.ID == kid {
+			return key, nil
+		}
+	}
+
+	return nil, ErrUnknownKey
+}
+
+func (s *fileKeyStore) PublicKeys() []*SigningKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return slices.Clone(s.keys)
+}
+
+// Reload reads every key in the keys directory, which lets keys added by an
+// operator or by another replica sharing the directory be picked up without
+// a restart. Key files are named after their creation time in UTC, e.g.
+// 20250102T1504.pem.
+func (s *fileKeyStore) Reload() error {
+	log := s.logger.With(slog.String("func", "Reload"))
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		log.Error("Failed to read signing keys directory", slog.String("error", err.Error()))
+		return err
+	}
+
+	keys := make([]*SigningKey, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != keyFileExtension {
+			continue
+		}
+
+		key, err := loadSigningKey(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			log.Warn("Skipping unreadable signing key", slog.String("file", entry.Name()), slog.String("error", err.Error()))
+			continue
+		}
+
+		keys = append(keys, key)
+	}
+
+	slices.SortFunc(keys, func(a, b *SigningKey) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	s.mu.Lock()
+	s.keys = keys
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Rotate generates a new signing key when the current one is older than the
+// rotation interval and removes keys that were superseded longer than the
+// retention period ago.
+func (s *fileKeyStore) Rotate(now time.Time) (bool, error) {
+	log := s.logger.With(slog.String("func", "Rotate"))
+
+	current, err := s.SigningKey()
+	if err != nil && !errors.Is(err, ErrNoSigningKey) {
+		return false, err
+	}
+
+	rotated := false
+	if s.rotationInterval > 0 && (current == nil || now.Sub(current.CreatedAt) >= s.rotationInterval) {
+		if err := s.generateKey(now); err != nil {
+			if !errors.Is(err, ErrKeyAlreadyGenerated) {
+				log.Error("Failed to generate signing key", slog.String("error", err.Error()))
+				return false, err
+			}
+		} else {
+			rotated = true
+		}
+
+		if err := s.Reload(); err != nil {
+			return false, err
+		}
+	}
+
+	s.pruneKeys(now)
+	return rotated, nil
+}
+
+func (s *fileKeyStore) pruneKeys(now time.Time) {
+	log := s.logger.With(slog.String("func", "pruneKeys"))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	retained := make([]*SigningKey, 0, len(s.keys))
+	for i, key := range s.keys {
+		if i < len(s.keys)-1 && now.Sub(s.keys[i+1].CreatedAt) > s.retention {
+			if err := os.Remove(filepath.Join(s.dir, key.ID+keyFileExtension)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				log.Warn("Failed to remove retired signing key", slog.String("kid", key.ID), slog.String("error", err.Error()))
+				retained = append(retained, key)
+			}
+			continue
+		}
+
+		retained = append(retained, key)
+	}
+
+	s.keys = retained
+}
+
+func (s *fileKeyStore) generateKey(now time.Time) error {
+	privateKey, err := generatePrivateKey(s.algorithm)
+	if err != nil {
+		return err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return err
+	}
+
+	// Replicas rotating in the same minute derive the same key ID, so only
+	// the first one to create the file wins.
+	kid := now.UTC().Format(keyIDLayout)
+	path := filepath.Join(s.dir, kid+keyFileExtension)
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return ErrKeyAlreadyGenerated
+		}
+		return err
+	}
+
+	if err := pem.Encode(file, &pem.Block{Type: "PRIVATE KEY", Bytes: der}); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+func generatePrivateKey(algorithm string) (crypto.Signer, error) {
+	switch strings.ToUpper(algorithm) {
+	case jwt.SigningMethodRS256.Alg():
+		return rsa.GenerateKey(rand.Reader, rsaKeySize)
+	case strings.ToUpper(jwt.SigningMethodEdDSA.Alg()):
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		return privateKey, err
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedSigning, algorithm)
+	}
+}
+
+func loadSigningKey(path string) (*SigningKey, error) {
+	kid := strings.TrimSuffix(filepath.Base(path), keyFileExtension)
+	createdAt, err := time.Parse(keyIDLayout, kid)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidKeyID, kid)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, ErrUnsupportedKey
+	}
+
+	var privateKey any
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		privateKey, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key := &SigningKey{
+		ID:        kid,
+		SignKey:   privateKey,
+		CreatedAt: createdAt,
+	}
+
+	switch k := privateKey.(type) {
+	case *rsa.PrivateKey:
+		key.Method = jwt.SigningMethodRS256
+		key.VerifyKey = &k.PublicKey
+	case ed25519.PrivateKey:
+		key.Method = jwt.SigningMethodEdDSA
+		key.VerifyKey = k.Public()
+	default:
+		return nil, ErrUnsupportedKey
+	}
+
+	return key, nil
+}
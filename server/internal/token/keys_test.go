@@ -0,0 +1,211 @@
+package token
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestKeyStore(dir string) *fileKeyStore {
+	return &fileKeyStore{
+		dir:              dir,
+		algorithm:        jwt.SigningMethodEdDSA.Alg(),
+		rotationInterval: 24 * time.Hour,
+		retention:        time.Hour,
+		logger:           slog.New(slog.NewJSONHandler(os.Stdout, nil)),
+	}
+}
+
+func keyIDs(keys []*SigningKey) []string {
+	ids := make([]string, 0, len(keys))
+	for _, key := range keys {
+		ids = append(ids, key.ID)
+	}
+
+	return ids
+}
+
+func TestFileKeyStore_Reload(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 30, 0, 0, time.UTC)
+
+	t.Run("should order keys by the creation time in their ID", func(t *testing.T) {
+		store := newTestKeyStore(t.TempDir())
+		older := now.Add(-48 * time.Hour)
+
+		assert.NoError(t, store.generateKey(now))
+		assert.NoError(t, store.generateKey(older))
+
+		// A restored directory gives every file the same modification time,
+		// or the wrong order altogether.
+		olderPath := filepath.Join(store.dir, older.Format(keyIDLayout)+keyFileExtension)
+		assert.NoError(t, os.Chtimes(olderPath, now.Add(time.Hour), now.Add(time.Hour)))
+
+		err := store.Reload()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"20250308T1230", "20250310T1230"}, keyIDs(store.PublicKeys()))
+
+		signingKey, err := store.SigningKey()
+		assert.NoError(t, err)
+		assert.Equal(t, "20250310T1230", signingKey.ID)
+		assert.Equal(t, now, signingKey.CreatedAt)
+	})
+
+	t.Run("should skip keys not named after their creation time", func(t *testing.T) {
+		store := newTestKeyStore(t.TempDir())
+		assert.NoError(t, store.generateKey(now))
+
+		content, err := os.ReadFile(filepath.Join(store.dir, now.Format(keyIDLayout)+keyFileExtension))
+		assert.NoError(t, err)
+		assert.NoError(t, os.WriteFile(filepath.Join(store.dir, "legacy"+keyFileExtension), content, 0o600))
+		assert.NoError(t, os.WriteFile(filepath.Join(store.dir, "notes.txt"), []byte("notes"), 0o600))
+
+		err = store.Reload()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"20250310T1230"}, keyIDs(store.PublicKeys()))
+	})
+
+	t.Run("should skip unreadable keys", func(t *testing.T) {
+		store := newTestKeyStore(t.TempDir())
+		assert.NoError(t, os.WriteFile(filepath.Join(store.dir, now.Format(keyIDLayout)+keyFileExtension), []byte("not a key"), 0o600))
+
+		err := store.Reload()
+
+		assert.NoError(t, err)
+		assert.Empty(t, store.PublicKeys())
+
+		_, err = store.SigningKey()
+		assert.ErrorIs(t, err, ErrNoSigningKey)
+	})
+
+	t.Run("should return error when the directory does not exist", func(t *testing.T) {
+		store := newTestKeyStore(filepath.Join(t.TempDir(), "missing"))
+
+		err := store.Reload()
+
+		assert.Error(t, err)
+	})
+}
+
+func TestFileKeyStore_Rotate(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 30, 0, 0, time.UTC)
+
+	t.Run("should generate the first key", func(t *testing.T) {
+		store := newTestKeyStore(t.TempDir())
+
+		rotated, err := store.Rotate(now)
+
+		assert.NoError(t, err)
+		assert.True(t, rotated)
+		assert.Equal(t, []string{"20250310T1230"}, keyIDs(store.PublicKeys()))
+	})
+
+	t.Run("should not rotate before the interval elapses", func(t *testing.T) {
+		store := newTestKeyStore(t.TempDir())
+		assert.NoError(t, store.generateKey(now))
+		assert.NoError(t, store.Reload())
+
+		rotated, err := store.Rotate(now.Add(store.rotationInterval - time.Minute))
+
+		assert.NoError(t, err)
+		assert.False(t, rotated)
+		assert.Equal(t, []string{"20250310T1230"}, keyIDs(store.PublicKeys()))
+	})
+
+	t.Run("should sign with the new key once the interval elapses", func(t *testing.T) {
+		store := newTestKeyStore(t.TempDir())
+		assert.NoError(t, store.generateKey(now))
+		assert.NoError(t, store.Reload())
+
+		rotated, err := store.Rotate(now.Add(store.rotationInterval))
+
+		assert.NoError(t, err)
+		assert.True(t, rotated)
+		assert.Equal(t, []string{"20250310T1230", "20250311T1230"}, keyIDs(store.PublicKeys()))
+
+		signingKey, err := store.SigningKey()
+		assert.NoError(t, err)
+		assert.Equal(t, "20250311T1230", signingKey.ID)
+	})
+
+	t.Run("should pick up the key another replica generated in the same minute", func(t *testing.T) {
+		dir := t.TempDir()
+		store := newTestKeyStore(dir)
+		replica := newTestKeyStore(dir)
+		assert.NoError(t, store.generateKey(now))
+		assert.NoError(t, store.Reload())
+		assert.NoError(t, replica.Reload())
+
+		rotatedAt := now.Add(store.rotationInterval)
+		rotated, err := replica.Rotate(rotatedAt)
+		assert.NoError(t, err)
+		assert.True(t, rotated)
+
+		rotated, err = store.Rotate(rotatedAt.Add(30 * time.Second))
+
+		assert.NoError(t, err)
+		assert.False(t, rotated)
+		assert.Equal(t, keyIDs(replica.PublicKeys()), keyIDs(store.PublicKeys()))
+	})
+
+	t.Run("should not rotate when rotation is disabled", func(t *testing.T) {
+		store := newTestKeyStore(t.TempDir())
+		store.rotationInterval = 0
+
+		rotated, err := store.Rotate(now)
+
+		assert.NoError(t, err)
+		assert.False(t, rotated)
+		assert.Empty(t, store.PublicKeys())
+	})
+}
+
+func TestFileKeyStore_PruneKeys(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 30, 0, 0, time.UTC)
+
+	t.Run("should remove keys superseded longer than the retention ago", func(t *testing.T) {
+		store := newTestKeyStore(t.TempDir())
+		for _, createdAt := range []time.Time{now.Add(-72 * time.Hour), now.Add(-48 * time.Hour), now.Add(-30 * time.Minute)} {
+			assert.NoError(t, store.generateKey(createdAt))
+		}
+		assert.NoError(t, store.Reload())
+
+		store.pruneKeys(now)
+
+		assert.Equal(t, []string{"20250308T1230", "20250310T1200"}, keyIDs(store.PublicKeys()))
+
+		_, err := os.Stat(filepath.Join(store.dir, "20250307T1230"+keyFileExtension))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+
+		// The removed key must stay gone after the directory is read again.
+		assert.NoError(t, store.Reload())
+		assert.Equal(t, []string{"20250308T1230", "20250310T1200"}, keyIDs(store.PublicKeys()))
+	})
+
+	t.Run("should keep the signing key however old it is", func(t *testing.T) {
+		store := newTestKeyStore(t.TempDir())
+		assert.NoError(t, store.generateKey(now.Add(-365*24*time.Hour)))
+		assert.NoError(t, store.Reload())
+
+		store.pruneKeys(now)
+
+		assert.Equal(t, []string{"20240310T1230"}, keyIDs(store.PublicKeys()))
+	})
+
+	t.Run("should keep a superseded key within the retention", func(t *testing.T) {
+		store := newTestKeyStore(t.TempDir())
+		assert.NoError(t, store.generateKey(now.Add(-48*time.Hour)))
+		assert.NoError(t, store.generateKey(now.Add(-store.retention)))
+		assert.NoError(t, store.Reload())
+
+		store.pruneKeys(now)
+
+		assert.Equal(t, []string{"20250308T1230", "20250310T1130"}, keyIDs(store.PublicKeys()))
+	})
+}
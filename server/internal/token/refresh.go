@@ -0,0 +1,43 @@
+package token
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/Bromolima/my-game-list/internal/security"
+	"github.com/google/uuid"
+)
+
+const (
+	refreshTokenSize = 32
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("the refresh token is invalid")
+)
+
+// NewRefreshToken builds an opaque refresh token bound to a session. The
+// session ID prefix identifies the token family, which lets a replayed token
+// be traced back to its session even after it has been rotated.
+func NewRefreshToken(sessionID uuid.UUID) (string, error) {
+	secret, err := security.GenerateRandomToken(refreshTokenSize)
+	if err != nil {
+		return "", err
+	}
+
+	return sessionID.String() + "." + secret, nil
+}
+
+func ParseRefreshToken(refreshToken string) (uuid.UUID, error) {
+	rawSessionID, secret, found := strings.Cut(refreshToken, ".")
+	if !found || secret == "" {
+		return uuid.Nil, ErrInvalidRefreshToken
+	}
+
+	sessionID, err := uuid.Parse(rawSessionID)
+	if err != nil {
+		return uuid.Nil, ErrInvalidRefreshToken
+	}
+
+	return sessionID, nil
+}
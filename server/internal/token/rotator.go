@@ -0,0 +1,61 @@
+package token
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Bromolima/my-game-list/internal/lifecycle"
+)
+
+const (
+	keyCheckInterval = time.Minute
+)
+
+type KeyRotator struct {
+	keyStore KeyStore
+	logger   *slog.Logger
+}
+
+// NewKeyRotator registers the rotator as a background worker, running from
+// the application start to its shutdown.
+func NewKeyRotator(keyStore KeyStore, lc *lifecycle.Lifecycle, logger *slog.Logger) *KeyRotator {
+	r := &KeyRotator{
+		keyStore: keyStore,
+		logger:   logger.With(slog.String("keyRotator", "token")),
+	}
+
+	lc.Append(lifecycle.Worker("key rotator", r.Run))
+	return r
+}
+
+// Run periodically reloads the key store and rotates the signing key when it
+// is due, until the context is cancelled.
+func (r *KeyRotator) Run(ctx context.Context) {
+	log := r.logger.With(slog.String("func", "Run"))
+
+	ticker := time.NewTicker(keyCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := r.keyStore.Reload(); err != nil {
+				log.Error("Failed to reload signing keys", slog.String("error", err.Error()))
+				continue
+			}
+
+			rotated, err := r.keyStore.Rotate(now)
+			if err != nil {
+				log.Error("Failed to rotate signing key", slog.String("error", err.Error()))
+				continue
+			}
+
+			if rotated {
+				log.Info("Signing key rotated successfully")
+			}
+		}
+	}
+}
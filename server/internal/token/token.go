@@ -1,7 +1,9 @@
 package token
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Bromolima/my-game-list/config"
@@ -13,83 +15,187 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+const (
+	AccessTokenDuration    = config.AccessTokenDuration
+	RefreshTokenDuration   = 30 * 24 * time.Hour
+	ChallengeTokenDuration = 5 * time.Minute
+
+	challengeTokenType = "two_factor_challenge"
+
+	bearerScheme = "Bearer"
+)
+
+var (
+	ErrInvalidClaims          = errors.New("the token claims are invalid")
+	ErrMissingToken           = errors.New("no token was provided")
+	ErrMalformedAuthorization = errors.New("the authorization header is malformed")
+)
+
 //go:generate mockgen -source=token.go -destination=../../mocks/token_service.go -package=mocks
 type JwtService interface {
-	ValidateToken(echo.Context) error
-	GenerateToken(*entities.User) (string, error)
-	ExtractToken(ectx echo.Context) (*entities.User, error)
+	GenerateToken(user *entities.User, sessionID uuid.UUID) (string, error)
+	ParseToken(tokenString string) (*entities.UserClaims, error)
+	ExtractToken(ectx echo.Context) (*entities.UserClaims, error)
+	GenerateChallengeToken(userID uuid.UUID) (string, error)
+	ParseChallengeToken(tokenString string) (uuid.UUID, error)
 }
 
 type jwtService struct {
-	SecretKey string
+	keyStore KeyStore
 }
 
-func NewJwtService() JwtService {
+func NewJwtService(keyStore KeyStore) JwtService {
 	return &jwtService{
-		SecretKey: config.Env.SecretKey,
+		keyStore: keyStore,
 	}
 }
 
-func (s *jwtService) GenerateToken(user *entities.User) (string, error) {
+func (s *jwtService) GenerateToken(user *entities.User, sessionID uuid.UUID) (string, error) {
 	claims := jwt.MapClaims{
-		"id":      user.ID,
-		"role_id": user.RoleID,
-		"exp":     time.Now().Add(time.Hour * 24).Unix(),
+		"id":         user.ID,
+		"role_id":    user.RoleID,
+		"session_id": sessionID,
+		"exp":        time.Now().Add(AccessTokenDuration).Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.SecretKey))
+	return s.sign(claims)
 }
 
-func (s *jwtService) ValidateToken(ectx echo.Context) error {
-	cookie, err := ectx.Cookie(cookie.CookieName)
-	if err != nil {
-		return err
+// GenerateChallengeToken issues the short-lived token proving that a user
+// passed the password step of a login that still requires a second factor.
+// It carries no session, so it is never accepted as an access token.
+func (s *jwtService) GenerateChallengeToken(userID uuid.UUID) (string, error) {
+	claims := jwt.MapClaims{
+		"id":  userID,
+		"typ": challengeTokenType,
+		"exp": time.Now().Add(ChallengeTokenDuration).Unix(),
 	}
 
-	tokenValue := cookie.Value
-	token, err := jwt.Parse(tokenValue, getIdentificationKey)
+	return s.sign(claims)
+}
+
+func (s *jwtService) ParseChallengeToken(tokenString string) (uuid.UUID, error) {
+	token, err := jwt.Parse(tokenString, s.getVerificationKey)
 	if err != nil {
-		return err
+		return uuid.Nil, err
 	}
 
-	_, ok := token.Claims.(jwt.MapClaims)
-	if !ok || !token.Valid {
-		return err
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid || claims["typ"] != challengeTokenType {
+		return uuid.Nil, ErrInvalidClaims
 	}
 
-	return nil
+	rawID, ok := claims["id"].(string)
+	if !ok {
+		return uuid.Nil, ErrInvalidClaims
+	}
+
+	userID, err := uuid.Parse(rawID)
+	if err != nil {
+		return uuid.Nil, ErrInvalidClaims
+	}
+
+	return userID, nil
 }
 
-func (s *jwtService) ExtractToken(ectx echo.Context) (*entities.User, error) {
-	cookie, err := ectx.Cookie(cookie.CookieName)
+func (s *jwtService) sign(claims jwt.MapClaims) (string, error) {
+	key, err := s.keyStore.SigningKey()
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	tokenString := cookie.Value
-	token, err := jwt.Parse(tokenString, getIdentificationKey)
+	token := jwt.NewWithClaims(key.Method, claims)
+	if key.ID != "" {
+		token.Header["kid"] = key.ID
+	}
+
+	return token.SignedString(key.SignKey)
+}
+
+func (s *jwtService) ParseToken(tokenString string) (*entities.UserClaims, error) {
+	token, err := jwt.Parse(tokenString, s.getVerificationKey)
 	if err != nil {
 		return nil, err
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok || !token.Valid {
+		return nil, ErrInvalidClaims
+	}
+
+	return parseUserClaims(claims)
+}
+
+func (s *jwtService) ExtractToken(ectx echo.Context) (*entities.UserClaims, error) {
+	tokenString, err := GetTokenFromRequest(ectx)
+	if err != nil {
 		return nil, err
 	}
 
-	userClaims := factory.NewUserClaims(
-		claims["id"].(uuid.UUID),
-		claims["role_id"].(uint),
-	)
+	return s.ParseToken(tokenString)
+}
+
+// GetTokenFromRequest returns the raw access token sent with the request. An
+// Authorization header takes precedence over the session cookie because it is
+// set explicitly by the client, while the cookie is attached by the browser
+// to every request.
+func GetTokenFromRequest(ectx echo.Context) (string, error) {
+	if authorization := ectx.Request().Header.Get(echo.HeaderAuthorization); authorization != "" {
+		scheme, tokenString, found := strings.Cut(authorization, " ")
+		if !found || !strings.EqualFold(scheme, bearerScheme) || strings.TrimSpace(tokenString) == "" {
+			return "", ErrMalformedAuthorization
+		}
+
+		return strings.TrimSpace(tokenString), nil
+	}
+
+	cookie, err := ectx.Cookie(cookie.CookieName)
+	if err != nil {
+		return "", ErrMissingToken
+	}
 
-	return userClaims, nil
+	return cookie.Value, nil
 }
 
-func getIdentificationKey(token *jwt.Token) (any, error) {
-	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+func parseUserClaims(claims jwt.MapClaims) (*entities.UserClaims, error) {
+	rawID, ok := claims["id"].(string)
+	if !ok {
+		return nil, ErrInvalidClaims
+	}
+
+	userID, err := uuid.Parse(rawID)
+	if err != nil {
+		return nil, ErrInvalidClaims
+	}
+
+	rawSessionID, ok := claims["session_id"].(string)
+	if !ok {
+		return nil, ErrInvalidClaims
+	}
+
+	sessionID, err := uuid.Parse(rawSessionID)
+	if err != nil {
+		return nil, ErrInvalidClaims
+	}
+
+	roleID, ok := claims["role_id"].(float64)
+	if !ok {
+		return nil, ErrInvalidClaims
+	}
+
+	return factory.NewUserClaims(userID, uint(roleID), sessionID), nil
+}
+
+func (s *jwtService) getVerificationKey(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, err := s.keyStore.VerificationKey(kid)
+	if err != nil {
+		return nil, err
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
 		return nil, fmt.Errorf("signing method unexpected! %v", token.Header["alg"])
 	}
 
-	return []byte(config.Env.SecretKey), nil
+	return key.VerifyKey, nil
 }
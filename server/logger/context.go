@@ -0,0 +1,65 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type requestLoggerKey struct{}
+
+// requestLogger keeps the attributes of the request apart from the logger, so
+// they can be added to the loggers of the components handling the request.
+type requestLogger struct {
+	logger *slog.Logger
+	attrs  []any
+}
+
+// WithRequest stores in ctx the logger of a request or a command, made of
+// logger and the attributes identifying the request, such as its ID.
+func WithRequest(ctx context.Context, logger *slog.Logger, attrs ...slog.Attr) context.Context {
+	args := make([]any, 0, len(attrs))
+	for _, attr := range attrs {
+		args = append(args, attr)
+	}
+
+	return context.WithValue(ctx, requestLoggerKey{}, &requestLogger{
+		logger: logger,
+		attrs:  args,
+	})
+}
+
+// FromContext returns the logger of the request ctx belongs to, or the
+// default logger outside of a request.
+func FromContext(ctx context.Context) *slog.Logger {
+	if requestLogger, ok := ctx.Value(requestLoggerKey{}).(*requestLogger); ok {
+		return Scoped(ctx, requestLogger.logger)
+	}
+
+	return Scoped(ctx, slog.Default())
+}
+
+// Scoped returns the logger of a component with the attributes of the request
+// in ctx and the IDs of the current span, so the lines logged by every layer
+// can be tied to the request and its trace. The component logger is returned
+// as is outside of a request and a trace.
+func Scoped(ctx context.Context, logger *slog.Logger) *slog.Logger {
+	var args []any
+	if requestLogger, ok := ctx.Value(requestLoggerKey{}).(*requestLogger); ok {
+		args = append(args, requestLogger.attrs...)
+	}
+
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		args = append(args,
+			slog.String("trace_id", spanContext.TraceID().String()),
+			slog.String("span_id", spanContext.SpanID().String()),
+		)
+	}
+
+	if len(args) == 0 {
+		return logger
+	}
+
+	return logger.With(args...)
+}
@@ -1,15 +1,112 @@
 package logger
 
 import (
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Bromolima/my-game-list/config"
+	"gopkg.in/natefinch/lumberjack.v2"
+	gormlogger "gorm.io/gorm/logger"
 )
 
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+
+	OutputStdout = "stdout"
+	OutputStderr = "stderr"
+	OutputFile   = "file"
+)
+
+var gormLevels = map[string]gormlogger.LogLevel{
+	"silent": gormlogger.Silent,
+	"error":  gormlogger.Error,
+	"warn":   gormlogger.Warn,
+	"info":   gormlogger.Info,
+}
+
+// NewLogger returns the logger configured by Setup, which every component
+// derives its own logger from.
 func NewLogger() *slog.Logger {
-	return slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	return slog.Default()
+}
+
+// Setup replaces the default logger with the one described by the LOG_*
+// settings, wrapped in the redacting handler. It must be called once the
+// environment is loaded.
+func Setup(cfg config.Log) error {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		return fmt.Errorf("invalid log level %q: %w", cfg.Level, err)
+	}
+
+	output, err := newOutput(cfg)
+	if err != nil {
+		return err
+	}
+
+	options := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+	switch cfg.Format {
+	case FormatJSON:
+		handler = slog.NewJSONHandler(output, options)
+	case FormatText:
+		handler = slog.NewTextHandler(output, options)
+	default:
+		return fmt.Errorf("unknown log format %q", cfg.Format)
+	}
+
+	slog.SetDefault(slog.New(NewRedactingHandler(handler)))
+	return nil
+}
+
+// NewGormLogger returns the gorm logger, which writes through the default
+// logger at the DB_LOG_LEVEL level. Queries are logged with placeholders, so
+// the values bound to them never reach the logs.
+func NewGormLogger(cfg config.Log) (gormlogger.Interface, error) {
+	level, ok := gormLevels[cfg.GormLevel]
+	if !ok {
+		return nil, fmt.Errorf("unknown database log level %q", cfg.GormLevel)
+	}
+
+	return gormlogger.NewSlogLogger(slog.Default().With(slog.String("database", "gorm")), gormlogger.Config{
+		SlowThreshold:             time.Second,
+		LogLevel:                  level,
+		IgnoreRecordNotFoundError: true,
+		ParameterizedQueries:      true,
+	}), nil
+}
+
+func newOutput(cfg config.Log) (io.Writer, error) {
+	switch cfg.Output {
+	case OutputStdout, "":
+		return os.Stdout, nil
+	case OutputStderr:
+		return os.Stderr, nil
+	case OutputFile:
+		if err := os.MkdirAll(filepath.Dir(cfg.File), 0o755); err != nil {
+			return nil, err
+		}
+
+		return &lumberjack.Logger{
+			Filename:   cfg.File,
+			MaxSize:    cfg.MaxSizeMB,
+			MaxBackups: cfg.MaxBackups,
+			MaxAge:     cfg.MaxAgeDays,
+			Compress:   cfg.Compress,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown log output %q", cfg.Output)
+	}
 }
 
+// init installs a JSON logger on the standard output for the lines logged
+// before the environment is loaded and Setup is called.
 func init() {
-	l := slog.New(slog.NewJSONHandler(os.Stdout, nil))
-	slog.SetDefault(l)
+	slog.SetDefault(slog.New(NewRedactingHandler(slog.NewJSONHandler(os.Stdout, nil))))
 }
@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: access_cache.go
+//
+// Generated by this command:
+//
+//	mockgen -source=access_cache.go -destination=../../mocks/access_cache.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/Bromolima/my-game-list/internal/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAccessCache is a mock of AccessCache interface.
+type MockAccessCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccessCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockAccessCacheMockRecorder is the mock recorder for MockAccessCache.
+type MockAccessCacheMockRecorder struct {
+	mock *MockAccessCache
+}
+
+// NewMockAccessCache creates a new mock instance.
+func NewMockAccessCache(ctrl *gomock.Controller) *MockAccessCache {
+	mock := &MockAccessCache{ctrl: ctrl}
+	mock.recorder = &MockAccessCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccessCache) EXPECT() *MockAccessCacheMockRecorder {
+	return m.recorder
+}
+
+// HasAccess mocks base method.
+func (m *MockAccessCache) HasAccess(ctx context.Context, roleID uint, access entities.AccessType) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasAccess", ctx, roleID, access)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasAccess indicates an expected call of HasAccess.
+func (mr *MockAccessCacheMockRecorder) HasAccess(ctx, roleID, access any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasAccess", reflect.TypeOf((*MockAccessCache)(nil).HasAccess), ctx, roleID, access)
+}
+
+// Invalidate mocks base method.
+func (m *MockAccessCache) Invalidate() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Invalidate")
+}
+
+// Invalidate indicates an expected call of Invalidate.
+func (mr *MockAccessCacheMockRecorder) Invalidate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidate", reflect.TypeOf((*MockAccessCache)(nil).Invalidate))
+}
@@ -85,6 +85,21 @@ func (mr *MockGameListRepositoryMockRecorder) Find(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockGameListRepository)(nil).Find), ctx, id)
 }
 
+// FindByUserID mocks base method.
+func (m *MockGameListRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.GameList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*entities.GameList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockGameListRepositoryMockRecorder) FindByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockGameListRepository)(nil).FindByUserID), ctx, userID)
+}
+
 // FindGamesByListID mocks base method.
 func (m *MockGameListRepository) FindGamesByListID(ctx context.Context, listID uuid.UUID) ([]*entities.Game, error) {
 	m.ctrl.T.Helper()
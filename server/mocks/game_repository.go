@@ -85,6 +85,21 @@ func (mr *MockGameRepositoryMockRecorder) Find(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockGameRepository)(nil).Find), ctx, id)
 }
 
+// RecalculateRatings mocks base method.
+func (m *MockGameRepository) RecalculateRatings(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecalculateRatings", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecalculateRatings indicates an expected call of RecalculateRatings.
+func (mr *MockGameRepositoryMockRecorder) RecalculateRatings(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecalculateRatings", reflect.TypeOf((*MockGameRepository)(nil).RecalculateRatings), ctx)
+}
+
 // Search mocks base method.
 func (m *MockGameRepository) Search(ctx context.Context, page *entities.Page[entities.Game], query string) (*entities.Page[entities.Game], error) {
 	m.ctrl.T.Helper()
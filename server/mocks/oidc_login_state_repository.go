@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: oidc_login_state.go
+//
+// Generated by this command:
+//
+//	mockgen -source=oidc_login_state.go -destination=../../mocks/oidc_login_state_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entities "github.com/Bromolima/my-game-list/internal/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOIDCLoginStateRepository is a mock of OIDCLoginStateRepository interface.
+type MockOIDCLoginStateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOIDCLoginStateRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOIDCLoginStateRepositoryMockRecorder is the mock recorder for MockOIDCLoginStateRepository.
+type MockOIDCLoginStateRepositoryMockRecorder struct {
+	mock *MockOIDCLoginStateRepository
+}
+
+// NewMockOIDCLoginStateRepository creates a new mock instance.
+func NewMockOIDCLoginStateRepository(ctrl *gomock.Controller) *MockOIDCLoginStateRepository {
+	mock := &MockOIDCLoginStateRepository{ctrl: ctrl}
+	mock.recorder = &MockOIDCLoginStateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOIDCLoginStateRepository) EXPECT() *MockOIDCLoginStateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Consume mocks base method.
+func (m *MockOIDCLoginStateRepository) Consume(ctx context.Context, hashedState string) (*entities.OIDCLoginState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", ctx, hashedState)
+	ret0, _ := ret[0].(*entities.OIDCLoginState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockOIDCLoginStateRepositoryMockRecorder) Consume(ctx, hashedState any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockOIDCLoginStateRepository)(nil).Consume), ctx, hashedState)
+}
+
+// Create mocks base method.
+func (m *MockOIDCLoginStateRepository) Create(ctx context.Context, loginState *entities.OIDCLoginState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, loginState)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOIDCLoginStateRepositoryMockRecorder) Create(ctx, loginState any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOIDCLoginStateRepository)(nil).Create), ctx, loginState)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockOIDCLoginStateRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockOIDCLoginStateRepositoryMockRecorder) DeleteExpired(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockOIDCLoginStateRepository)(nil).DeleteExpired), ctx, now)
+}
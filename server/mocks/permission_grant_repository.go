@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: permission_grant.go
+//
+// Generated by this command:
+//
+//	mockgen -source=permission_grant.go -destination=../../mocks/permission_grant_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/Bromolima/my-game-list/internal/entities"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPermissionGrantRepository is a mock of PermissionGrantRepository interface.
+type MockPermissionGrantRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPermissionGrantRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPermissionGrantRepositoryMockRecorder is the mock recorder for MockPermissionGrantRepository.
+type MockPermissionGrantRepositoryMockRecorder struct {
+	mock *MockPermissionGrantRepository
+}
+
+// NewMockPermissionGrantRepository creates a new mock instance.
+func NewMockPermissionGrantRepository(ctrl *gomock.Controller) *MockPermissionGrantRepository {
+	mock := &MockPermissionGrantRepository{ctrl: ctrl}
+	mock.recorder = &MockPermissionGrantRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPermissionGrantRepository) EXPECT() *MockPermissionGrantRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPermissionGrantRepository) Create(ctx context.Context, entity *entities.PermissionGrant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPermissionGrantRepositoryMockRecorder) Create(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPermissionGrantRepository)(nil).Create), ctx, entity)
+}
+
+// Delete mocks base method.
+func (m *MockPermissionGrantRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPermissionGrantRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPermissionGrantRepository)(nil).Delete), ctx, id)
+}
+
+// DeleteForResource mocks base method.
+func (m *MockPermissionGrantRepository) DeleteForResource(ctx context.Context, userID uuid.UUID, resourceType entities.ResourceType, resourceID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteForResource", ctx, userID, resourceType, resourceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteForResource indicates an expected call of DeleteForResource.
+func (mr *MockPermissionGrantRepositoryMockRecorder) DeleteForResource(ctx, userID, resourceType, resourceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteForResource", reflect.TypeOf((*MockPermissionGrantRepository)(nil).DeleteForResource), ctx, userID, resourceType, resourceID)
+}
+
+// Find mocks base method.
+func (m *MockPermissionGrantRepository) Find(ctx context.Context, id uuid.UUID) (*entities.PermissionGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, id)
+	ret0, _ := ret[0].(*entities.PermissionGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockPermissionGrantRepositoryMockRecorder) Find(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockPermissionGrantRepository)(nil).Find), ctx, id)
+}
+
+// FindAll mocks base method.
+func (m *MockPermissionGrantRepository) FindAll(ctx context.Context) ([]*entities.PermissionGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]*entities.PermissionGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPermissionGrantRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPermissionGrantRepository)(nil).FindAll), ctx)
+}
+
+// FindForSubject mocks base method.
+func (m *MockPermissionGrantRepository) FindForSubject(ctx context.Context, userID uuid.UUID, resourceType entities.ResourceType, actions []entities.PermissionAction) ([]*entities.PermissionGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindForSubject", ctx, userID, resourceType, actions)
+	ret0, _ := ret[0].([]*entities.PermissionGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindForSubject indicates an expected call of FindForSubject.
+func (mr *MockPermissionGrantRepositoryMockRecorder) FindForSubject(ctx, userID, resourceType, actions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindForSubject", reflect.TypeOf((*MockPermissionGrantRepository)(nil).FindForSubject), ctx, userID, resourceType, actions)
+}
+
+// Update mocks base method.
+func (m *MockPermissionGrantRepository) Update(ctx context.Context, entity *entities.PermissionGrant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPermissionGrantRepositoryMockRecorder) Update(ctx, entity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPermissionGrantRepository)(nil).Update), ctx, entity)
+}
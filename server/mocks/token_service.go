@@ -13,6 +13,7 @@ import (
 	reflect "reflect"
 
 	entities "github.com/Bromolima/my-game-list/internal/entities"
+	uuid "github.com/google/uuid"
 	echo "github.com/labstack/echo/v4"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -42,10 +43,10 @@ func (m *MockJwtService) EXPECT() *MockJwtServiceMockRecorder {
 }
 
 // ExtractToken mocks base method.
-func (m *MockJwtService) ExtractToken(ectx echo.Context) (*entities.User, error) {
+func (m *MockJwtService) ExtractToken(ectx echo.Context) (*entities.UserClaims, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ExtractToken", ectx)
-	ret0, _ := ret[0].(*entities.User)
+	ret0, _ := ret[0].(*entities.UserClaims)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -56,31 +57,62 @@ func (mr *MockJwtServiceMockRecorder) ExtractToken(ectx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtractToken", reflect.TypeOf((*MockJwtService)(nil).ExtractToken), ectx)
 }
 
+// GenerateChallengeToken mocks base method.
+func (m *MockJwtService) GenerateChallengeToken(userID uuid.UUID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateChallengeToken", userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateChallengeToken indicates an expected call of GenerateChallengeToken.
+func (mr *MockJwtServiceMockRecorder) GenerateChallengeToken(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateChallengeToken", reflect.TypeOf((*MockJwtService)(nil).GenerateChallengeToken), userID)
+}
+
 // GenerateToken mocks base method.
-func (m *MockJwtService) GenerateToken(arg0 *entities.User) (string, error) {
+func (m *MockJwtService) GenerateToken(user *entities.User, sessionID uuid.UUID) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateToken", arg0)
+	ret := m.ctrl.Call(m, "GenerateToken", user, sessionID)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GenerateToken indicates an expected call of GenerateToken.
-func (mr *MockJwtServiceMockRecorder) GenerateToken(arg0 any) *gomock.Call {
+func (mr *MockJwtServiceMockRecorder) GenerateToken(user, sessionID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockJwtService)(nil).GenerateToken), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockJwtService)(nil).GenerateToken), user, sessionID)
 }
 
-// ValidateToken mocks base method.
-func (m *MockJwtService) ValidateToken(arg0 echo.Context) error {
+// ParseChallengeToken mocks base method.
+func (m *MockJwtService) ParseChallengeToken(tokenString string) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ValidateToken", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ParseChallengeToken", tokenString)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseChallengeToken indicates an expected call of ParseChallengeToken.
+func (mr *MockJwtServiceMockRecorder) ParseChallengeToken(tokenString any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseChallengeToken", reflect.TypeOf((*MockJwtService)(nil).ParseChallengeToken), tokenString)
+}
+
+// ParseToken mocks base method.
+func (m *MockJwtService) ParseToken(tokenString string) (*entities.UserClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseToken", tokenString)
+	ret0, _ := ret[0].(*entities.UserClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// ValidateToken indicates an expected call of ValidateToken.
-func (mr *MockJwtServiceMockRecorder) ValidateToken(arg0 any) *gomock.Call {
+// ParseToken indicates an expected call of ParseToken.
+func (mr *MockJwtServiceMockRecorder) ParseToken(tokenString any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateToken", reflect.TypeOf((*MockJwtService)(nil).ValidateToken), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockJwtService)(nil).ParseToken), tokenString)
 }